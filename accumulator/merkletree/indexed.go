@@ -0,0 +1,455 @@
+package merkletree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+	"slices"
+)
+
+var (
+	ErrInvalidKey  = errors.New("keys and values must be h.Size() bytes long, and keys non-zero")
+	ErrKeyExists   = errors.New("the key is already in the tree")
+	ErrKeyNotFound = errors.New("the key is not in the tree")
+	ErrTreeFull    = errors.New("the tree is full")
+)
+
+// IndexedTree is an indexed Merkle tree: a SparseTree whose leaves form a
+// linked list of key-value pairs sorted by key. Each leaf holds, with its key
+// and value, the index and the key of the leaf of the next larger key, so that
+// the absence of a key is proven by the leaf of the next smaller key, the
+// "low leaf", which skips it.
+//
+// The keys and values are byte strings of h.Size() bytes, compared as
+// big-endian integers. The leaf 0 holds the zero key, smaller than all the
+// others, and the last leaf of the list points to index 0 and to the zero key.
+// A leaf is hashed as h(key ‖ value ‖ nextIndex ‖ nextKey), where nextIndex
+// is encoded on h.Size() bytes in big-endian, so that field hashers can be
+// used when the keys and values are encodings of field elements.
+//
+// Leaves are appended at the first never used index; the index of a deleted
+// key is not reused.
+type IndexedTree struct {
+	tree *SparseTree
+	// keys are the keys in the tree, sorted, and indices their positions
+	keys    [][]byte
+	indices []uint64
+	leaves  map[uint64]*IndexedLeaf
+	next    uint64
+}
+
+// IndexedLeaf is a leaf of an IndexedTree.
+type IndexedLeaf struct {
+	Key, Value []byte
+	NextIndex  uint64
+	NextKey    []byte
+}
+
+// IndexedProof is a proof of a leaf of an IndexedTree. It proves that a key
+// is in the tree if Leaf.Key is the key, and that a key is not in the tree if
+// Leaf is its low leaf.
+type IndexedProof struct {
+	Leaf IndexedLeaf
+	Path SparseProof
+}
+
+// IndexedInsertProof proves the insertion of a key: Low is the proof of the
+// low leaf of the key before the insertion, and New is the proof of the empty
+// leaf at which the key is inserted, after the update of the low leaf.
+type IndexedInsertProof struct {
+	Low IndexedProof
+	New SparseProof
+}
+
+// IndexedDeleteProof proves the deletion of a key: Low is the proof of the
+// leaf pointing to the key before the deletion, and Leaf the proof of the
+// leaf of the key after the update of the low leaf.
+type IndexedDeleteProof struct {
+	Low  IndexedProof
+	Leaf IndexedProof
+}
+
+// NewIndexed returns an indexed tree of the given depth, holding the leaf of
+// the zero key with a zero value.
+func NewIndexed(h hash.Hash, depth int) (*IndexedTree, error) {
+	tree, err := NewSparse(h, depth)
+	if err != nil {
+		return nil, err
+	}
+	t := &IndexedTree{
+		tree:   tree,
+		leaves: make(map[uint64]*IndexedLeaf),
+	}
+	zero := make([]byte, h.Size())
+	t.keys = [][]byte{zero}
+	t.indices = []uint64{0}
+	t.setLeaf(0, &IndexedLeaf{Key: zero, Value: zero, NextKey: zero})
+	t.next = 1
+	return t, nil
+}
+
+// Root returns the Merkle root of the tree.
+func (t *IndexedTree) Root() []byte {
+	return t.tree.Root()
+}
+
+// Get returns the value of key, or ErrKeyNotFound.
+func (t *IndexedTree) Get(key []byte) ([]byte, error) {
+	if err := t.checkKey(key); err != nil {
+		return nil, err
+	}
+	i, found := t.search(key)
+	if !found {
+		return nil, ErrKeyNotFound
+	}
+	return bytes.Clone(t.leaves[t.indices[i]].Value), nil
+}
+
+// Prove returns a proof of the leaf of key if it is in the tree, and of its
+// low leaf otherwise.
+func (t *IndexedTree) Prove(key []byte) (IndexedProof, error) {
+	if err := t.checkKey(key); err != nil {
+		return IndexedProof{}, err
+	}
+	i, found := t.search(key)
+	if !found {
+		i--
+	}
+	return t.prove(t.indices[i])
+}
+
+// Insert adds key with the given value to the tree.
+func (t *IndexedTree) Insert(key, value []byte) (IndexedInsertProof, error) {
+	if err := t.checkKey(key); err != nil {
+		return IndexedInsertProof{}, err
+	}
+	if len(value) != t.tree.hash.Size() {
+		return IndexedInsertProof{}, ErrInvalidKey
+	}
+	i, found := t.search(key)
+	if found {
+		return IndexedInsertProof{}, ErrKeyExists
+	}
+	if !t.tree.inRange(t.next) {
+		return IndexedInsertProof{}, ErrTreeFull
+	}
+
+	var proof IndexedInsertProof
+	var err error
+	lowIndex := t.indices[i-1]
+	if proof.Low, err = t.prove(lowIndex); err != nil {
+		return IndexedInsertProof{}, err
+	}
+	low := *t.leaves[lowIndex]
+	leaf := &IndexedLeaf{
+		Key:       bytes.Clone(key),
+		Value:     bytes.Clone(value),
+		NextIndex: low.NextIndex,
+		NextKey:   low.NextKey,
+	}
+	low.NextIndex, low.NextKey = t.next, leaf.Key
+	t.setLeaf(lowIndex, &low)
+	if proof.New, err = t.tree.Prove(t.next); err != nil {
+		return IndexedInsertProof{}, err
+	}
+	t.setLeaf(t.next, leaf)
+
+	t.keys = slices.Insert(t.keys, i, leaf.Key)
+	t.indices = slices.Insert(t.indices, i, t.next)
+	t.next++
+	return proof, nil
+}
+
+// BatchInsert inserts the keys with the given values, in order, and returns
+// the proofs of the insertions.
+func (t *IndexedTree) BatchInsert(keys, values [][]byte) ([]IndexedInsertProof, error) {
+	if len(keys) != len(values) {
+		return nil, errors.New("the number of keys and of values differ")
+	}
+	proofs := make([]IndexedInsertProof, len(keys))
+	for i := range keys {
+		var err error
+		if proofs[i], err = t.Insert(keys[i], values[i]); err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
+// Update sets the value of key, which must be in the tree, and returns the
+// proof of its leaf before the update.
+func (t *IndexedTree) Update(key, value []byte) (IndexedProof, error) {
+	if err := t.checkKey(key); err != nil {
+		return IndexedProof{}, err
+	}
+	if len(value) != t.tree.hash.Size() {
+		return IndexedProof{}, ErrInvalidKey
+	}
+	i, found := t.search(key)
+	if !found {
+		return IndexedProof{}, ErrKeyNotFound
+	}
+	index := t.indices[i]
+	proof, err := t.prove(index)
+	if err != nil {
+		return IndexedProof{}, err
+	}
+	leaf := *t.leaves[index]
+	leaf.Value = bytes.Clone(value)
+	t.setLeaf(index, &leaf)
+	return proof, nil
+}
+
+// Delete removes key from the tree, emptying its leaf.
+func (t *IndexedTree) Delete(key []byte) (IndexedDeleteProof, error) {
+	if err := t.checkKey(key); err != nil {
+		return IndexedDeleteProof{}, err
+	}
+	i, found := t.search(key)
+	if !found {
+		return IndexedDeleteProof{}, ErrKeyNotFound
+	}
+	lowIndex, index := t.indices[i-1], t.indices[i]
+
+	var proof IndexedDeleteProof
+	var err error
+	if proof.Low, err = t.prove(lowIndex); err != nil {
+		return IndexedDeleteProof{}, err
+	}
+	leaf := t.leaves[index]
+	low := *t.leaves[lowIndex]
+	low.NextIndex, low.NextKey = leaf.NextIndex, leaf.NextKey
+	t.setLeaf(lowIndex, &low)
+	if proof.Leaf, err = t.prove(index); err != nil {
+		return IndexedDeleteProof{}, err
+	}
+	t.setLeaf(index, nil)
+
+	t.keys = slices.Delete(t.keys, i, i+1)
+	t.indices = slices.Delete(t.indices, i, i+1)
+	return proof, nil
+}
+
+// VerifyIndexedMembership returns true if proof proves that the indexed tree
+// of the given depth and of root merkleRoot maps key to value.
+func VerifyIndexedMembership(h hash.Hash, merkleRoot []byte, depth int, key, value []byte, proof *IndexedProof) bool {
+	return bytes.Equal(proof.Leaf.Key, key) && bytes.Equal(proof.Leaf.Value, value) &&
+		!isZero(key) && proof.Leaf.verify(h, merkleRoot, depth, &proof.Path)
+}
+
+// VerifyIndexedNonMembership returns true if proof proves that key is not in
+// the indexed tree of the given depth and of root merkleRoot.
+func VerifyIndexedNonMembership(h hash.Hash, merkleRoot []byte, depth int, key []byte, proof *IndexedProof) bool {
+	return proof.Leaf.skips(key) && proof.Leaf.verify(h, merkleRoot, depth, &proof.Path)
+}
+
+// VerifyIndexedInsert returns true if proof proves that inserting key with
+// value in the indexed tree of the given depth and of root oldRoot results in
+// the tree of root newRoot.
+func VerifyIndexedInsert(h hash.Hash, oldRoot, newRoot []byte, depth int, key, value []byte, proof *IndexedInsertProof) bool {
+	if len(key) != h.Size() || len(value) != h.Size() || !VerifyIndexedNonMembership(h, oldRoot, depth, key, &proof.Low) {
+		return false
+	}
+	low := proof.Low.Leaf
+	leaf := IndexedLeaf{Key: key, Value: value, NextIndex: low.NextIndex, NextKey: low.NextKey}
+	low.NextIndex, low.NextKey = proof.New.Index, key
+	root := low.computeRoot(h, &proof.Low.Path)
+	if !VerifySparseProof(h, root, depth, nil, &proof.New) {
+		return false
+	}
+	return bytes.Equal(leaf.computeRoot(h, &proof.New), newRoot)
+}
+
+// VerifyIndexedUpdate returns true if proof proves that setting the value of
+// key in the indexed tree of the given depth and of root oldRoot results in the
+// tree of root newRoot.
+func VerifyIndexedUpdate(h hash.Hash, oldRoot, newRoot []byte, depth int, key, value []byte, proof *IndexedProof) bool {
+	if len(value) != h.Size() || !VerifyIndexedMembership(h, oldRoot, depth, key, proof.Leaf.Value, proof) {
+		return false
+	}
+	leaf := proof.Leaf
+	leaf.Value = value
+	return bytes.Equal(leaf.computeRoot(h, &proof.Path), newRoot)
+}
+
+// VerifyIndexedDelete returns true if proof proves that deleting key from the
+// indexed tree of the given depth and of root oldRoot results in the tree of
+// root newRoot.
+func VerifyIndexedDelete(h hash.Hash, oldRoot, newRoot []byte, depth int, key []byte, proof *IndexedDeleteProof) bool {
+	low, leaf := proof.Low.Leaf, proof.Leaf.Leaf
+	if !bytes.Equal(low.NextKey, key) || low.NextIndex != proof.Leaf.Path.Index ||
+		!bytes.Equal(leaf.Key, key) || isZero(key) ||
+		!low.verify(h, oldRoot, depth, &proof.Low.Path) {
+		return false
+	}
+	low.NextIndex, low.NextKey = leaf.NextIndex, leaf.NextKey
+	root := low.computeRoot(h, &proof.Low.Path)
+	if !leaf.verify(h, root, depth, &proof.Leaf.Path) {
+		return false
+	}
+	return bytes.Equal(proof.Leaf.Path.ComputeRoot(h, nil), newRoot)
+}
+
+// WriteTo writes the binary encoding of the proof: the key, value, next
+// index and next key of the leaf, followed by the path.
+func (p *IndexedProof) WriteTo(w io.Writer) (int64, error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], p.Leaf.NextIndex)
+	written, err := writeByteSlices(w, [][]byte{p.Leaf.Key, p.Leaf.Value, buf[:], p.Leaf.NextKey})
+	if err != nil {
+		return written, err
+	}
+	n, err := p.Path.WriteTo(w)
+	return written + n, err
+}
+
+// ReadFrom reads the binary encoding of the proof written by WriteTo.
+func (p *IndexedProof) ReadFrom(r io.Reader) (int64, error) {
+	s, read, err := readByteSlices(r)
+	if err != nil {
+		return read, err
+	}
+	if len(s) != 4 || len(s[2]) != 8 {
+		return read, errors.New("invalid encoding of an indexed leaf")
+	}
+	p.Leaf = IndexedLeaf{Key: s[0], Value: s[1], NextIndex: binary.BigEndian.Uint64(s[2]), NextKey: s[3]}
+	n, err := p.Path.ReadFrom(r)
+	return read + n, err
+}
+
+// WriteTo writes the binary encoding of the proof.
+func (p *IndexedInsertProof) WriteTo(w io.Writer) (int64, error) {
+	written, err := p.Low.WriteTo(w)
+	if err != nil {
+		return written, err
+	}
+	n, err := p.New.WriteTo(w)
+	return written + n, err
+}
+
+// ReadFrom reads the binary encoding of the proof written by WriteTo.
+func (p *IndexedInsertProof) ReadFrom(r io.Reader) (int64, error) {
+	read, err := p.Low.ReadFrom(r)
+	if err != nil {
+		return read, err
+	}
+	n, err := p.New.ReadFrom(r)
+	return read + n, err
+}
+
+// WriteTo writes the binary encoding of the proof.
+func (p *IndexedDeleteProof) WriteTo(w io.Writer) (int64, error) {
+	written, err := p.Low.WriteTo(w)
+	if err != nil {
+		return written, err
+	}
+	n, err := p.Leaf.WriteTo(w)
+	return written + n, err
+}
+
+// ReadFrom reads the binary encoding of the proof written by WriteTo.
+func (p *IndexedDeleteProof) ReadFrom(r io.Reader) (int64, error) {
+	read, err := p.Low.ReadFrom(r)
+	if err != nil {
+		return read, err
+	}
+	n, err := p.Leaf.ReadFrom(r)
+	return read + n, err
+}
+
+// encode returns key ‖ value ‖ nextIndex ‖ nextKey, with nextIndex on
+// len(key) bytes
+func (l *IndexedLeaf) encode() []byte {
+	size := len(l.Key)
+	res := make([]byte, 4*size)
+	copy(res, l.Key)
+	copy(res[size:], l.Value)
+	var index [8]byte
+	binary.BigEndian.PutUint64(index[:], l.NextIndex)
+	if size >= 8 {
+		copy(res[3*size-8:], index[:])
+	} else {
+		copy(res[2*size:], index[8-size:])
+	}
+	copy(res[3*size:], l.NextKey)
+	return res
+}
+
+// computeRoot returns the root of the tree of path in which l is at path.Index
+func (l *IndexedLeaf) computeRoot(h hash.Hash, path *SparseProof) []byte {
+	return path.ComputeRoot(h, l.encode())
+}
+
+// verify returns true if l is a well-formed leaf at path.Index in the tree of
+// the given depth and of root merkleRoot
+func (l *IndexedLeaf) verify(h hash.Hash, merkleRoot []byte, depth int, path *SparseProof) bool {
+	size := h.Size()
+	if len(l.Key) != size || len(l.Value) != size || len(l.NextKey) != size {
+		return false
+	}
+	if size < 8 && l.NextIndex>>(8*size) != 0 {
+		return false
+	}
+	return VerifySparseProof(h, merkleRoot, depth, l.encode(), path)
+}
+
+// skips returns true if key is strictly between l.Key and the next key
+func (l *IndexedLeaf) skips(key []byte) bool {
+	if len(key) != len(l.Key) || bytes.Compare(l.Key, key) >= 0 {
+		return false
+	}
+	// the last leaf of the list points to the zero leaf
+	return (l.NextIndex == 0 && isZero(l.NextKey)) || bytes.Compare(key, l.NextKey) < 0
+}
+
+// prove returns the proof of the leaf at index
+func (t *IndexedTree) prove(index uint64) (IndexedProof, error) {
+	path, err := t.tree.Prove(index)
+	if err != nil {
+		return IndexedProof{}, err
+	}
+	l := t.leaves[index]
+	leaf := IndexedLeaf{
+		Key:       bytes.Clone(l.Key),
+		Value:     bytes.Clone(l.Value),
+		NextIndex: l.NextIndex,
+		NextKey:   bytes.Clone(l.NextKey),
+	}
+	return IndexedProof{Leaf: leaf, Path: path}, nil
+}
+
+// setLeaf sets the leaf at index, or empties it if l is nil
+func (t *IndexedTree) setLeaf(index uint64, l *IndexedLeaf) {
+	if l == nil {
+		delete(t.leaves, index)
+		// the index is in range since the leaf was set
+		_ = t.tree.Set(index, nil)
+		return
+	}
+	t.leaves[index] = l
+	_ = t.tree.Set(index, l.encode())
+}
+
+// search returns the position of key in the sorted keys, and whether it is
+// in the tree
+func (t *IndexedTree) search(key []byte) (int, bool) {
+	return slices.BinarySearchFunc(t.keys, key, bytes.Compare)
+}
+
+func (t *IndexedTree) checkKey(key []byte) error {
+	if len(key) != t.tree.hash.Size() || isZero(key) {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+func isZero(b []byte) bool {
+	for i := range b {
+		if b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package merkletree
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexedTree(t *testing.T) {
+	for name, hs := range hashers {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			h := hs.h()
+			const depth = 8
+
+			tree, err := NewIndexed(h, depth)
+			assert.NoError(err)
+
+			// insert keys, and check the transitions
+			keys := make([][]byte, 30)
+			values := make([][]byte, len(keys))
+			for i := range keys {
+				keys[i], values[i] = hs.data(), hs.data()
+			}
+			root := tree.Root()
+			proofs, err := tree.BatchInsert(keys, values)
+			assert.NoError(err)
+			for i := range proofs {
+				next := tree.Root()
+				if i+1 < len(proofs) {
+					next = proofs[i+1].Low.Path.ComputeRoot(h, proofs[i+1].Low.Leaf.encode())
+				}
+				assert.True(VerifyIndexedInsert(h, root, next, depth, keys[i], values[i], &proofs[i]))
+				assert.False(VerifyIndexedInsert(h, root, next, depth, keys[i], hs.data(), &proofs[i]))
+				root = next
+			}
+			_, err = tree.Insert(keys[0], values[0])
+			assert.ErrorIs(err, ErrKeyExists)
+
+			// the leaves form the sorted list of the keys
+			sorted := slices.Clone(keys)
+			slices.SortFunc(sorted, bytes.Compare)
+			index := uint64(0)
+			for _, k := range sorted {
+				index = tree.leaves[index].NextIndex
+				assert.Equal(k, tree.leaves[index].Key)
+			}
+			assert.Equal(uint64(0), tree.leaves[index].NextIndex)
+
+			// membership and non-membership
+			for i := range keys {
+				proof, err := tree.Prove(keys[i])
+				assert.NoError(err)
+				assert.True(VerifyIndexedMembership(h, root, depth, keys[i], values[i], &proof))
+				assert.False(VerifyIndexedNonMembership(h, root, depth, keys[i], &proof))
+				v, err := tree.Get(keys[i])
+				assert.NoError(err)
+				assert.Equal(values[i], v)
+			}
+			for range 10 {
+				key := hs.data()
+				proof, err := tree.Prove(key)
+				assert.NoError(err)
+				assert.True(VerifyIndexedNonMembership(h, root, depth, key, &proof))
+				assert.False(VerifyIndexedMembership(h, root, depth, key, proof.Leaf.Value, &proof))
+			}
+
+			// update
+			value := hs.data()
+			update, err := tree.Update(keys[3], value)
+			assert.NoError(err)
+			assert.True(VerifyIndexedUpdate(h, root, tree.Root(), depth, keys[3], value, &update))
+			root = tree.Root()
+
+			// delete
+			deletion, err := tree.Delete(keys[5])
+			assert.NoError(err)
+			assert.True(VerifyIndexedDelete(h, root, tree.Root(), depth, keys[5], &deletion))
+			assert.False(VerifyIndexedDelete(h, root, tree.Root(), depth, keys[6], &deletion))
+			root = tree.Root()
+			_, err = tree.Get(keys[5])
+			assert.ErrorIs(err, ErrKeyNotFound)
+			proof, err := tree.Prove(keys[5])
+			assert.NoError(err)
+			assert.True(VerifyIndexedNonMembership(h, root, depth, keys[5], &proof))
+
+			// serialization
+			var buf bytes.Buffer
+			_, err = proofs[7].WriteTo(&buf)
+			assert.NoError(err)
+			var decoded IndexedInsertProof
+			_, err = decoded.ReadFrom(&buf)
+			assert.NoError(err)
+			assert.Equal(proofs[7], decoded)
+		})
+	}
+}
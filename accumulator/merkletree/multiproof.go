@@ -0,0 +1,191 @@
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"io"
+	"math/bits"
+	"slices"
+)
+
+// multiProof holds the state of a Tree constructing a proof for several leaves.
+//
+// The shape of the Tree is the one of RFC 6962: a tree of n > 1 leaves is the
+// node of the complete tree of its first k leaves, where k is the largest
+// power of two smaller than n, and of the tree of the n - k others. A
+// multiproof is the list of the digests of the maximal subtrees containing no
+// proven leaf, in depth-first order, left to right. The digests are recorded
+// by the Tree as the subtrees are joined.
+type multiProof struct {
+	indices []uint64
+	leaves  map[uint64][]byte
+	// sums are the digests of the subtrees of the proof, by start ‖ size
+	sums map[[2]uint64][]byte
+}
+
+// SetIndices will tell the Tree to create a proof for the leaves at the input
+// indices, sharing the common nodes. SetIndices must be called on an empty
+// tree, and can be used along SetIndex.
+func (t *Tree) SetIndices(indices []uint64) error {
+	if t.head != nil {
+		return errors.New("cannot call SetIndices on Tree if Tree has not been reset")
+	}
+	if len(indices) == 0 {
+		return errors.New("no index to prove")
+	}
+	sorted := slices.Clone(indices)
+	slices.Sort(sorted)
+	t.multiProof = &multiProof{
+		indices: slices.Compact(sorted),
+		leaves:  make(map[uint64][]byte, len(sorted)),
+		sums:    make(map[[2]uint64][]byte),
+	}
+	return nil
+}
+
+// ProveMulti creates a proof that the leaves at the indices established by
+// SetIndices are elements of the Merkle tree. It returns the data of these
+// leaves, by index, and the proof, to be checked by VerifyMultiProof. It
+// returns an error if not all leaves were pushed. ProveMulti does not modify
+// the Tree.
+func (t *Tree) ProveMulti() (merkleRoot []byte, leaves map[uint64][]byte, proofSet [][]byte, numLeaves uint64, err error) {
+	if t.multiProof == nil {
+		panic("wrong usage: can't call ProveMulti on a tree if SetIndices wasn't called")
+	}
+	m := t.multiProof
+	if t.head == nil || m.indices[len(m.indices)-1] >= t.currentIndex {
+		return nil, nil, nil, 0, errors.New("indices were not reached while creating proof")
+	}
+
+	// collapse the subtrees as Root does, recording the digests of the
+	// aggregates joined with subtrees containing proven leaves.
+	current, start := t.head, t.currentIndex-(1<<uint(t.head.height))
+	sums := make(map[[2]uint64][]byte, len(m.sums))
+	for k, v := range m.sums {
+		sums[k] = v
+	}
+	for current.next != nil {
+		left := start - 1<<uint(current.next.height)
+		m.record(sums, current.next.sum, left, start-left, current.sum, start, t.currentIndex-start)
+		current = joinSubTrees(t.hash, current.next, current)
+		start = left
+	}
+
+	numLeaves = t.currentIndex
+	m.collect(sums, &proofSet, 0, numLeaves, m.indices)
+	leaves = make(map[uint64][]byte, len(m.leaves))
+	for i, d := range m.leaves {
+		leaves[i] = d
+	}
+	return append(current.sum[:0:0], current.sum...), leaves, proofSet, numLeaves, nil
+}
+
+// VerifyMultiProof returns true if proofSet proves that leaves, by index, are
+// leaves of the Merkle tree of root merkleRoot and numLeaves leaves.
+func VerifyMultiProof(h hash.Hash, merkleRoot []byte, leaves map[uint64][]byte, proofSet [][]byte, numLeaves uint64) bool {
+	if merkleRoot == nil || len(leaves) == 0 {
+		return false
+	}
+	indices := make([]uint64, 0, len(leaves))
+	for i := range leaves {
+		if i >= numLeaves {
+			return false
+		}
+		indices = append(indices, i)
+	}
+	slices.Sort(indices)
+
+	sum, ok := multiProofRoot(h, leaves, &proofSet, 0, numLeaves, indices)
+	return ok && len(proofSet) == 0 && bytes.Equal(sum, merkleRoot)
+}
+
+// BuildReaderMultiProof returns a proof that certain data is in the merkle
+// tree created by the data in the reader, along with the data of the proven
+// leaves. All leaves will be 'segmentSize' bytes except the last leaf, which
+// will not be padded out if there are not enough bytes remaining in the
+// reader.
+func BuildReaderMultiProof(r io.Reader, h hash.Hash, segmentSize int, indices []uint64) (root []byte, leaves map[uint64][]byte, proofSet [][]byte, numLeaves uint64, err error) {
+	tree := New(h)
+	if err = tree.SetIndices(indices); err != nil {
+		return
+	}
+	if err = tree.ReadAll(r, segmentSize); err != nil {
+		return
+	}
+	return tree.ProveMulti()
+}
+
+// contains returns true if a proven index is in [start, end)
+func (m *multiProof) contains(start, end uint64) bool {
+	i, _ := slices.BinarySearch(m.indices, start)
+	return i < len(m.indices) && m.indices[i] < end
+}
+
+// push records the data of a leaf if it is proven
+func (m *multiProof) push(index uint64, data []byte) {
+	if _, found := slices.BinarySearch(m.indices, index); found {
+		m.leaves[index] = data
+	}
+}
+
+// record records the digest of the subtree among the siblings [lStart,
+// lStart+lSize) and [rStart, rStart+rSize) which contains no proven leaf, if
+// the other one does.
+func (m *multiProof) record(sums map[[2]uint64][]byte, lSum []byte, lStart, lSize uint64, rSum []byte, rStart, rSize uint64) {
+	l, r := m.contains(lStart, lStart+lSize), m.contains(rStart, rStart+rSize)
+	switch {
+	case l && !r:
+		sums[[2]uint64{rStart, rSize}] = rSum
+	case r && !l:
+		sums[[2]uint64{lStart, lSize}] = lSum
+	}
+}
+
+// collect appends to proofSet the digests of the maximal subtrees of the
+// subtree [start, start+size) containing none of indices.
+func (m *multiProof) collect(sums map[[2]uint64][]byte, proofSet *[][]byte, start, size uint64, indices []uint64) {
+	if len(indices) == 0 {
+		*proofSet = append(*proofSet, sums[[2]uint64{start, size}])
+		return
+	}
+	if size == 1 {
+		return
+	}
+	k := splitSize(size)
+	i, _ := slices.BinarySearch(indices, start+k)
+	m.collect(sums, proofSet, start, k, indices[:i])
+	m.collect(sums, proofSet, start+k, size-k, indices[i:])
+}
+
+// multiProofRoot returns the digest of the subtree [start, start+size), from
+// the leaves at indices and the digests of proofSet, which are consumed.
+func multiProofRoot(h hash.Hash, leaves map[uint64][]byte, proofSet *[][]byte, start, size uint64, indices []uint64) ([]byte, bool) {
+	if len(indices) == 0 {
+		if len(*proofSet) == 0 {
+			return nil, false
+		}
+		sum := (*proofSet)[0]
+		*proofSet = (*proofSet)[1:]
+		return sum, true
+	}
+	if size == 1 {
+		return leafSum(h, leaves[start]), true
+	}
+	k := splitSize(size)
+	i, _ := slices.BinarySearch(indices, start+k)
+	left, ok := multiProofRoot(h, leaves, proofSet, start, k, indices[:i])
+	if !ok {
+		return nil, false
+	}
+	right, ok := multiProofRoot(h, leaves, proofSet, start+k, size-k, indices[i:])
+	if !ok {
+		return nil, false
+	}
+	return nodeSum(h, left, right), true
+}
+
+// splitSize returns the largest power of two smaller than size > 1
+func splitSize(size uint64) uint64 {
+	return 1 << (bits.Len64(size-1) - 1)
+}
@@ -0,0 +1,98 @@
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiProof(t *testing.T) {
+	assert := require.New(t)
+	h := sha256.New()
+	const segmentSize = 4
+
+	for _, numLeaves := range []uint64{1, 2, 3, 7, 8, 11, 32, 37} {
+		data := make([]byte, segmentSize*numLeaves-1)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		root, err := ReaderRoot(bytes.NewReader(data), h, segmentSize)
+		assert.NoError(err)
+
+		for _, indices := range [][]uint64{
+			{0},
+			{numLeaves - 1},
+			{0, numLeaves - 1},
+			{numLeaves / 2, numLeaves / 3, numLeaves / 2},
+		} {
+			r, leaves, proofSet, n, err := BuildReaderMultiProof(bytes.NewReader(data), h, segmentSize, indices)
+			assert.NoError(err)
+			assert.Equal(root, r)
+			assert.Equal(numLeaves, n)
+			for _, i := range indices {
+				end := min(segmentSize*(i+1), uint64(len(data)))
+				assert.Equal(data[segmentSize*i:end], leaves[i])
+			}
+			assert.True(VerifyMultiProof(h, root, leaves, proofSet, numLeaves))
+
+			// the proof of a single leaf has the size of the proof of Prove
+			if len(leaves) == 1 {
+				_, single, _, err := BuildReaderProof(bytes.NewReader(data), h, segmentSize, indices[0])
+				assert.NoError(err)
+				assert.Equal(len(single)-1, len(proofSet))
+			}
+
+			// tampering with the leaves, the proof or the number of leaves
+			for i := range leaves {
+				leaves[i] = append(bytes.Clone(leaves[i]), 0)
+				assert.False(VerifyMultiProof(h, root, leaves, proofSet, numLeaves))
+				leaves[i] = leaves[i][:len(leaves[i])-1]
+			}
+			if len(proofSet) > 0 {
+				assert.False(VerifyMultiProof(h, root, leaves, proofSet[1:], numLeaves))
+				assert.False(VerifyMultiProof(h, root, leaves, append(proofSet, proofSet[0]), numLeaves))
+			}
+			leaves[numLeaves] = nil
+			assert.False(VerifyMultiProof(h, root, leaves, proofSet, numLeaves))
+		}
+	}
+}
+
+func TestMultiProofPushSubTree(t *testing.T) {
+	assert := require.New(t)
+	h := sha256.New()
+
+	// the leaves 4 to 7 are pushed as a cached subtree
+	cached := New(h)
+	for i := byte(4); i < 8; i++ {
+		cached.Push([]byte{i})
+	}
+	full := New(h)
+	for i := byte(0); i < 10; i++ {
+		full.Push([]byte{i})
+	}
+
+	tree := New(h)
+	assert.NoError(tree.SetIndices([]uint64{1, 9}))
+	for i := byte(0); i < 4; i++ {
+		tree.Push([]byte{i})
+	}
+	assert.NoError(tree.PushSubTree(2, cached.Root()))
+	tree.Push([]byte{8})
+	tree.Push([]byte{9})
+
+	root, leaves, proofSet, numLeaves, err := tree.ProveMulti()
+	assert.NoError(err)
+	assert.Equal(full.Root(), root)
+	assert.True(VerifyMultiProof(h, root, leaves, proofSet, numLeaves))
+
+	// the cached tree can't contain a proven leaf
+	tree = New(h)
+	assert.NoError(tree.SetIndices([]uint64{1, 5}))
+	for i := byte(0); i < 4; i++ {
+		tree.Push([]byte{i})
+	}
+	assert.Error(tree.PushSubTree(2, cached.Root()))
+}
@@ -0,0 +1,303 @@
+package merkletree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+)
+
+var (
+	ErrInvalidDepth = errors.New("the depth of a sparse tree must be between 1 and 64")
+	ErrOutOfRange   = errors.New("index out of the range of the tree")
+)
+
+// SparseTree is a Merkle tree of fixed depth, whose 2^depth leaves are all
+// empty at creation. An empty leaf has the digest made of h.Size() zero bytes,
+// and a set leaf the digest h(data); a node is h(left ‖ right).
+//
+// Only the nodes that are not the root of an empty subtree are stored, so that
+// the memory footprint grows in O(n·depth) in the number n of set leaves. The
+// digests of the empty subtrees are computed once at creation.
+//
+// Any hash.Hash can be used, including the field hashers (MiMC, Poseidon2),
+// in which case the data of the leaves must be encodings of field elements.
+type SparseTree struct {
+	hash  hash.Hash
+	depth int
+	// empty[l] is the digest of an empty subtree of height l
+	empty [][]byte
+	// nodes[l] holds the digests of the non-empty nodes at height l, by index
+	nodes []map[uint64][]byte
+	// leaves holds the data of the set leaves
+	leaves map[uint64][]byte
+}
+
+// SparseProof is a proof of the value of a leaf of a SparseTree.
+type SparseProof struct {
+	// Index is the position of the leaf.
+	Index uint64
+	// Siblings are the digests of the siblings of the nodes on the path from
+	// the leaf to the root, starting with the sibling of the leaf.
+	Siblings [][]byte
+}
+
+// NewSparse returns an empty sparse tree of the given depth, with 2^depth
+// leaves.
+func NewSparse(h hash.Hash, depth int) (*SparseTree, error) {
+	if depth < 1 || depth > 64 {
+		return nil, ErrInvalidDepth
+	}
+	t := &SparseTree{
+		hash:   h,
+		depth:  depth,
+		empty:  make([][]byte, depth+1),
+		nodes:  make([]map[uint64][]byte, depth+1),
+		leaves: make(map[uint64][]byte),
+	}
+	t.empty[0] = make([]byte, h.Size())
+	for l := 1; l <= depth; l++ {
+		t.empty[l] = nodeSum(h, t.empty[l-1], t.empty[l-1])
+	}
+	for l := range t.nodes {
+		t.nodes[l] = make(map[uint64][]byte)
+	}
+	return t, nil
+}
+
+// Depth returns the depth of the tree.
+func (t *SparseTree) Depth() int {
+	return t.depth
+}
+
+// Root returns the Merkle root of the tree.
+func (t *SparseTree) Root() []byte {
+	return bytes.Clone(t.node(t.depth, 0))
+}
+
+// Get returns the data of the leaf at index, or nil if the leaf is empty.
+func (t *SparseTree) Get(index uint64) ([]byte, error) {
+	if !t.inRange(index) {
+		return nil, ErrOutOfRange
+	}
+	return bytes.Clone(t.leaves[index]), nil
+}
+
+// Set sets the data of the leaf at index. A nil data empties the leaf.
+func (t *SparseTree) Set(index uint64, data []byte) error {
+	if !t.inRange(index) {
+		return ErrOutOfRange
+	}
+	if data == nil {
+		delete(t.leaves, index)
+		t.setNode(0, index, nil)
+	} else {
+		t.leaves[index] = bytes.Clone(data)
+		t.setNode(0, index, leafSum(t.hash, data))
+	}
+	for l := 1; l <= t.depth; l++ {
+		index >>= 1
+		left, right := t.node(l-1, 2*index), t.node(l-1, 2*index+1)
+		if bytes.Equal(left, t.empty[l-1]) && bytes.Equal(right, t.empty[l-1]) {
+			t.setNode(l, index, nil)
+		} else {
+			t.setNode(l, index, nodeSum(t.hash, left, right))
+		}
+	}
+	return nil
+}
+
+// Delete empties the leaf at index.
+func (t *SparseTree) Delete(index uint64) error {
+	return t.Set(index, nil)
+}
+
+// Prove returns a proof of the value of the leaf at index, which may be empty.
+func (t *SparseTree) Prove(index uint64) (SparseProof, error) {
+	if !t.inRange(index) {
+		return SparseProof{}, ErrOutOfRange
+	}
+	proof := SparseProof{Index: index, Siblings: make([][]byte, t.depth)}
+	for l := range t.depth {
+		proof.Siblings[l] = bytes.Clone(t.node(l, index^1))
+		index >>= 1
+	}
+	return proof, nil
+}
+
+// BatchUpdate sets the data of the leaves at indices, in order, and returns
+// for each update a proof of the leaf against the root before the update.
+// Together with the previous data of the leaves, the proofs allow a verifier
+// to replay the sequence of roots from the current one with
+// SparseProof.ComputeRoot.
+func (t *SparseTree) BatchUpdate(indices []uint64, data [][]byte) ([]SparseProof, error) {
+	if len(indices) != len(data) {
+		return nil, errors.New("the number of indices and of data differ")
+	}
+	proofs := make([]SparseProof, len(indices))
+	for i := range indices {
+		var err error
+		if proofs[i], err = t.Prove(indices[i]); err != nil {
+			return nil, err
+		}
+		if err = t.Set(indices[i], data[i]); err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
+// ComputeRoot returns the root of a tree in which the leaf at p.Index holds
+// data, a nil data meaning an empty leaf, and whose other leaves are as in
+// the tree p was computed from.
+func (p *SparseProof) ComputeRoot(h hash.Hash, data []byte) []byte {
+	var sum []byte
+	if data == nil {
+		sum = make([]byte, h.Size())
+	} else {
+		sum = leafSum(h, data)
+	}
+	index := p.Index
+	for _, sibling := range p.Siblings {
+		if index&1 == 0 {
+			sum = nodeSum(h, sum, sibling)
+		} else {
+			sum = nodeSum(h, sibling, sum)
+		}
+		index >>= 1
+	}
+	return sum
+}
+
+// VerifySparseProof returns true if proof proves that the leaf at
+// proof.Index of the sparse tree of the given depth and of root merkleRoot
+// holds data, where a nil data means that the leaf is empty.
+//
+// The depth must be the one of the tree: leaves and nodes are hashed alike, so
+// that a shorter proof would prove the content of an internal node.
+func VerifySparseProof(h hash.Hash, merkleRoot []byte, depth int, data []byte, proof *SparseProof) bool {
+	if merkleRoot == nil || depth < 1 || depth > 64 || len(proof.Siblings) != depth {
+		return false
+	}
+	if depth < 64 && proof.Index>>depth != 0 {
+		return false
+	}
+	return bytes.Equal(proof.ComputeRoot(h, data), merkleRoot)
+}
+
+// WriteTo writes the binary encoding of the proof: the index on 8 bytes, the
+// number of siblings on 2 bytes, and each sibling prefixed by its length on
+// 2 bytes, all in big-endian.
+func (p *SparseProof) WriteTo(w io.Writer) (int64, error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], p.Index)
+	n, err := w.Write(buf[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+	m, err := writeByteSlices(w, p.Siblings)
+	return written + m, err
+}
+
+// ReadFrom reads the binary encoding of the proof written by WriteTo.
+func (p *SparseProof) ReadFrom(r io.Reader) (int64, error) {
+	var buf [8]byte
+	n, err := io.ReadFull(r, buf[:])
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+	p.Index = binary.BigEndian.Uint64(buf[:])
+	var m int64
+	p.Siblings, m, err = readByteSlices(r)
+	return read + m, err
+}
+
+// node returns the digest of the node at height l and position index
+func (t *SparseTree) node(l int, index uint64) []byte {
+	if d, ok := t.nodes[l][index]; ok {
+		return d
+	}
+	return t.empty[l]
+}
+
+// setNode sets the digest of a node, or removes it if it is empty
+func (t *SparseTree) setNode(l int, index uint64, d []byte) {
+	if d == nil {
+		delete(t.nodes[l], index)
+	} else {
+		t.nodes[l][index] = d
+	}
+}
+
+func (t *SparseTree) inRange(index uint64) bool {
+	return t.depth == 64 || index>>t.depth == 0
+}
+
+// writeByteSlices writes the number of slices and the slices prefixed by their
+// lengths, on 2 bytes in big-endian.
+func writeByteSlices(w io.Writer, s [][]byte) (int64, error) {
+	var buf [2]byte
+	binary.BigEndian.PutUint16(buf[:], uint16(len(s)))
+	n, err := w.Write(buf[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+	for i := range s {
+		m, err := writeBytes(w, s[i])
+		written += m
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// readByteSlices reads slices written by writeByteSlices
+func readByteSlices(r io.Reader) ([][]byte, int64, error) {
+	var buf [2]byte
+	n, err := io.ReadFull(r, buf[:])
+	read := int64(n)
+	if err != nil {
+		return nil, read, err
+	}
+	s := make([][]byte, binary.BigEndian.Uint16(buf[:]))
+	for i := range s {
+		var m int64
+		s[i], m, err = readBytes(r)
+		read += m
+		if err != nil {
+			return nil, read, err
+		}
+	}
+	return s, read, nil
+}
+
+// writeBytes writes b prefixed by its length on 2 bytes in big-endian
+func writeBytes(w io.Writer, b []byte) (int64, error) {
+	var buf [2]byte
+	binary.BigEndian.PutUint16(buf[:], uint16(len(b)))
+	n, err := w.Write(buf[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+	n, err = w.Write(b)
+	return written + int64(n), err
+}
+
+// readBytes reads a slice written by writeBytes
+func readBytes(r io.Reader) ([]byte, int64, error) {
+	var buf [2]byte
+	n, err := io.ReadFull(r, buf[:])
+	read := int64(n)
+	if err != nil {
+		return nil, read, err
+	}
+	b := make([]byte, binary.BigEndian.Uint16(buf[:]))
+	n, err = io.ReadFull(r, b)
+	return b, read + int64(n), err
+}
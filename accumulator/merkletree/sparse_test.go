@@ -0,0 +1,143 @@
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
+	"github.com/stretchr/testify/require"
+)
+
+// hashers are the hashes the trees are tested with, along with a function
+// returning random data accepted by the hash
+var hashers = map[string]struct {
+	h    func() hash.Hash
+	data func() []byte
+}{
+	"sha256":    {sha256.New, randomBytes},
+	"mimc":      {func() hash.Hash { return mimc.NewMiMC() }, randomElement},
+	"poseidon2": {func() hash.Hash { return poseidon2.NewMerkleDamgardHasher() }, randomElement},
+}
+
+func randomBytes() []byte {
+	b := make([]byte, 32)
+	for i := range b {
+		b[i] = byte(rand.N(256))
+	}
+	return b
+}
+
+func randomElement() []byte {
+	var e fr.Element
+	e.MustSetRandom()
+	b := e.Bytes()
+	return b[:]
+}
+
+func TestSparseTree(t *testing.T) {
+	for name, hs := range hashers {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			h := hs.h()
+			const depth = 10
+
+			tree, err := NewSparse(h, depth)
+			assert.NoError(err)
+			emptyRoot := tree.Root()
+
+			// set random leaves, and check the proofs of set and empty leaves
+			values := make(map[uint64][]byte)
+			for range 20 {
+				index := rand.Uint64N(1 << depth)
+				values[index] = hs.data()
+				assert.NoError(tree.Set(index, values[index]))
+			}
+			root := tree.Root()
+			for index := range uint64(1 << depth) {
+				v, err := tree.Get(index)
+				assert.NoError(err)
+				assert.Equal(values[index], v)
+				proof, err := tree.Prove(index)
+				assert.NoError(err)
+				assert.True(VerifySparseProof(h, root, depth, values[index], &proof))
+				assert.False(VerifySparseProof(h, root, depth, hs.data(), &proof))
+			}
+
+			// the proofs of the updates replay the roots
+			indices := []uint64{3, 3, 500, 1023}
+			data := [][]byte{hs.data(), nil, hs.data(), hs.data()}
+			proofs, err := tree.BatchUpdate(indices, data)
+			assert.NoError(err)
+			for i := range proofs {
+				assert.True(VerifySparseProof(h, root, depth, values[indices[i]], &proofs[i]))
+				root = proofs[i].ComputeRoot(h, data[i])
+				values[indices[i]] = data[i]
+			}
+			assert.Equal(tree.Root(), root)
+
+			// emptying all leaves gives back the empty tree
+			for index := range values {
+				assert.NoError(tree.Delete(index))
+			}
+			assert.Equal(emptyRoot, tree.Root())
+			for l := range tree.nodes {
+				assert.Empty(tree.nodes[l])
+			}
+
+			_, err = tree.Prove(1 << depth)
+			assert.ErrorIs(err, ErrOutOfRange)
+		})
+	}
+}
+
+func TestSparseProofSerialization(t *testing.T) {
+	assert := require.New(t)
+	tree, err := NewSparse(sha256.New(), 64)
+	assert.NoError(err)
+	assert.NoError(tree.Set(1<<63+5, []byte("leaf")))
+
+	proof, err := tree.Prove(1<<63 + 5)
+	assert.NoError(err)
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), written)
+
+	var decoded SparseProof
+	read, err := decoded.ReadFrom(&buf)
+	assert.NoError(err)
+	assert.Equal(written, read)
+	assert.Equal(proof, decoded)
+	assert.True(VerifySparseProof(sha256.New(), tree.Root(), 64, []byte("leaf"), &decoded))
+}
+
+func TestSparseProofDepth(t *testing.T) {
+	assert := require.New(t)
+	h := sha256.New()
+	const depth = 10
+
+	tree, err := NewSparse(h, depth)
+	assert.NoError(err)
+	assert.NoError(tree.Set(6, []byte("left")))
+	assert.NoError(tree.Set(7, []byte("right")))
+	root := tree.Root()
+	proof, err := tree.Prove(6)
+	assert.NoError(err)
+
+	// dropping the first sibling turns the proof into one of the parent node,
+	// seen as a leaf holding the concatenation of its children
+	forged := SparseProof{Index: proof.Index >> 1, Siblings: proof.Siblings[1:]}
+	data := append(leafSum(h, []byte("left")), leafSum(h, []byte("right"))...)
+	assert.Equal(root, forged.ComputeRoot(h, data))
+	assert.False(VerifySparseProof(h, root, depth, data, &forged))
+
+	// the depth bounds the proof length and the index
+	assert.True(VerifySparseProof(h, root, depth, []byte("left"), &proof))
+	assert.False(VerifySparseProof(h, root, depth+1, []byte("left"), &proof))
+	assert.False(VerifySparseProof(h, root, 0, nil, &SparseProof{}))
+}
@@ -0,0 +1,99 @@
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+var ErrNodeNotFound = errors.New("node not found in the store")
+
+// NodeStore stores the digests of the nodes of a Tree, so that its leaves can
+// be updated. A node is identified by its height, 0 for the leaves, and its
+// position among the nodes of that height.
+type NodeStore interface {
+	// Get returns the digest of a node, or ErrNodeNotFound.
+	Get(height int, index uint64) ([]byte, error)
+	// Set sets the digest of a node.
+	Set(height int, index uint64, sum []byte) error
+}
+
+// MemoryStore is a NodeStore holding the nodes in memory.
+type MemoryStore struct {
+	nodes map[[2]uint64][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{nodes: make(map[[2]uint64][]byte)}
+}
+
+// Get implements NodeStore.
+func (s *MemoryStore) Get(height int, index uint64) ([]byte, error) {
+	sum, ok := s.nodes[[2]uint64{uint64(height), index}]
+	if !ok {
+		return nil, ErrNodeNotFound
+	}
+	return bytes.Clone(sum), nil
+}
+
+// Set implements NodeStore.
+func (s *MemoryStore) Set(height int, index uint64, sum []byte) error {
+	s.nodes[[2]uint64{uint64(height), index}] = bytes.Clone(sum)
+	return nil
+}
+
+// FileStore is a NodeStore holding the nodes in a file, typically an
+// *os.File. The digests, of nodeSize bytes, are stored in the in-order of the
+// nodes of the tree, each in a slot of nodeSize+1 bytes whose first byte marks
+// the node as set: the node at height h and position i is at offset
+// (i·2ʰ⁺¹ + 2ʰ - 1)·(nodeSize+1), so that the file grows linearly with the
+// number of leaves. A node in a hole of the file is not found.
+type FileStore struct {
+	file interface {
+		io.ReaderAt
+		io.WriterAt
+	}
+	nodeSize int
+}
+
+// NewFileStore returns a FileStore backed by file, holding digests of
+// nodeSize bytes.
+func NewFileStore(file interface {
+	io.ReaderAt
+	io.WriterAt
+}, nodeSize int) *FileStore {
+	return &FileStore{file: file, nodeSize: nodeSize}
+}
+
+// Get implements NodeStore.
+func (s *FileStore) Get(height int, index uint64) ([]byte, error) {
+	slot := make([]byte, s.nodeSize+1)
+	n, err := s.file.ReadAt(slot, s.offset(height, index))
+	if n == len(slot) {
+		if slot[0] == 0 {
+			return nil, ErrNodeNotFound
+		}
+		return slot[1:], nil
+	}
+	if err == io.EOF {
+		return nil, ErrNodeNotFound
+	}
+	return nil, err
+}
+
+// Set implements NodeStore.
+func (s *FileStore) Set(height int, index uint64, sum []byte) error {
+	if len(sum) != s.nodeSize {
+		return errors.New("invalid size of node")
+	}
+	slot := make([]byte, s.nodeSize+1)
+	slot[0] = 1
+	copy(slot[1:], sum)
+	_, err := s.file.WriteAt(slot, s.offset(height, index))
+	return err
+}
+
+func (s *FileStore) offset(height int, index uint64) int64 {
+	return int64(index<<uint(height+1)+1<<uint(height)-1) * int64(s.nodeSize+1)
+}
@@ -17,7 +17,12 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
 // SOFTWARE.
 
-// Package merkletree provides Merkle tree and proof following RFC 6962.
+// Package merkletree provides Merkle tree and proof following RFC 6962,
+// including proofs of several leaves sharing their common nodes. A Tree
+// backed by a NodeStore can update its leaves.
+//
+// It also provides sparse Merkle trees (SparseTree) and indexed Merkle trees
+// (IndexedTree), with membership and non-membership proofs.
 //
 // From https://gitlab.com/NebulousLabs/merkletree
 package merkletree
@@ -54,6 +59,15 @@ type Tree struct {
 	proofSet     [][]byte
 	proofTree    bool
 
+	// multiProof is the state of the proof of several leaves, if SetIndices
+	// was called.
+	multiProof *multiProof
+
+	// store holds the digests of all the nodes, if the Tree was created with
+	// NewWithStore, and err is the first error it returned.
+	store NodeStore
+	err   error
+
 	// The cachedTree flag indicates that the tree is cached, meaning that
 	// different code is used in 'Push' for creating a new head subtree. Adding
 	// this flag is somewhat gross, but eliminates needing to duplicate the
@@ -209,6 +223,9 @@ func (t *Tree) Push(data []byte) {
 	if t.currentIndex == t.proofIndex {
 		t.proofSet = append(t.proofSet, data)
 	}
+	if t.multiProof != nil {
+		t.multiProof.push(t.currentIndex, data)
+	}
 
 	// Hash the data to create a subtree of height 0. The sum of the new node
 	// is going to be the data for cached trees, and is going to be the result
@@ -223,6 +240,7 @@ func (t *Tree) Push(data []byte) {
 	} else {
 		t.head.sum = leafSum(t.hash, data)
 	}
+	t.storeNode(0, t.currentIndex, t.head.sum)
 
 	// Join subTrees if possible.
 	t.joinAllSubTrees()
@@ -259,6 +277,9 @@ func (t *Tree) PushSubTree(height int, sum []byte) error {
 		(t.currentIndex < t.proofIndex && t.proofIndex < newIndex)) {
 		return errors.New("the cached tree shouldn't contain the element to prove")
 	}
+	if t.multiProof != nil && t.multiProof.contains(t.currentIndex, newIndex) {
+		return errors.New("the cached tree shouldn't contain the elements to prove")
+	}
 
 	// We can only add the cached tree if its depth is <= the depth of the
 	// current subtree.
@@ -272,6 +293,7 @@ func (t *Tree) PushSubTree(height int, sum []byte) error {
 		next:   t.head,
 		sum:    sum,
 	}
+	t.storeNode(height, t.currentIndex>>uint(height), sum)
 
 	// Join subTrees if possible.
 	t.joinAllSubTrees()
@@ -358,8 +380,16 @@ func (t *Tree) joinAllSubTrees() {
 			// }
 		}
 
+		// Record the subtree to add to the multiproof, if any.
+		if t.multiProof != nil {
+			leaves := uint64(1 << uint(t.head.height))
+			mid := (t.currentIndex / leaves) * leaves
+			t.multiProof.record(t.multiProof.sums, t.head.next.sum, mid-leaves, leaves, t.head.sum, mid, leaves)
+		}
+
 		// Join the two subTrees into one subTree with a greater height. Then
 		// compare the new subTree to the next subTree.
 		t.head = joinSubTrees(t.hash, t.head.next, t.head)
+		t.storeNode(t.head.height, t.currentIndex>>uint(t.head.height), t.head.sum)
 	}
 }
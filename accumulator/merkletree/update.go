@@ -0,0 +1,176 @@
+package merkletree
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+)
+
+// NewWithStore creates a new Tree writing the digests of all its nodes to
+// store, which allows updating its leaves. The memory footprint of the Tree
+// remains in O(log(n)) in the number of leaves, the nodes living in store.
+func NewWithStore(h hash.Hash, store NodeStore) *Tree {
+	return &Tree{
+		hash:  h,
+		store: store,
+	}
+}
+
+// Err returns the first error returned by the store of the Tree, if any. The
+// Tree can't be updated nor written after such an error.
+func (t *Tree) Err() error {
+	return t.err
+}
+
+// Update sets the data of the leaf at index, and updates the Merkle root in
+// O(log(n)). The Tree must have a store, and the leaf must have been added
+// with Push: the nodes of the subtrees added with PushSubTree are unknown, and
+// updating their leaves returns ErrNodeNotFound.
+// Update can't be used on a Tree constructing a proof.
+func (t *Tree) Update(index uint64, data []byte) error {
+	if t.store == nil {
+		return errors.New("cannot update a Tree without a store")
+	}
+	if t.proofTree || t.multiProof != nil {
+		return errors.New("cannot update a Tree constructing a proof")
+	}
+	if t.err != nil {
+		return t.err
+	}
+	if index >= t.currentIndex {
+		return ErrOutOfRange
+	}
+
+	// find the subtree containing the leaf
+	current, end := t.head, t.currentIndex
+	for index < end-1<<uint(current.height) {
+		end -= 1 << uint(current.height)
+		current = current.next
+	}
+
+	// read all the siblings before writing, so that an update failing on a
+	// node missing from the store, e.g. below a subtree added with
+	// PushSubTree, leaves the store unchanged
+	sums := make([][]byte, current.height+1)
+	sums[0] = leafSum(t.hash, data)
+	for height := 0; height < current.height; height++ {
+		i := index >> uint(height)
+		sibling, err := t.store.Get(height, i^1)
+		if err != nil {
+			return err
+		}
+		if i&1 == 0 {
+			sums[height+1] = nodeSum(t.hash, sums[height], sibling)
+		} else {
+			sums[height+1] = nodeSum(t.hash, sibling, sums[height])
+		}
+	}
+	for height, sum := range sums {
+		if err := t.store.Set(height, index>>uint(height), sum); err != nil {
+			return err
+		}
+	}
+	current.sum = sums[current.height]
+	return nil
+}
+
+// WriteTo writes a snapshot of the Tree, which can be restored with ReadFrom:
+// the number of leaves, the state of the proof established by SetIndex, and
+// the digests of the subtrees. The nodes in the store are not written. A Tree
+// constructing a multiproof can't be written.
+func (t *Tree) WriteTo(w io.Writer) (int64, error) {
+	if t.multiProof != nil {
+		return 0, errors.New("cannot write a Tree constructing a multiproof")
+	}
+	if t.err != nil {
+		return 0, t.err
+	}
+	var buf [17]byte
+	binary.BigEndian.PutUint64(buf[:8], t.currentIndex)
+	if t.proofTree {
+		buf[8] = 1
+	}
+	binary.BigEndian.PutUint64(buf[9:], t.proofIndex)
+	n, err := w.Write(buf[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+	m, err := writeByteSlices(w, t.proofSet)
+	written += m
+	if err != nil {
+		return written, err
+	}
+
+	// the subtrees, from the smallest, prefixed by their heights
+	var subTrees [][]byte
+	for current := t.head; current != nil; current = current.next {
+		subTrees = append(subTrees, []byte{byte(current.height)}, current.sum)
+	}
+	m, err = writeByteSlices(w, subTrees)
+	return written + m, err
+}
+
+// ReadFrom restores a snapshot of a Tree written by WriteTo. The hash and the
+// store of the Tree are kept: they must be the ones of the written Tree. An
+// error is returned if the subtrees are inconsistent with the number of leaves
+// or the hash, in which case the Tree is left unchanged.
+func (t *Tree) ReadFrom(r io.Reader) (int64, error) {
+	var buf [17]byte
+	n, err := io.ReadFull(r, buf[:])
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+	proofSet, m, err := readByteSlices(r)
+	read += m
+	if err != nil {
+		return read, err
+	}
+	subTrees, m, err := readByteSlices(r)
+	read += m
+	if err != nil {
+		return read, err
+	}
+	if len(subTrees)%2 != 0 {
+		return read, errors.New("invalid encoding of the subtrees")
+	}
+
+	// the heights strictly increase from the head, and the subtrees hold
+	// exactly the leaves of the Tree
+	var head *subTree
+	var numLeaves uint64
+	for i := len(subTrees) - 2; i >= 0; i -= 2 {
+		if len(subTrees[i]) != 1 || len(subTrees[i+1]) != t.hash.Size() {
+			return read, errors.New("invalid encoding of the subtrees")
+		}
+		height := int(subTrees[i][0])
+		if height >= 64 || (head != nil && height >= head.height) {
+			return read, errors.New("invalid heights of the subtrees")
+		}
+		numLeaves += 1 << uint(height)
+		head = &subTree{next: head, height: height, sum: subTrees[i+1]}
+	}
+	currentIndex := binary.BigEndian.Uint64(buf[:8])
+	if numLeaves != currentIndex {
+		return read, errors.New("the subtrees don't match the number of leaves")
+	}
+
+	t.head = head
+	t.currentIndex = currentIndex
+	t.proofTree = buf[8] == 1
+	t.proofIndex = binary.BigEndian.Uint64(buf[9:])
+	t.proofSet = proofSet
+	t.multiProof = nil
+	t.err = nil
+	return read, nil
+}
+
+// storeNode writes the digest of a node to the store, if any
+func (t *Tree) storeNode(height int, index uint64, sum []byte) {
+	if t.store == nil || t.err != nil {
+		return
+	}
+	t.err = t.store.Set(height, index, sum)
+}
@@ -0,0 +1,179 @@
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate(t *testing.T) {
+	h := sha256.New()
+	file, err := os.Create(filepath.Join(t.TempDir(), "nodes"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	stores := map[string]NodeStore{
+		"memory": NewMemoryStore(),
+		"file":   NewFileStore(file, h.Size()),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			const numLeaves = 21
+			leaves := make([][]byte, numLeaves)
+			tree := NewWithStore(h, store)
+			for i := range leaves {
+				leaves[i] = []byte{byte(i)}
+				tree.Push(leaves[i])
+			}
+			assert.NoError(tree.Err())
+
+			for _, i := range []uint64{0, 5, 15, 16, 20, 5} {
+				leaves[i] = append(leaves[i], 0xff)
+				assert.NoError(tree.Update(i, leaves[i]))
+
+				expected := New(h)
+				for j := range leaves {
+					expected.Push(leaves[j])
+				}
+				assert.Equal(expected.Root(), tree.Root())
+			}
+			assert.ErrorIs(tree.Update(numLeaves, nil), ErrOutOfRange)
+		})
+	}
+}
+
+func TestUpdatePushSubTree(t *testing.T) {
+	h := sha256.New()
+	file, err := os.Create(filepath.Join(t.TempDir(), "nodes"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	stores := map[string]NodeStore{
+		"memory": NewMemoryStore(),
+		"file":   NewFileStore(file, h.Size()),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			const numLeaves = 8
+			leaves := make([][]byte, numLeaves)
+			for i := range leaves {
+				leaves[i] = []byte{byte(i)}
+			}
+			root := func() []byte {
+				expected := New(h)
+				for i := range leaves {
+					expected.Push(leaves[i])
+				}
+				return expected.Root()
+			}
+
+			// the leaves 4 and 5 are added as a subtree
+			tree := NewWithStore(h, store)
+			for i := range 4 {
+				tree.Push(leaves[i])
+			}
+			cached := New(h)
+			cached.Push(leaves[4])
+			cached.Push(leaves[5])
+			assert.NoError(tree.PushSubTree(1, cached.Root()))
+			for i := 6; i < numLeaves; i++ {
+				tree.Push(leaves[i])
+			}
+			assert.NoError(tree.Err())
+			assert.Equal(root(), tree.Root())
+
+			// the leaves of the subtree can't be updated, and the failed
+			// updates don't alter the next ones
+			assert.ErrorIs(tree.Update(4, []byte{0xff}), ErrNodeNotFound)
+			assert.ErrorIs(tree.Update(5, []byte{0xff}), ErrNodeNotFound)
+			assert.Equal(root(), tree.Root())
+			for _, i := range []uint64{0, 7} {
+				leaves[i] = append(leaves[i], 0xff)
+				assert.NoError(tree.Update(i, leaves[i]))
+				assert.Equal(root(), tree.Root())
+			}
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	assert := require.New(t)
+	h := sha256.New()
+	store := NewMemoryStore()
+
+	tree := NewWithStore(h, store)
+	expected := New(h)
+	assert.NoError(expected.SetIndex(4))
+	for i := range byte(13) {
+		tree.Push([]byte{i})
+		expected.Push([]byte{i})
+	}
+
+	var buf bytes.Buffer
+	written, err := tree.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), written)
+
+	// restore the snapshot, update a leaf and keep pushing
+	restored := NewWithStore(h, store)
+	read, err := restored.ReadFrom(&buf)
+	assert.NoError(err)
+	assert.Equal(written, read)
+	assert.Equal(tree.Root(), restored.Root())
+	assert.NoError(restored.Update(2, []byte{2}))
+	for i := byte(13); i < 20; i++ {
+		restored.Push([]byte{i})
+		expected.Push([]byte{i})
+	}
+	assert.Equal(expected.Root(), restored.Root())
+
+	// the state of the proof is kept
+	buf.Reset()
+	_, err = expected.WriteTo(&buf)
+	assert.NoError(err)
+	proving := New(h)
+	_, err = proving.ReadFrom(&buf)
+	assert.NoError(err)
+	root, proofSet, proofIndex, numLeaves := proving.Prove()
+	assert.True(VerifyProof(h, root, proofSet, proofIndex, numLeaves))
+	assert.Equal(uint64(4), proofIndex)
+}
+
+func TestSnapshotInvalid(t *testing.T) {
+	h := sha256.New()
+	build := func() *Tree {
+		tree := New(h)
+		for i := range byte(13) {
+			tree.Push([]byte{i})
+		}
+		return tree
+	}
+	tamperings := map[string]func(tree *Tree){
+		"numLeaves":  func(tree *Tree) { tree.currentIndex++ },
+		"heights":    func(tree *Tree) { tree.head.height, tree.head.next.height = tree.head.next.height, tree.head.height },
+		"height":     func(tree *Tree) { tree.head.next.next.height = 64 },
+		"digestSize": func(tree *Tree) { tree.head.sum = tree.head.sum[:h.Size()-1] },
+		"missing":    func(tree *Tree) { tree.head = tree.head.next },
+	}
+	for name, tamper := range tamperings {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			tree := build()
+			tamper(tree)
+			var buf bytes.Buffer
+			_, err := tree.WriteTo(&buf)
+			assert.NoError(err)
+
+			restored := build()
+			_, err = restored.ReadFrom(&buf)
+			assert.Error(err)
+			assert.Equal(build().Root(), restored.Root())
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package verkle
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var (
+	ErrInvalidEncoding = errors.New("invalid point encoding")
+	ErrNotInSubgroup   = errors.New("point is not in the Banderwagon subgroup")
+)
+
+// PointSize is the size in bytes of an encoded Point.
+const PointSize = fp.Bytes
+
+// Point is an element of the Banderwagon group: the points of bandersnatch
+// in the subgroup of order 2r, where r is the prime order of the main
+// subgroup, quotiented by the 2-torsion point (0, -1). The points (x, y) and
+// (-x, -y) represent the same element.
+//
+// The zero value is not a valid Point; use SetIdentity.
+type Point struct {
+	p bandersnatch.PointExtended
+}
+
+// SetIdentity sets p to the identity and returns it.
+func (p *Point) SetIdentity() *Point {
+	p.p.X.SetZero()
+	p.p.Y.SetOne()
+	p.p.Z.SetOne()
+	p.p.T.SetZero()
+	return p
+}
+
+// IsIdentity returns true if p is the identity.
+func (p *Point) IsIdentity() bool {
+	return p.p.X.IsZero()
+}
+
+// Set sets p to q and returns it.
+func (p *Point) Set(q *Point) *Point {
+	p.p.Set(&q.p)
+	return p
+}
+
+// Equal returns true if p and q are the same element of Banderwagon, that is
+// if x_p·y_q = x_q·y_p.
+func (p *Point) Equal(q *Point) bool {
+	var a, b fp.Element
+	a.Mul(&p.p.X, &q.p.Y)
+	b.Mul(&q.p.X, &p.p.Y)
+	return a.Equal(&b)
+}
+
+// Add sets p to a+b and returns it.
+func (p *Point) Add(a, b *Point) *Point {
+	p.p.Add(&a.p, &b.p)
+	return p
+}
+
+// Sub sets p to a-b and returns it.
+func (p *Point) Sub(a, b *Point) *Point {
+	var nb bandersnatch.PointExtended
+	nb.Neg(&b.p)
+	p.p.Add(&a.p, &nb)
+	return p
+}
+
+// Neg sets p to -a and returns it.
+func (p *Point) Neg(a *Point) *Point {
+	p.p.Neg(&a.p)
+	return p
+}
+
+// ScalarMultiplication sets p to [s]a and returns it.
+func (p *Point) ScalarMultiplication(a *Point, s *fr.Element) *Point {
+	const c = 4
+	var table [1 << c]bandersnatch.PointExtended
+	table[0].Set(&identity.p)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &a.p)
+	}
+	limbs := s.Bits()
+	var res bandersnatch.PointExtended
+	res.Set(&identity.p)
+	for w := (fr.Bits+c-1)/c - 1; w >= 0; w-- {
+		for range c {
+			res.Double(&res)
+		}
+		if d := window64(&limbs, w*c, 1<<c-1); d != 0 {
+			res.Add(&res, &table[d])
+		}
+	}
+	p.p.Set(&res)
+	return p
+}
+
+// MultiExp sets p to ∑ᵢ [scalars[i]]points[i] and returns it.
+func (p *Point) MultiExp(points []Point, scalars []fr.Element) (*Point, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("the number of points and scalars differ")
+	}
+	*p = msm(points, scalars)
+	return p, nil
+}
+
+// Bytes returns the encoding of p: the big-endian encoding of x if y is
+// lexicographically largest, of -x otherwise. It is the same for (x, y) and
+// (-x, -y).
+func (p *Point) Bytes() [PointSize]byte {
+	var zInv fp.Element
+	zInv.Inverse(&p.p.Z)
+	return p.bytes(&zInv)
+}
+
+// bytes returns the encoding of p, given the inverse of its Z coordinate
+func (p *Point) bytes(zInv *fp.Element) [PointSize]byte {
+	var x, y fp.Element
+	x.Mul(&p.p.X, zInv)
+	y.Mul(&p.p.Y, zInv)
+	if !y.LexicographicallyLargest() {
+		x.Neg(&x)
+	}
+	return x.Bytes()
+}
+
+// SetBytes sets p from its encoding. It returns an error if buf is not the
+// canonical encoding of an element of Banderwagon.
+func (p *Point) SetBytes(buf []byte) error {
+	if len(buf) != PointSize {
+		return ErrInvalidEncoding
+	}
+	var x fp.Element
+	if err := x.SetBytesCanonical(buf); err != nil {
+		return ErrInvalidEncoding
+	}
+	return p.setX(&x)
+}
+
+// setX sets p to the point of Banderwagon with abscissa x, with
+// lexicographically largest ordinate.
+func (p *Point) setX(x *fp.Element) error {
+	curve := bandersnatch.GetEdwardsCurve()
+
+	// the points of the 2r subgroup are those for which 1 - ax² is a square
+	var x2, num, den, one fp.Element
+	one.SetOne()
+	x2.Square(x)
+	num.Mul(&x2, &curve.A).Sub(&one, &num)
+	if num.Legendre() != 1 {
+		return ErrNotInSubgroup
+	}
+
+	// y² = (1 - ax²) / (1 - dx²)
+	den.Mul(&x2, &curve.D).Sub(&one, &den)
+	if den.IsZero() {
+		return ErrInvalidEncoding
+	}
+	var y fp.Element
+	y.Div(&num, &den)
+	if y.Sqrt(&y) == nil {
+		return ErrInvalidEncoding
+	}
+	if !y.LexicographicallyLargest() {
+		y.Neg(&y)
+	}
+
+	var a bandersnatch.PointAffine
+	a.X.Set(x)
+	a.Y.Set(&y)
+	p.p.FromAffine(&a)
+	return nil
+}
+
+// MapToScalarField returns x/y, reduced modulo the order of the scalar field.
+// It is the same for (x, y) and (-x, -y), and maps the identity to zero.
+func (p *Point) MapToScalarField() fr.Element {
+	var yInv fp.Element
+	yInv.Inverse(&p.p.Y)
+	return p.mapToScalarField(&yInv)
+}
+
+// mapToScalarField returns x/y reduced, given the inverse of the Y coordinate of p
+func (p *Point) mapToScalarField(yInv *fp.Element) fr.Element {
+	var u fp.Element
+	u.Mul(&p.p.X, yInv)
+	b := u.Bytes()
+	var res fr.Element
+	res.SetBytes(b[:])
+	return res
+}
+
+// batchMapToScalarField returns the images of points by MapToScalarField,
+// with a single inversion.
+func batchMapToScalarField(points []Point) []fr.Element {
+	ys := make([]fp.Element, len(points))
+	for i := range points {
+		ys[i] = points[i].p.Y
+	}
+	ys = fp.BatchInvert(ys)
+	res := make([]fr.Element, len(points))
+	for i := range points {
+		res[i] = points[i].mapToScalarField(&ys[i])
+	}
+	return res
+}
+
+// batchBytes returns the encodings of points, with a single inversion.
+func batchBytes(points []Point) [][PointSize]byte {
+	zs := make([]fp.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].p.Z
+	}
+	zs = fp.BatchInvert(zs)
+	res := make([][PointSize]byte, len(points))
+	for i := range points {
+		res[i] = points[i].bytes(&zs[i])
+	}
+	return res
+}
+
+// msm returns ∑ᵢ [scalars[i]]points[i], with the bucket method.
+func msm(points []Point, scalars []fr.Element) Point {
+	var res Point
+	res.SetIdentity()
+	switch len(points) {
+	case 0:
+		return res
+	case 1:
+		res.ScalarMultiplication(&points[0], &scalars[0])
+		return res
+	}
+
+	c := 4
+	switch {
+	case len(points) >= 256:
+		c = 8
+	case len(points) >= 32:
+		c = 6
+	}
+	nbWindows := (fr.Bits + c - 1) / c
+	mask := uint64(1)<<c - 1
+
+	digits := make([][fr.Limbs]uint64, len(scalars))
+	for i := range scalars {
+		digits[i] = scalars[i].Bits()
+	}
+
+	buckets := make([]bandersnatch.PointExtended, 1<<c)
+	var running, window bandersnatch.PointExtended
+	for w := nbWindows - 1; w >= 0; w-- {
+		for range c {
+			res.p.Double(&res.p)
+		}
+		for i := range buckets {
+			buckets[i].Set(&identity.p)
+		}
+		for i := range points {
+			d := window64(&digits[i], w*c, mask)
+			if d != 0 {
+				buckets[d].Add(&buckets[d], &points[i].p)
+			}
+		}
+		// ∑ᵢ i·bucketᵢ as a sum of running sums
+		running.Set(&identity.p)
+		window.Set(&identity.p)
+		for i := len(buckets) - 1; i > 0; i-- {
+			running.Add(&running, &buckets[i])
+			window.Add(&window, &running)
+		}
+		res.p.Add(&res.p, &window)
+	}
+	return res
+}
+
+// window64 returns the bits [offset, offset+c) of the little-endian limbs
+// s, where c is the bit length of mask.
+func window64(s *[fr.Limbs]uint64, offset int, mask uint64) uint64 {
+	limb, shift := offset/64, offset%64
+	d := s[limb] >> shift
+	if shift != 0 && limb+1 < fr.Limbs {
+		d |= s[limb+1] << (64 - shift)
+	}
+	return d & mask
+}
+
+// identity is the identity of Banderwagon
+var identity = *new(Point).SetIdentity()
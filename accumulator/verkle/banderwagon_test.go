@@ -0,0 +1,102 @@
+package verkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerators(t *testing.T) {
+	t.Parallel()
+
+	// test vectors of go-ipa, used by Ethereum
+	g := Generators()
+	require.Len(t, g, NodeWidth)
+	b := g[0].Bytes()
+	require.Equal(t, "01587ad1336675eb912550ec2a28eb8923b824b490dd2ba82e48f14590a298a0", hex.EncodeToString(b[:]))
+	b = g[NodeWidth-1].Bytes()
+	require.Equal(t, "3de2be346b539395b0c0de56a5ccca54a317f1b5c80107b0802af9a62276a4d8", hex.EncodeToString(b[:]))
+
+	h := sha256.New()
+	for _, b := range batchBytes(g) {
+		h.Write(b[:])
+	}
+	require.Equal(t, "1fcaea10bf24f750200e06fa473c76ff0468007291fa548e2d99f09ba9256fdb", hex.EncodeToString(h.Sum(nil)))
+}
+
+func TestPointEncoding(t *testing.T) {
+	t.Parallel()
+
+	g := Generators()
+	var s fr.Element
+	for i := range 10 {
+		s.MustSetRandom()
+		var p, q Point
+		p.ScalarMultiplication(&g[i], &s)
+		b := p.Bytes()
+		require.NoError(t, q.SetBytes(b[:]))
+		require.True(t, p.Equal(&q))
+		require.Equal(t, p.MapToScalarField(), q.MapToScalarField())
+	}
+
+	// the identity
+	var id, q Point
+	id.SetIdentity()
+	b := id.Bytes()
+	require.Equal(t, [PointSize]byte{}, b)
+	require.NoError(t, q.SetBytes(b[:]))
+	require.True(t, q.IsIdentity())
+	require.True(t, q.Equal(&id))
+	require.Equal(t, fr.Element{}, id.MapToScalarField())
+
+	// (x, y) and (-x, -y) are the same element
+	var p Point
+	p.Set(&g[3])
+	p.p.X.Neg(&p.p.X)
+	p.p.Y.Neg(&p.p.Y)
+	require.True(t, p.Equal(&g[3]))
+	require.Equal(t, g[3].Bytes(), p.Bytes())
+	require.Equal(t, g[3].MapToScalarField(), p.MapToScalarField())
+
+	// invalid encodings
+	require.ErrorIs(t, q.SetBytes(b[:31]), ErrInvalidEncoding)
+	modulus := fp.Modulus().Bytes()
+	require.ErrorIs(t, q.SetBytes(modulus), ErrInvalidEncoding)
+	var x fp.Element
+	var rejected bool
+	for i := uint64(1); !rejected; i++ {
+		x.SetUint64(i)
+		b := x.Bytes()
+		if err := q.SetBytes(b[:]); err == ErrNotInSubgroup {
+			rejected = true
+		}
+	}
+}
+
+func TestMultiExp(t *testing.T) {
+	t.Parallel()
+
+	g := Generators()
+	for _, n := range []int{0, 1, 2, 31, 64, NodeWidth} {
+		scalars := make(fr.Vector, n)
+		scalars.MustSetRandom()
+		var res Point
+		_, err := res.MultiExp(g[:n], scalars)
+		require.NoError(t, err)
+
+		var expected, tmp Point
+		expected.SetIdentity()
+		for i := range n {
+			tmp.ScalarMultiplication(&g[i], &scalars[i])
+			expected.Add(&expected, &tmp)
+		}
+		require.True(t, expected.Equal(&res), "n=%d", n)
+
+		c := Commit(scalars)
+		require.True(t, expected.Equal(&c), "n=%d", n)
+	}
+}
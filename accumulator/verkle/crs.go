@@ -0,0 +1,220 @@
+package verkle
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// NodeWidth is the number of values committed to by a vector commitment, and
+// the number of children of a node of the tree.
+const NodeWidth = 256
+
+// crsSeed is the seed from which the generators are derived
+const crsSeed = "eth_verkle_oct_2021"
+
+const (
+	tableWindowSize    = 4
+	tableWindowEntries = 1 << tableWindowSize
+	tableWindowCount   = (fr.Bits + tableWindowSize - 1) / tableWindowSize
+)
+
+var (
+	crsOnce sync.Once
+	// crsG are the generators of the vector commitment
+	crsG [NodeWidth]Point
+	// crsQ is the generator used to bind the inner product in the IPA
+	crsQ Point
+
+	tablesOnce sync.Once
+	// tables[i][w][d] = [d·2ʷᵏ]Gᵢ where k is the window size
+	tables *[NodeWidth][tableWindowCount][tableWindowEntries]bandersnatch.PointAffine
+)
+
+func initCRS() {
+	var x fp.Element
+	var buf [8]byte
+	for i, n := uint64(0), 0; n < NodeWidth; i++ {
+		h := sha256.New()
+		h.Write([]byte(crsSeed))
+		binary.BigEndian.PutUint64(buf[:], i)
+		h.Write(buf[:])
+		x.SetBytes(h.Sum(nil))
+		if crsG[n].setX(&x) == nil {
+			n++
+		}
+	}
+	base := bandersnatch.GetEdwardsCurve().Base
+	crsQ.p.FromAffine(&base)
+}
+
+// Generators returns the generators of the vector commitment. The i-th one
+// is the i-th point of Banderwagon whose abscissa is sha256(seed ‖ j) for
+// increasing j, where the seed is "eth_verkle_oct_2021" and j is encoded on 8
+// big-endian bytes.
+func Generators() []Point {
+	crsOnce.Do(initCRS)
+	res := make([]Point, NodeWidth)
+	copy(res, crsG[:])
+	return res
+}
+
+// initTables computes the multiples of the generators used by commit
+func initTables() {
+	crsOnce.Do(initCRS)
+	t := new([NodeWidth][tableWindowCount][tableWindowEntries]bandersnatch.PointAffine)
+	var wg sync.WaitGroup
+	for i := range NodeWidth {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			points := make([]Point, 0, tableWindowCount*tableWindowEntries)
+			base := crsG[i]
+			for range tableWindowCount {
+				var acc Point
+				acc.SetIdentity()
+				for range tableWindowEntries {
+					points = append(points, acc)
+					acc.Add(&acc, &base)
+				}
+				for range tableWindowSize {
+					base.p.Double(&base.p)
+				}
+			}
+			zs := make([]fp.Element, len(points))
+			for j := range points {
+				zs[j] = points[j].p.Z
+			}
+			zs = fp.BatchInvert(zs)
+			for j := range points {
+				e := &t[i][j/tableWindowEntries][j%tableWindowEntries]
+				e.X.Mul(&points[j].p.X, &zs[j])
+				e.Y.Mul(&points[j].p.Y, &zs[j])
+			}
+		}(i)
+	}
+	wg.Wait()
+	tables = t
+}
+
+// Commit returns the Pedersen vector commitment ∑ᵢ [values[i]]Gᵢ. It panics if
+// there are more than NodeWidth values.
+func Commit(values []fr.Element) Point {
+	if len(values) > NodeWidth {
+		panic("verkle: too many values to commit to")
+	}
+	var res Point
+	res.SetIdentity()
+	for i := range values {
+		res.addMul(i, &values[i])
+	}
+	return res
+}
+
+// addMul adds [s]Gᵢ to p, with the precomputed tables.
+func (p *Point) addMul(i int, s *fr.Element) {
+	if s.IsZero() {
+		return
+	}
+	tablesOnce.Do(initTables)
+	t := &tables[i]
+	limbs := s.Bits()
+	for w := range tableWindowCount {
+		if d := window64(&limbs, w*tableWindowSize, tableWindowEntries-1); d != 0 {
+			p.p.MixedAdd(&p.p, &t[w][d])
+		}
+	}
+}
+
+// domain precomputations for polynomials in evaluation form on {0, …, NodeWidth-1}
+var (
+	domainOnce sync.Once
+	// aPrime[i] = A'(i) = ∏_{j≠i}(i-j) where A(X) = ∏ⱼ(X-j)
+	aPrime [NodeWidth]fr.Element
+	// aPrimeInv[i] = 1/A'(i)
+	aPrimeInv [NodeWidth]fr.Element
+	// inverses[NodeWidth-1+k] = 1/k for k in [-(NodeWidth-1), NodeWidth-1], k ≠ 0
+	inverses [2*NodeWidth - 1]fr.Element
+)
+
+func initDomain() {
+	var d fr.Element
+	for i := range NodeWidth {
+		aPrime[i].SetOne()
+		for j := range NodeWidth {
+			if i != j {
+				d.SetInt64(int64(i - j))
+				aPrime[i].Mul(&aPrime[i], &d)
+			}
+		}
+	}
+	copy(aPrimeInv[:], fr.BatchInvert(aPrime[:]))
+	for k := range inverses {
+		inverses[k].SetInt64(int64(k - (NodeWidth - 1)))
+	}
+	copy(inverses[:], fr.BatchInvert(inverses[:]))
+}
+
+// inverse returns 1/k for k in [-(NodeWidth-1), NodeWidth-1], k ≠ 0
+func inverse(k int) *fr.Element {
+	return &inverses[NodeWidth-1+k]
+}
+
+// lagrangeCoefficients returns the values at z of the Lagrange polynomials on the domain,
+// Lᵢ(z) = A(z) / (A'(i)(z-i)), so that f(z) = ∑ᵢ f(i)Lᵢ(z).
+func lagrangeCoefficients(z *fr.Element) []fr.Element {
+	domainOnce.Do(initDomain)
+	res := make([]fr.Element, NodeWidth)
+	if z.IsUint64() && z.Uint64() < NodeWidth {
+		res[z.Uint64()].SetOne()
+		return res
+	}
+
+	var az, d fr.Element
+	az.SetOne()
+	for i := range res {
+		d.SetUint64(uint64(i))
+		res[i].Sub(z, &d)
+		az.Mul(&az, &res[i])
+		res[i].Mul(&res[i], &aPrime[i])
+	}
+	res = fr.BatchInvert(res)
+	for i := range res {
+		res[i].Mul(&res[i], &az)
+	}
+	return res
+}
+
+// divideOnDomain returns the evaluations on the domain of (f(X) - f(m)) / (X - m),
+// where m is in the domain.
+func divideOnDomain(m int, f []fr.Element) []fr.Element {
+	domainOnce.Do(initDomain)
+	q := make([]fr.Element, NodeWidth)
+	var t fr.Element
+	for i := range NodeWidth {
+		if i == m {
+			continue
+		}
+		// qᵢ = (fᵢ - fₘ) / (i - m)
+		q[i].Sub(&f[i], &f[m]).Mul(&q[i], inverse(i-m))
+		// qₘ = f'(m) = -∑_{i≠m} qᵢ A'(m)/A'(i)
+		t.Mul(&q[i], &aPrimeInv[i])
+		q[m].Sub(&q[m], &t)
+	}
+	q[m].Mul(&q[m], &aPrime[m])
+	return q
+}
+
+// innerProduct returns ∑ᵢ a[i]b[i]
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, t fr.Element
+	for i := range a {
+		t.Mul(&a[i], &b[i])
+		res.Add(&res, &t)
+	}
+	return res
+}
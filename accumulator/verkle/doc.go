@@ -0,0 +1,18 @@
+// Package verkle implements Verkle tries over the Banderwagon group, built on
+// bandersnatch, as specified for Ethereum in EIP-6800.
+//
+// It provides:
+//   - Point, the Banderwagon group, with the encoding and the map to the scalar
+//     field used by Ethereum;
+//   - Commit, a Pedersen vector commitment to 256 values, with the generators
+//     derived from the seed "eth_verkle_oct_2021" and precomputed tables;
+//   - MultiProof, an inner product argument proving the openings of several
+//     commitments, in evaluation form on {0, …, 255};
+//   - Tree, an in-memory trie with 31-byte stems and 256 values per stem,
+//     with incremental commitments and proofs of presence and absence;
+//   - GetTreeKey and its variants, the layout of the accounts in the trie.
+//
+// The commitments, the encodings and the Fiat-Shamir transcript are those of
+// the Ethereum implementations, go-ipa and go-verkle. The serialization of
+// the proofs is left to the caller.
+package verkle
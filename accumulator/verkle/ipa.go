@@ -0,0 +1,141 @@
+package verkle
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// nbRounds is the number of rounds of the IPA, log₂(NodeWidth)
+const nbRounds = 8
+
+var ErrVerifyOpeningProof = errors.New("can't verify opening proof")
+
+// IPAProof is a proof that a vector commitment C to f, in evaluation form on
+// {0, …, NodeWidth-1}, opens to f(z) at a point z. It is the inner product
+// argument of Bulletproofs, between f and the Lagrange coefficients at z.
+type IPAProof struct {
+	// L, R are the cross terms of the rounds
+	L, R [nbRounds]Point
+	// A is the last folded coefficient of f
+	A fr.Element
+}
+
+// proveIPA returns a proof that c, the commitment to f, opens to f(z),
+// continuing the transcript t.
+func proveIPA(t *transcript, c *Point, f []fr.Element, z *fr.Element) IPAProof {
+	crsOnce.Do(initCRS)
+	var proof IPAProof
+
+	a := make([]fr.Element, NodeWidth)
+	copy(a, f)
+	b := lagrangeCoefficients(z)
+	y := innerProduct(a, b)
+
+	t.domainSeparator("ipa")
+	t.appendPoint(c, "C")
+	t.appendScalar(z, "input point")
+	t.appendScalar(&y, "output point")
+	w := t.challengeScalar("w")
+	var q Point
+	q.ScalarMultiplication(&crsQ, &w)
+
+	g := make([]Point, NodeWidth)
+	copy(g, crsG[:])
+	var x, xInv fr.Element
+	for i := range nbRounds {
+		m := len(a) / 2
+		aL, aR := a[:m], a[m:]
+		bL, bR := b[:m], b[m:]
+		gL, gR := g[:m], g[m:]
+
+		// L = <a_R, G_L> + <a_R, b_L>Q, R = <a_L, G_R> + <a_L, b_R>Q
+		zL, zR := innerProduct(aR, bL), innerProduct(aL, bR)
+		proof.L[i] = msm(append(gL[:m:m], q), append(aR[:m:m], zL))
+		proof.R[i] = msm(append(gR[:m:m], q), append(aL[:m:m], zR))
+
+		t.appendPoint(&proof.L[i], "L")
+		t.appendPoint(&proof.R[i], "R")
+		x = t.challengeScalar("x")
+		xInv.Inverse(&x)
+
+		// a ← a_L + x·a_R, b ← b_L + x⁻¹·b_R, G ← G_L + x⁻¹·G_R
+		var s fr.Element
+		var gi Point
+		for j := range m {
+			s.Mul(&aR[j], &x)
+			aL[j].Add(&aL[j], &s)
+			s.Mul(&bR[j], &xInv)
+			bL[j].Add(&bL[j], &s)
+		}
+		for j := range m {
+			gi.ScalarMultiplication(&gR[j], &xInv)
+			gL[j].Add(&gL[j], &gi)
+		}
+		a, b, g = aL, bL, gL
+	}
+	proof.A = a[0]
+	return proof
+}
+
+// verifyIPA checks that proof proves that c opens to y at z, continuing the
+// transcript t.
+func verifyIPA(t *transcript, c *Point, proof *IPAProof, z, y *fr.Element) error {
+	crsOnce.Do(initCRS)
+	b := lagrangeCoefficients(z)
+
+	t.domainSeparator("ipa")
+	t.appendPoint(c, "C")
+	t.appendScalar(z, "input point")
+	t.appendScalar(y, "output point")
+	w := t.challengeScalar("w")
+
+	var x [nbRounds]fr.Element
+	for i := range nbRounds {
+		t.appendPoint(&proof.L[i], "L")
+		t.appendPoint(&proof.R[i], "R")
+		x[i] = t.challengeScalar("x")
+	}
+	xInv := fr.BatchInvert(x[:])
+
+	// the folded generator and Lagrange coefficient are <s, G> and <s, b>,
+	// where sᵢ is the product of the x⁻¹ of the rounds in which the index i
+	// is in the right half.
+	s := make([]fr.Element, NodeWidth)
+	s[0].SetOne()
+	for i := range nbRounds {
+		m := 1 << i
+		for j := range m {
+			s[j+m].Mul(&s[j], &xInv[nbRounds-1-i])
+		}
+	}
+	b0 := innerProduct(s, b)
+
+	// C + y·w·Q + ∑ᵢ (xᵢ·Lᵢ + xᵢ⁻¹·Rᵢ) = A·<s, G> + A·b₀·w·Q
+	points := make([]Point, 0, NodeWidth+2*nbRounds+2)
+	scalars := make([]fr.Element, 0, cap(points))
+	points = append(points, crsG[:]...)
+	for i := range s {
+		s[i].Mul(&s[i], &proof.A)
+	}
+	scalars = append(scalars, s...)
+	var e fr.Element
+	e.Mul(&proof.A, &b0).Sub(&e, y).Mul(&e, &w)
+	points = append(points, crsQ, *c)
+	scalars = append(scalars, e, fr.Element{})
+	scalars[len(scalars)-1].SetOne()
+	scalars[len(scalars)-1].Neg(&scalars[len(scalars)-1])
+	for i := range nbRounds {
+		points = append(points, proof.L[i], proof.R[i])
+		var nx, nxInv fr.Element
+		nx.Neg(&x[i])
+		nxInv.Neg(&xInv[i])
+		scalars = append(scalars, nx, nxInv)
+	}
+
+	check := msm(points, scalars)
+	if !check.IsIdentity() {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
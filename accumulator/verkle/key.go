@@ -0,0 +1,85 @@
+package verkle
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// Layout of the account data in the tree, from EIP-6800.
+const (
+	BasicDataLeafKey    = 0
+	CodeHashLeafKey     = 1
+	HeaderStorageOffset = 64
+	CodeOffset          = 128
+)
+
+// mainStorageOffset is 256³¹, the offset of the storage slots not in the header
+var mainStorageOffset = new(big.Int).Lsh(big.NewInt(1), 8*StemSize)
+
+// GetTreeKey returns the key of the subIndex-th value of the treeIndex-th stem
+// of an account, as specified in EIP-6800: the stem is the hash of the
+// address and of the tree index encoded on 32 little-endian bytes, where the
+// hash of 64 bytes is φ(∑ᵢ vᵢGᵢ) in little-endian, with v₀ = 2 + 256·64 and
+// vᵢ the 16-byte chunks of the input read in little-endian.
+//
+// The address is 32 bytes long, 20-byte addresses being left padded with
+// zeros. treeIndex must be non-negative and smaller than 2²⁵⁶.
+func GetTreeKey(address [32]byte, treeIndex *big.Int, subIndex byte) []byte {
+	var input [64]byte
+	copy(input[:32], address[:])
+	treeIndex.FillBytes(input[32:])
+	slices.Reverse(input[32:])
+
+	var v [5]fr.Element
+	v[0].SetUint64(2 + 256*64)
+	for i := range 4 {
+		v[i+1] = leBytesToScalar(input[16*i : 16*(i+1)])
+	}
+	c := Commit(v[:])
+	h := c.MapToScalarField()
+
+	key := h.Bytes()
+	slices.Reverse(key[:])
+	key[StemSize] = subIndex
+	return key[:]
+}
+
+// GetTreeKeyForBasicData returns the key of the version, code size, nonce
+// and balance of an account.
+func GetTreeKeyForBasicData(address [32]byte) []byte {
+	return GetTreeKey(address, new(big.Int), BasicDataLeafKey)
+}
+
+// GetTreeKeyForCodeHash returns the key of the code hash of an account.
+func GetTreeKeyForCodeHash(address [32]byte) []byte {
+	return GetTreeKey(address, new(big.Int), CodeHashLeafKey)
+}
+
+// GetTreeKeyForCodeChunk returns the key of the chunkID-th 31-byte chunk of
+// the code of an account.
+func GetTreeKeyForCodeChunk(address [32]byte, chunkID uint64) []byte {
+	pos := new(big.Int).SetUint64(chunkID)
+	pos.Add(pos, big.NewInt(CodeOffset))
+	return getTreeKeyAt(address, pos)
+}
+
+// GetTreeKeyForStorageSlot returns the key of a storage slot of an account.
+// The slots below CodeOffset - HeaderStorageOffset are stored with the header.
+func GetTreeKeyForStorageSlot(address [32]byte, storageKey *big.Int) []byte {
+	pos := new(big.Int).Set(storageKey)
+	if storageKey.Cmp(big.NewInt(CodeOffset-HeaderStorageOffset)) < 0 {
+		pos.Add(pos, big.NewInt(HeaderStorageOffset))
+	} else {
+		pos.Add(pos, mainStorageOffset)
+	}
+	return getTreeKeyAt(address, pos)
+}
+
+// getTreeKeyAt returns the key of the value at position pos of an account
+func getTreeKeyAt(address [32]byte, pos *big.Int) []byte {
+	var treeIndex, subIndex big.Int
+	treeIndex.DivMod(pos, big.NewInt(NodeWidth), &subIndex)
+	return GetTreeKey(address, &treeIndex, byte(subIndex.Uint64()))
+}
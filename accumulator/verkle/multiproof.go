@@ -0,0 +1,156 @@
+package verkle
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrInvalidNbQueries      = errors.New("the number of commitments, points and values differ")
+	ErrInvalidPolynomialSize = errors.New("the polynomials must have NodeWidth evaluations")
+)
+
+// MultiProof is a proof that vector commitments Cᵢ to fᵢ open to fᵢ(zᵢ), for
+// zᵢ in the domain {0, …, NodeWidth-1}.
+//
+// With r, t random, g(X) = ∑ᵢ rⁱ(fᵢ(X) - fᵢ(zᵢ))/(X - zᵢ) and
+// h(X) = ∑ᵢ rⁱfᵢ(X)/(t - zᵢ), the proof consists of the commitment D to g
+// and of an IPAProof that E - D, where E = ∑ᵢ rⁱ/(t-zᵢ)·Cᵢ, opens to
+// ∑ᵢ rⁱfᵢ(zᵢ)/(t - zᵢ) at t.
+type MultiProof struct {
+	D   Point
+	IPA IPAProof
+}
+
+// ProveMulti returns a proof that the commitments cs to the polynomials fs,
+// in evaluation form on the domain, open to fs[i][zs[i]] at zs[i]. The
+// transcript starts with the label "vt", as in Ethereum.
+func ProveMulti(cs []Point, fs [][]fr.Element, zs []uint8) (MultiProof, error) {
+	if len(cs) != len(fs) || len(cs) != len(zs) {
+		return MultiProof{}, ErrInvalidNbQueries
+	}
+	for i := range fs {
+		if len(fs[i]) != NodeWidth {
+			return MultiProof{}, ErrInvalidPolynomialSize
+		}
+	}
+	return proveMulti(newTranscript("vt"), cs, fs, zs), nil
+}
+
+// VerifyMulti checks that proof proves that the commitments cs open to
+// ys[i] at zs[i].
+func VerifyMulti(cs []Point, zs []uint8, ys []fr.Element, proof *MultiProof) error {
+	if len(cs) != len(zs) || len(cs) != len(ys) {
+		return ErrInvalidNbQueries
+	}
+	return verifyMulti(newTranscript("vt"), cs, zs, ys, proof)
+}
+
+// bindQueries absorbs the queries and returns the challenge r
+func bindQueries(t *transcript, cs []Point, zs []uint8, ys []fr.Element) fr.Element {
+	t.domainSeparator("multiproof")
+	encoded := batchBytes(cs)
+	var z fr.Element
+	for i := range cs {
+		t.appendEncodedPoint(encoded[i][:], "C")
+		z.SetUint64(uint64(zs[i]))
+		t.appendScalar(&z, "z")
+		t.appendScalar(&ys[i], "y")
+	}
+	return t.challengeScalar("r")
+}
+
+func proveMulti(t *transcript, cs []Point, fs [][]fr.Element, zs []uint8) MultiProof {
+	ys := make([]fr.Element, len(fs))
+	for i := range fs {
+		ys[i] = fs[i][zs[i]]
+	}
+	r := bindQueries(t, cs, zs, ys)
+
+	// aggregate the polynomials opened at the same point: ∑ rⁱfᵢ for zᵢ = z
+	var grouped [NodeWidth][]fr.Element
+	var ri, s fr.Element
+	ri.SetOne()
+	for i := range fs {
+		z := zs[i]
+		if grouped[z] == nil {
+			grouped[z] = make([]fr.Element, NodeWidth)
+		}
+		for j := range fs[i] {
+			s.Mul(&fs[i][j], &ri)
+			grouped[z][j].Add(&grouped[z][j], &s)
+		}
+		ri.Mul(&ri, &r)
+	}
+
+	// g = ∑_z (grouped_z(X) - grouped_z(z)) / (X - z)
+	g := make([]fr.Element, NodeWidth)
+	for z := range grouped {
+		if grouped[z] == nil {
+			continue
+		}
+		q := divideOnDomain(z, grouped[z])
+		for j := range g {
+			g[j].Add(&g[j], &q[j])
+		}
+	}
+	var proof MultiProof
+	proof.D = Commit(g)
+	t.appendPoint(&proof.D, "D")
+	tc := t.challengeScalar("t")
+
+	// h = ∑_z grouped_z / (t - z)
+	h := make([]fr.Element, NodeWidth)
+	var den fr.Element
+	for z := range grouped {
+		if grouped[z] == nil {
+			continue
+		}
+		den.SetUint64(uint64(z))
+		den.Sub(&tc, &den).Inverse(&den)
+		for j := range h {
+			s.Mul(&grouped[z][j], &den)
+			h[j].Add(&h[j], &s)
+		}
+	}
+	e := Commit(h)
+	t.appendPoint(&e, "E")
+
+	var eMinusD Point
+	eMinusD.Sub(&e, &proof.D)
+	for j := range h {
+		h[j].Sub(&h[j], &g[j])
+	}
+	proof.IPA = proveIPA(t, &eMinusD, h, &tc)
+	return proof
+}
+
+func verifyMulti(t *transcript, cs []Point, zs []uint8, ys []fr.Element, proof *MultiProof) error {
+	r := bindQueries(t, cs, zs, ys)
+	t.appendPoint(&proof.D, "D")
+	tc := t.challengeScalar("t")
+
+	// coefficients rⁱ/(t - zᵢ)
+	coeffs := make([]fr.Element, len(cs))
+	var ri fr.Element
+	ri.SetOne()
+	for i := range coeffs {
+		coeffs[i].SetUint64(uint64(zs[i]))
+		coeffs[i].Sub(&tc, &coeffs[i])
+	}
+	coeffs = fr.BatchInvert(coeffs)
+	var y, s fr.Element
+	for i := range coeffs {
+		coeffs[i].Mul(&coeffs[i], &ri)
+		s.Mul(&coeffs[i], &ys[i])
+		y.Add(&y, &s)
+		ri.Mul(&ri, &r)
+	}
+
+	e := msm(cs, coeffs)
+	t.appendPoint(&e, "E")
+	var eMinusD Point
+	eMinusD.Sub(&e, &proof.D)
+	return verifyIPA(t, &eMinusD, &proof.IPA, &tc, &y)
+}
@@ -0,0 +1,104 @@
+package verkle
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomain(t *testing.T) {
+	t.Parallel()
+
+	f := make(fr.Vector, NodeWidth)
+	f.MustSetRandom()
+
+	// the Lagrange coefficients at a point of the domain select it
+	var z fr.Element
+	z.SetUint64(17)
+	require.Equal(t, f[17], innerProduct(f, lagrangeCoefficients(&z)))
+
+	// (f(X) - f(m)) / (X - m) at a random point
+	z.MustSetRandom()
+	fz := innerProduct(f, lagrangeCoefficients(&z))
+	for _, m := range []int{0, 1, 100, NodeWidth - 1} {
+		q := divideOnDomain(m, f)
+		qz := innerProduct(q, lagrangeCoefficients(&z))
+		var expected, d fr.Element
+		d.SetUint64(uint64(m))
+		d.Sub(&z, &d)
+		expected.Sub(&fz, &f[m]).Div(&expected, &d)
+		require.Equal(t, expected, qz, "m=%d", m)
+	}
+}
+
+func TestMultiProof(t *testing.T) {
+	t.Parallel()
+
+	const n = 6
+	cs := make([]Point, n)
+	fs := make([][]fr.Element, n)
+	zs := []uint8{0, 3, 3, 255, 7, 0}
+	ys := make([]fr.Element, n)
+	for i := range n {
+		fs[i] = make(fr.Vector, NodeWidth)
+		fr.Vector(fs[i]).MustSetRandom()
+		cs[i] = Commit(fs[i])
+		ys[i] = fs[i][zs[i]]
+	}
+
+	proof, err := ProveMulti(cs, fs, zs)
+	require.NoError(t, err)
+	require.NoError(t, VerifyMulti(cs, zs, ys, &proof))
+
+	// wrong value
+	wrongYs := make([]fr.Element, n)
+	copy(wrongYs, ys)
+	wrongYs[2].SetOne()
+	require.ErrorIs(t, VerifyMulti(cs, zs, wrongYs, &proof), ErrVerifyOpeningProof)
+
+	// wrong point
+	wrongZs := append([]uint8(nil), zs...)
+	wrongZs[1] = 4
+	require.ErrorIs(t, VerifyMulti(cs, wrongZs, ys, &proof), ErrVerifyOpeningProof)
+
+	// tampered proof
+	wrongProof := proof
+	wrongProof.IPA.A.SetOne()
+	require.ErrorIs(t, VerifyMulti(cs, zs, ys, &wrongProof), ErrVerifyOpeningProof)
+	wrongProof = proof
+	wrongProof.D.Add(&proof.D, &proof.D)
+	require.ErrorIs(t, VerifyMulti(cs, zs, ys, &wrongProof), ErrVerifyOpeningProof)
+
+	_, err = ProveMulti(cs, fs[1:], zs)
+	require.ErrorIs(t, err, ErrInvalidNbQueries)
+	_, err = ProveMulti(cs[:1], [][]fr.Element{fs[0][:5]}, zs[:1])
+	require.ErrorIs(t, err, ErrInvalidPolynomialSize)
+}
+
+func BenchmarkMultiProof(b *testing.B) {
+	const n = 64
+	cs := make([]Point, n)
+	fs := make([][]fr.Element, n)
+	zs := make([]uint8, n)
+	ys := make([]fr.Element, n)
+	for i := range n {
+		fs[i] = make(fr.Vector, NodeWidth)
+		fr.Vector(fs[i]).MustSetRandom()
+		cs[i] = Commit(fs[i])
+		zs[i] = uint8(i * 5)
+		ys[i] = fs[i][zs[i]]
+	}
+	proof, _ := ProveMulti(cs, fs, zs)
+
+	b.Run("prove", func(b *testing.B) {
+		for range b.N {
+			_, _ = ProveMulti(cs, fs, zs)
+		}
+	})
+	b.Run("verify", func(b *testing.B) {
+		for range b.N {
+			_ = VerifyMulti(cs, zs, ys, &proof)
+		}
+	})
+}
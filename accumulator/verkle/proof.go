@@ -0,0 +1,359 @@
+package verkle
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrInvalidProof = errors.New("invalid verkle proof")
+	ErrNoKeys       = errors.New("no keys to prove")
+)
+
+// ExtensionStatus is the status in the tree of the stem of a proven key.
+type ExtensionStatus uint8
+
+const (
+	// ExtensionAbsentEmpty means that the path of the stem ends at an empty
+	// child of an internal node.
+	ExtensionAbsentEmpty ExtensionStatus = iota
+	// ExtensionAbsentOther means that the path of the stem ends at a leaf
+	// node of another stem.
+	ExtensionAbsentOther
+	// ExtensionPresent means that the path of the stem ends at its leaf node.
+	ExtensionPresent
+)
+
+// StemProof describes the path of a stem in the tree.
+type StemProof struct {
+	// Depth is the length of the path: the leaf node or the empty child is
+	// the child Stem[Depth-1] of the internal node at depth Depth-1.
+	Depth  uint8
+	Status ExtensionStatus
+	// OtherStem is the stem of the leaf node at the end of the path, if Status
+	// is ExtensionAbsentOther.
+	OtherStem [StemSize]byte
+}
+
+// Proof is a proof of the values of a set of keys in a tree, absent keys
+// included.
+type Proof struct {
+	// Stems are the paths of the distinct stems of the keys, in increasing order.
+	Stems []StemProof
+	// Commitments are the commitments of the nodes on the paths, other than
+	// the root, in the order in which the paths are walked.
+	Commitments []Point
+	// Multiproof proves the openings of the commitments along the paths.
+	Multiproof MultiProof
+}
+
+// pathKind is the kind of node at the end of a path
+type pathKind uint8
+
+const (
+	kindInternal pathKind = iota
+	kindLeaf
+	kindEmpty
+)
+
+// pathNode is a node met by walking the proven paths
+type pathNode struct {
+	kind       pathKind
+	commitment *Point
+	// f are the values committed to, known only to the prover
+	f []fr.Element
+}
+
+// walker walks the proven paths, in the same order for the prover and the
+// verifier. It collects the openings of the multiproof, and rejects
+// inconsistent paths.
+type walker struct {
+	nodes    map[string]*pathNode // by path, and by stem ‖ half for the halves of the values
+	openings map[string]int       // index of the opening of a commitment at a point, by path ‖ point
+
+	cs []Point
+	fs [][]fr.Element
+	zs []uint8
+	ys []fr.Element
+
+	// next returns the commitment of a node met for the first time
+	next func(path string, kind pathKind) (*pathNode, error)
+}
+
+func newWalker(root *Point, rootF []fr.Element) *walker {
+	w := &walker{
+		nodes:    make(map[string]*pathNode),
+		openings: make(map[string]int),
+	}
+	w.nodes[""] = &pathNode{kind: kindInternal, commitment: root, f: rootF}
+	return w
+}
+
+// node returns the node at path, which must be of the given kind
+func (w *walker) node(path string, kind pathKind) (*pathNode, error) {
+	if n, ok := w.nodes[path]; ok {
+		if n.kind != kind {
+			return nil, ErrInvalidProof
+		}
+		return n, nil
+	}
+	n, err := w.next(path, kind)
+	if err != nil {
+		return nil, err
+	}
+	w.nodes[path] = n
+	return n, nil
+}
+
+// open records the opening of the node n, identified by id, at z to y
+func (w *walker) open(id string, n *pathNode, z uint8, y fr.Element) error {
+	key := id + string([]byte{z})
+	if i, ok := w.openings[key]; ok {
+		if !w.ys[i].Equal(&y) {
+			return ErrInvalidProof
+		}
+		return nil
+	}
+	w.openings[key] = len(w.ys)
+	w.cs = append(w.cs, *n.commitment)
+	w.fs = append(w.fs, n.f)
+	w.zs = append(w.zs, z)
+	w.ys = append(w.ys, y)
+	return nil
+}
+
+// walk records the openings proving the values of the keys of stem
+func (w *walker) walk(stem []byte, sp *StemProof, suffixes []byte, values [][]byte) error {
+	depth := int(sp.Depth)
+	if depth < 1 || depth > StemSize || sp.Status > ExtensionPresent {
+		return ErrInvalidProof
+	}
+
+	// the internal nodes on the path, and the end of the path
+	parent, err := w.node("", kindInternal)
+	if err != nil {
+		return err
+	}
+	for k := 1; k <= depth; k++ {
+		kind := kindInternal
+		if k == depth {
+			kind = [...]pathKind{kindEmpty, kindLeaf, kindLeaf}[sp.Status]
+		}
+		n, err := w.node(string(stem[:k]), kind)
+		if err != nil {
+			return err
+		}
+		var y fr.Element
+		if kind != kindEmpty {
+			y = n.commitment.MapToScalarField()
+		}
+		if err := w.open(string(stem[:k-1]), parent, stem[k-1], y); err != nil {
+			return err
+		}
+		parent = n
+	}
+	leafID := string(stem[:depth])
+
+	switch sp.Status {
+	case ExtensionAbsentEmpty, ExtensionAbsentOther:
+		for i := range values {
+			if values[i] != nil {
+				return ErrInvalidProof
+			}
+		}
+		if sp.Status == ExtensionAbsentEmpty {
+			return nil
+		}
+		if !bytes.Equal(sp.OtherStem[:depth], stem[:depth]) || bytes.Equal(sp.OtherStem[:], stem) {
+			return ErrInvalidProof
+		}
+		return w.openStem(leafID, parent, sp.OtherStem[:])
+	}
+
+	if err := w.openStem(leafID, parent, stem); err != nil {
+		return err
+	}
+	var v [2]fr.Element
+	for i, suffix := range suffixes {
+		half := int(suffix) / (NodeWidth / 2)
+		id := string(stem) + string([]byte{byte(half)})
+		c, err := w.node(id, kindLeaf)
+		if err != nil {
+			return err
+		}
+		if err := w.open(leafID, parent, uint8(2+half), c.commitment.MapToScalarField()); err != nil {
+			return err
+		}
+		valueToScalars(&v, values[i])
+		z := 2 * (suffix % (NodeWidth / 2))
+		if err := w.open(id, c, z, v[0]); err != nil {
+			return err
+		}
+		if err := w.open(id, c, z+1, v[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openStem records the openings of the leaf node n to the marker 1 and to stem
+func (w *walker) openStem(id string, n *pathNode, stem []byte) error {
+	var one fr.Element
+	one.SetOne()
+	if err := w.open(id, n, 0, one); err != nil {
+		return err
+	}
+	return w.open(id, n, 1, stemToScalar(stem))
+}
+
+// groupByStem sorts the keys and returns their distinct stems, and for each
+// stem the suffixes of its keys and the indices of these keys.
+func groupByStem(keys [][]byte) (stems [][]byte, suffixes [][]byte, indices [][]int, err error) {
+	order := make([]int, len(keys))
+	for i := range keys {
+		if len(keys[i]) != KeySize {
+			return nil, nil, nil, ErrInvalidKeySize
+		}
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return bytes.Compare(keys[i], keys[j]) })
+	for _, i := range order {
+		stem := keys[i][:StemSize]
+		if len(stems) == 0 || !bytes.Equal(stems[len(stems)-1], stem) {
+			stems = append(stems, stem)
+			suffixes = append(suffixes, nil)
+			indices = append(indices, nil)
+		}
+		last := len(stems) - 1
+		suffixes[last] = append(suffixes[last], keys[i][StemSize])
+		indices[last] = append(indices[last], i)
+	}
+	return stems, suffixes, indices, nil
+}
+
+// Prove commits to the tree and returns a proof of the values of keys,
+// which may be absent.
+func (t *Tree) Prove(keys [][]byte) (*Proof, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	stems, suffixes, _, err := groupByStem(keys)
+	if err != nil {
+		return nil, err
+	}
+	root := t.Commit()
+
+	proof := &Proof{Stems: make([]StemProof, len(stems))}
+	w := newWalker(&root, t.root.scalars[:])
+	for s, stem := range stems {
+		leaf, path := t.find(stem)
+		sp := &proof.Stems[s]
+		sp.Depth = uint8(len(path))
+		switch {
+		case leaf == nil:
+			sp.Status = ExtensionAbsentEmpty
+		case bytes.Equal(leaf.stem[:], stem):
+			sp.Status = ExtensionPresent
+		default:
+			sp.Status = ExtensionAbsentOther
+			sp.OtherStem = leaf.stem
+		}
+
+		w.next = func(p string, kind pathKind) (*pathNode, error) {
+			var n *pathNode
+			switch {
+			case len(p) > StemSize:
+				// a half of the values of leaf
+				half := int(p[StemSize])
+				c := &leaf.c1
+				if half == 1 {
+					c = &leaf.c2
+				}
+				n = &pathNode{kind: kind, commitment: c, f: leaf.valuesPolynomial(half)}
+			case kind == kindInternal:
+				in := path[len(p)]
+				n = &pathNode{kind: kind, commitment: &in.commitment, f: in.scalars[:]}
+			case kind == kindLeaf:
+				n = &pathNode{kind: kind, commitment: &leaf.c, f: leaf.polynomial()}
+			default:
+				var identity Point
+				identity.SetIdentity()
+				return &pathNode{kind: kind, commitment: &identity}, nil
+			}
+			proof.Commitments = append(proof.Commitments, *n.commitment)
+			return n, nil
+		}
+
+		values := make([][]byte, len(suffixes[s]))
+		if sp.Status == ExtensionPresent {
+			for i, suffix := range suffixes[s] {
+				values[i] = leaf.values[suffix]
+			}
+		}
+		if err := w.walk(stem, sp, suffixes[s], values); err != nil {
+			return nil, err
+		}
+	}
+	proof.Multiproof = proveMulti(newTranscript("vt"), w.cs, w.fs, w.zs)
+	return proof, nil
+}
+
+// Verify checks that proof proves that the tree committed to by root maps
+// keys[i] to values[i], where a nil value means that the key is absent.
+func Verify(root *Point, keys, values [][]byte, proof *Proof) error {
+	if len(keys) == 0 {
+		return ErrNoKeys
+	}
+	if len(keys) != len(values) {
+		return ErrInvalidProof
+	}
+	for i := range values {
+		if values[i] != nil && len(values[i]) != ValueSize {
+			return ErrInvalidValueSize
+		}
+	}
+	stems, suffixes, indices, err := groupByStem(keys)
+	if err != nil {
+		return err
+	}
+	if len(stems) != len(proof.Stems) {
+		return ErrInvalidProof
+	}
+
+	commitments := proof.Commitments
+	w := newWalker(root, nil)
+	w.next = func(p string, kind pathKind) (*pathNode, error) {
+		if kind == kindEmpty {
+			var identity Point
+			identity.SetIdentity()
+			return &pathNode{kind: kind, commitment: &identity}, nil
+		}
+		if len(commitments) == 0 {
+			return nil, ErrInvalidProof
+		}
+		n := &pathNode{kind: kind, commitment: &commitments[0]}
+		commitments = commitments[1:]
+		return n, nil
+	}
+
+	for s, stem := range stems {
+		vs := make([][]byte, len(indices[s]))
+		for i, k := range indices[s] {
+			vs[i] = values[k]
+		}
+		if err := w.walk(stem, &proof.Stems[s], suffixes[s], vs); err != nil {
+			return err
+		}
+	}
+	if len(commitments) != 0 {
+		return ErrInvalidProof
+	}
+
+	if err := verifyMulti(newTranscript("vt"), w.cs, w.zs, w.ys, &proof.Multiproof); err != nil {
+		return ErrInvalidProof
+	}
+	return nil
+}
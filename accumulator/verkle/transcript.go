@@ -0,0 +1,59 @@
+package verkle
+
+import (
+	"crypto/sha256"
+	"hash"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// transcript is the Fiat-Shamir transcript of the multiproof. Labels and
+// values are written to a sha256 state; a challenge is the digest of the
+// state, read in little-endian and reduced, after which the state is reset
+// and the challenge is absorbed.
+type transcript struct {
+	h hash.Hash
+}
+
+func newTranscript(label string) *transcript {
+	t := &transcript{h: sha256.New()}
+	t.domainSeparator(label)
+	return t
+}
+
+func (t *transcript) domainSeparator(label string) {
+	t.h.Write([]byte(label))
+}
+
+// appendScalar absorbs the little-endian encoding of s
+func (t *transcript) appendScalar(s *fr.Element, label string) {
+	b := s.Bytes()
+	slices.Reverse(b[:])
+	t.h.Write([]byte(label))
+	t.h.Write(b[:])
+}
+
+// appendPoint absorbs the encoding of p
+func (t *transcript) appendPoint(p *Point, label string) {
+	b := p.Bytes()
+	t.appendEncodedPoint(b[:], label)
+}
+
+// appendEncodedPoint absorbs the encoding b of a point
+func (t *transcript) appendEncodedPoint(b []byte, label string) {
+	t.h.Write([]byte(label))
+	t.h.Write(b)
+}
+
+// challengeScalar returns a challenge binded to everything absorbed so far
+func (t *transcript) challengeScalar(label string) fr.Element {
+	t.domainSeparator(label)
+	digest := t.h.Sum(nil)
+	slices.Reverse(digest)
+	var res fr.Element
+	res.SetBytes(digest)
+	t.h.Reset()
+	t.appendScalar(&res, label)
+	return res
+}
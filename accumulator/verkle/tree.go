@@ -0,0 +1,304 @@
+package verkle
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+const (
+	// KeySize is the size in bytes of a key: a stem and a suffix.
+	KeySize = 32
+	// StemSize is the size in bytes of a stem.
+	StemSize = 31
+	// ValueSize is the size in bytes of a value.
+	ValueSize = 32
+)
+
+var (
+	ErrInvalidKeySize   = errors.New("keys must be 32 bytes long")
+	ErrInvalidValueSize = errors.New("values must be 32 bytes long")
+)
+
+// Tree is an in-memory Verkle trie, as specified in EIP-6800.
+//
+// A key is made of a 31-byte stem and of a 1-byte suffix. The 256 values of a
+// stem are stored in a leaf node, which commits to them with two vector
+// commitments C₁ (suffixes 0 to 127) and C₂ (suffixes 128 to 255) to the
+// 128-bit halves of the values, and to the stem with
+//
+//	C = 1·G₀ + stem·G₁ + φ(C₁)·G₂ + φ(C₂)·G₃
+//
+// where φ is Point.MapToScalarField and the stem and the halves are read in
+// little-endian. The low half of a value is offset by 2¹²⁸, so that a zero
+// value and an absent value are distinguished. An internal node commits to
+// φ of the commitments of its 256 children, the child i containing the stems
+// whose byte at the depth of the node is i. A leaf node is stored as the child
+// of the shallowest internal node at which no other stem shares its path.
+//
+// The commitments are updated incrementally by Commit, only along the paths
+// of the values inserted since the previous call.
+//
+// A Tree is not safe for concurrent use.
+type Tree struct {
+	root internalNode
+}
+
+// New returns an empty tree.
+func New() *Tree {
+	t := new(Tree)
+	t.root.commitment.SetIdentity()
+	return t
+}
+
+// internalNode is a node of the tree, whose children are leaf nodes or
+// internal nodes.
+type internalNode struct {
+	children   [NodeWidth]any // nil, *internalNode or *leafNode
+	depth      int
+	commitment Point
+	// scalars are the images by φ of the commitments of the children at the
+	// last commit
+	scalars [NodeWidth]fr.Element
+	// dirty lists the children updated since the last commit
+	dirty []byte
+}
+
+// leafNode is a node of the tree holding the values of a stem.
+type leafNode struct {
+	stem   [StemSize]byte
+	values [NodeWidth][]byte
+	// c, c1, c2 are the commitments to the node and to the two halves of the values
+	c, c1, c2 Point
+	// old holds the values at the last commit of the suffixes updated since
+	old map[byte][]byte
+}
+
+func newInternalNode(depth int) *internalNode {
+	n := &internalNode{depth: depth}
+	n.commitment.SetIdentity()
+	return n
+}
+
+func newLeafNode(stem []byte) *leafNode {
+	n := &leafNode{old: make(map[byte][]byte)}
+	copy(n.stem[:], stem)
+	n.c.SetIdentity()
+	n.c1.SetIdentity()
+	n.c2.SetIdentity()
+	return n
+}
+
+// Insert sets the value of key, inserting it or updating it.
+func (t *Tree) Insert(key, value []byte) error {
+	if len(key) != KeySize {
+		return ErrInvalidKeySize
+	}
+	if len(value) != ValueSize {
+		return ErrInvalidValueSize
+	}
+	stem, suffix := key[:StemSize], key[StemSize]
+
+	n := &t.root
+	for {
+		i := stem[n.depth]
+		n.markDirty(i)
+		switch child := n.children[i].(type) {
+		case nil:
+			leaf := newLeafNode(stem)
+			leaf.set(suffix, value)
+			n.children[i] = leaf
+			return nil
+		case *internalNode:
+			n = child
+		case *leafNode:
+			if bytes.Equal(child.stem[:], stem) {
+				child.set(suffix, value)
+				return nil
+			}
+			// the stems share the path to the leaf: move the leaf one level down
+			in := newInternalNode(n.depth + 1)
+			j := child.stem[in.depth]
+			in.children[j] = child
+			in.markDirty(j)
+			n.children[i] = in
+			n = in
+		}
+	}
+}
+
+// Get returns the value of key, or nil if the key is not in the tree.
+func (t *Tree) Get(key []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKeySize
+	}
+	leaf, _ := t.find(key[:StemSize])
+	if leaf == nil || !bytes.Equal(leaf.stem[:], key[:StemSize]) {
+		return nil, nil
+	}
+	if v := leaf.values[key[StemSize]]; v != nil {
+		return bytes.Clone(v), nil
+	}
+	return nil, nil
+}
+
+// find returns the internal nodes on the path of stem, and the leaf node at
+// its end if any, which may have another stem.
+func (t *Tree) find(stem []byte) (*leafNode, []*internalNode) {
+	path := []*internalNode{&t.root}
+	for {
+		n := path[len(path)-1]
+		switch child := n.children[stem[n.depth]].(type) {
+		case *internalNode:
+			path = append(path, child)
+		case *leafNode:
+			return child, path
+		default:
+			return nil, path
+		}
+	}
+}
+
+// Commit updates the commitments of the nodes modified since the last call,
+// and returns the commitment to the root.
+func (t *Tree) Commit() Point {
+	t.root.commit()
+	return t.root.commitment
+}
+
+// markDirty records that the child i must be committed again
+func (n *internalNode) markDirty(i byte) {
+	for _, j := range n.dirty {
+		if j == i {
+			return
+		}
+	}
+	n.dirty = append(n.dirty, i)
+}
+
+// commit updates the commitment of n from the children updated since the last commit
+func (n *internalNode) commit() {
+	if len(n.dirty) == 0 {
+		return
+	}
+	points := make([]Point, len(n.dirty))
+	for k, i := range n.dirty {
+		switch child := n.children[i].(type) {
+		case *internalNode:
+			child.commit()
+			points[k] = child.commitment
+		case *leafNode:
+			child.commit()
+			points[k] = child.c
+		}
+	}
+	scalars := batchMapToScalarField(points)
+	var delta fr.Element
+	for k, i := range n.dirty {
+		delta.Sub(&scalars[k], &n.scalars[i])
+		n.commitment.addMul(int(i), &delta)
+		n.scalars[i] = scalars[k]
+	}
+	n.dirty = n.dirty[:0]
+}
+
+// set sets the value of suffix, recording the previous one
+func (n *leafNode) set(suffix byte, value []byte) {
+	if _, ok := n.old[suffix]; !ok {
+		n.old[suffix] = n.values[suffix]
+	}
+	n.values[suffix] = bytes.Clone(value)
+}
+
+// commit updates the commitments of n from the values updated since the last commit
+func (n *leafNode) commit() {
+	if len(n.old) == 0 {
+		return
+	}
+	var s1, s2 fr.Element
+	if n.c.IsIdentity() {
+		// first commit
+		var one, stem fr.Element
+		one.SetOne()
+		stem = stemToScalar(n.stem[:])
+		n.c.addMul(0, &one)
+		n.c.addMul(1, &stem)
+	} else {
+		s := batchMapToScalarField([]Point{n.c1, n.c2})
+		s1, s2 = s[0], s[1]
+	}
+
+	var oldV, newV [2]fr.Element
+	for suffix, old := range n.old {
+		c := &n.c1
+		if suffix >= NodeWidth/2 {
+			c = &n.c2
+		}
+		valueToScalars(&oldV, old)
+		valueToScalars(&newV, n.values[suffix])
+		for k := range oldV {
+			newV[k].Sub(&newV[k], &oldV[k])
+			c.addMul(2*int(suffix%(NodeWidth/2))+k, &newV[k])
+		}
+	}
+	clear(n.old)
+
+	s := batchMapToScalarField([]Point{n.c1, n.c2})
+	s[0].Sub(&s[0], &s1)
+	s[1].Sub(&s[1], &s2)
+	n.c.addMul(2, &s[0])
+	n.c.addMul(3, &s[1])
+}
+
+// polynomial returns the values committed to by n.c
+func (n *leafNode) polynomial() []fr.Element {
+	f := make([]fr.Element, NodeWidth)
+	f[0].SetOne()
+	f[1] = stemToScalar(n.stem[:])
+	s := batchMapToScalarField([]Point{n.c1, n.c2})
+	f[2], f[3] = s[0], s[1]
+	return f
+}
+
+// valuesPolynomial returns the values committed to by c1 (half = 0) or c2 (half = 1)
+func (n *leafNode) valuesPolynomial(half int) []fr.Element {
+	f := make([]fr.Element, NodeWidth)
+	var v [2]fr.Element
+	for i := range NodeWidth / 2 {
+		valueToScalars(&v, n.values[half*NodeWidth/2+i])
+		f[2*i], f[2*i+1] = v[0], v[1]
+	}
+	return f
+}
+
+// stemToScalar returns the stem read in little-endian
+func stemToScalar(stem []byte) fr.Element {
+	return leBytesToScalar(stem)
+}
+
+// valueToScalars sets res to the low half of value offset by 2¹²⁸ and to the
+// high half, read in little-endian, or to zero if the value is absent.
+func valueToScalars(res *[2]fr.Element, value []byte) {
+	if value == nil {
+		res[0].SetZero()
+		res[1].SetZero()
+		return
+	}
+	var low [17]byte
+	copy(low[:], value[:16])
+	low[16] = 1
+	res[0] = leBytesToScalar(low[:])
+	res[1] = leBytesToScalar(value[16:])
+}
+
+// leBytesToScalar returns b read in little-endian; b must be shorter than 32 bytes
+func leBytesToScalar(b []byte) fr.Element {
+	var be [fr.Bytes]byte
+	for i := range b {
+		be[len(be)-1-i] = b[i]
+	}
+	var res fr.Element
+	res.SetBytes(be[:])
+	return res
+}
@@ -0,0 +1,217 @@
+package verkle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomKey(r *rand.Rand, stem []byte) []byte {
+	key := make([]byte, KeySize)
+	for i := range key {
+		key[i] = byte(r.Uint32())
+	}
+	copy(key, stem)
+	return key
+}
+
+func TestTreeVectors(t *testing.T) {
+	t.Parallel()
+
+	// test vectors of go-verkle and rust-verkle: inserting the zero value at the zero key
+	tree := New()
+	key := make([]byte, KeySize)
+	require.NoError(t, tree.Insert(key, key))
+	root := tree.Commit()
+	b := root.Bytes()
+	require.Equal(t, "6b630905ce275e39f223e175242df2c1e8395e6f46ec71dce5557012c1334a5c", hex.EncodeToString(b[:]))
+	h := root.MapToScalarField()
+	b = h.Bytes()
+	slices.Reverse(b[:])
+	require.Equal(t, "ff00a9f3f2d4f58fc23bceebf6b2310419ceac2c30445e2f374e571487715015", hex.EncodeToString(b[:]))
+}
+
+func TestTreeInsertGet(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(1, 2)) //nolint: gosec // G404, no strong PRNG needed here
+	tree := New()
+	empty := tree.Commit()
+	require.True(t, empty.IsIdentity())
+
+	keys := make([][]byte, 50)
+	values := make([][]byte, len(keys))
+	for i := range keys {
+		// keys sharing stems and stem prefixes
+		keys[i] = randomKey(r, []byte{byte(i % 3), byte(i % 5)}[:i%3])
+		values[i] = randomKey(r, nil)
+		require.NoError(t, tree.Insert(keys[i], values[i]))
+	}
+	for i := range keys {
+		v, err := tree.Get(keys[i])
+		require.NoError(t, err)
+		require.Equal(t, values[i], v)
+	}
+	v, err := tree.Get(randomKey(r, nil))
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	// the incremental commitment is the commitment to the final tree,
+	// regardless of the order of the insertions
+	root := tree.Commit()
+	for i := range 10 {
+		values[i] = randomKey(r, nil)
+		require.NoError(t, tree.Insert(keys[i], values[i]))
+		if i == 4 {
+			tree.Commit()
+		}
+	}
+	updated := tree.Commit()
+	require.False(t, updated.Equal(&root))
+
+	other := New()
+	for _, i := range r.Perm(len(keys)) {
+		require.NoError(t, other.Insert(keys[i], values[i]))
+	}
+	fresh := other.Commit()
+	require.True(t, fresh.Equal(&updated))
+
+	require.ErrorIs(t, tree.Insert(keys[0][:31], values[0]), ErrInvalidKeySize)
+	require.ErrorIs(t, tree.Insert(keys[0], values[0][:31]), ErrInvalidValueSize)
+	_, err = tree.Get(nil)
+	require.ErrorIs(t, err, ErrInvalidKeySize)
+}
+
+func TestTreeProof(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(3, 4)) //nolint: gosec // G404, no strong PRNG needed here
+	tree := New()
+	var keys, values [][]byte
+	for i := range 30 {
+		key := randomKey(r, []byte{byte(i % 4)}[:i%2])
+		value := randomKey(r, nil)
+		keys = append(keys, key)
+		values = append(values, value)
+		require.NoError(t, tree.Insert(key, value))
+	}
+	root := tree.Commit()
+
+	// present keys, absent keys in a present stem, in an empty child, and
+	// with the path of another stem
+	absentSuffix := bytes.Clone(keys[3])
+	absentSuffix[StemSize]++
+	absentEmpty := randomKey(r, []byte{200})
+	absentOther := bytes.Clone(keys[5])
+	absentOther[StemSize-1]++
+
+	proven := [][]byte{keys[0], keys[7], absentSuffix, keys[3], absentEmpty, absentOther, keys[7]}
+	expected := [][]byte{values[0], values[7], nil, values[3], nil, nil, values[7]}
+	proof, err := tree.Prove(proven)
+	require.NoError(t, err)
+	require.NoError(t, Verify(&root, proven, expected, proof))
+
+	statuses := make(map[ExtensionStatus]bool)
+	for _, sp := range proof.Stems {
+		statuses[sp.Status] = true
+	}
+	require.Len(t, statuses, 3)
+
+	// wrong values
+	wrong := slices.Clone(expected)
+	wrong[1] = values[8]
+	require.ErrorIs(t, Verify(&root, proven, wrong, proof), ErrInvalidProof)
+	wrong = slices.Clone(expected)
+	wrong[2] = values[0]
+	require.ErrorIs(t, Verify(&root, proven, wrong, proof), ErrInvalidProof)
+	wrong = slices.Clone(expected)
+	wrong[4] = values[0]
+	require.ErrorIs(t, Verify(&root, proven, wrong, proof), ErrInvalidProof)
+	wrong = slices.Clone(expected)
+	wrong[0] = nil
+	require.ErrorIs(t, Verify(&root, proven, wrong, proof), ErrInvalidProof)
+
+	// wrong root
+	other := Generators()[0]
+	require.ErrorIs(t, Verify(&other, proven, expected, proof), ErrInvalidProof)
+
+	// tampered proof
+	tampered := *proof
+	tampered.Stems = slices.Clone(proof.Stems)
+	tampered.Stems[0].Depth++
+	require.ErrorIs(t, Verify(&root, proven, expected, &tampered), ErrInvalidProof)
+	tampered = *proof
+	tampered.Commitments = proof.Commitments[1:]
+	require.ErrorIs(t, Verify(&root, proven, expected, &tampered), ErrInvalidProof)
+	tampered = *proof
+	tampered.Commitments = slices.Clone(proof.Commitments)
+	tampered.Commitments[0] = other
+	require.ErrorIs(t, Verify(&root, proven, expected, &tampered), ErrInvalidProof)
+
+	// proofs on the empty tree
+	empty := New()
+	emptyRoot := empty.Commit()
+	proof, err = empty.Prove(proven[:2])
+	require.NoError(t, err)
+	require.NoError(t, Verify(&emptyRoot, proven[:2], [][]byte{nil, nil}, proof))
+
+	_, err = tree.Prove(nil)
+	require.ErrorIs(t, err, ErrNoKeys)
+}
+
+func TestTreeKey(t *testing.T) {
+	t.Parallel()
+
+	var address [32]byte
+	address[31] = 1
+	key := GetTreeKey(address, big.NewInt(0), 7)
+	require.Len(t, key, KeySize)
+	require.Equal(t, byte(7), key[StemSize])
+	require.Equal(t, key[:StemSize], GetTreeKeyForBasicData(address)[:StemSize])
+	require.Equal(t, GetTreeKey(address, big.NewInt(0), CodeHashLeafKey), GetTreeKeyForCodeHash(address))
+
+	// the header holds the first storage slots and code chunks
+	require.Equal(t, GetTreeKey(address, big.NewInt(0), HeaderStorageOffset+5), GetTreeKeyForStorageSlot(address, big.NewInt(5)))
+	require.Equal(t, GetTreeKey(address, big.NewInt(0), CodeOffset+3), GetTreeKeyForCodeChunk(address, 3))
+	require.Equal(t, GetTreeKey(address, big.NewInt(1), 2), GetTreeKeyForCodeChunk(address, NodeWidth-CodeOffset+2))
+
+	// the main storage
+	slot := big.NewInt(1000)
+	treeIndex := new(big.Int).Lsh(big.NewInt(1), 8*StemSize-8)
+	treeIndex.Add(treeIndex, big.NewInt(1000/NodeWidth))
+	require.Equal(t, GetTreeKey(address, treeIndex, 1000%NodeWidth), GetTreeKeyForStorageSlot(address, slot))
+
+	// the stem depends on the address and the tree index
+	address[0] = 1
+	require.NotEqual(t, key[:StemSize], GetTreeKey(address, big.NewInt(0), 7)[:StemSize])
+	address[0] = 0
+	require.NotEqual(t, key[:StemSize], GetTreeKey(address, big.NewInt(1), 7)[:StemSize])
+}
+
+func BenchmarkTree(b *testing.B) {
+	r := rand.New(rand.NewPCG(5, 6)) //nolint: gosec // G404, no strong PRNG needed here
+	tree := New()
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = randomKey(r, nil)
+		_ = tree.Insert(keys[i], keys[i])
+	}
+	tree.Commit()
+
+	b.Run("insert and commit", func(b *testing.B) {
+		for i := range b.N {
+			_ = tree.Insert(keys[i%len(keys)], keys[(i+1)%len(keys)])
+			tree.Commit()
+		}
+	})
+	b.Run("prove", func(b *testing.B) {
+		for range b.N {
+			_, _ = tree.Prove(keys[:100])
+		}
+	})
+}
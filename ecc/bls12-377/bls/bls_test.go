@@ -0,0 +1,351 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestBLS(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = 10
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] test the signing and verification", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+			wrong, _ := publicKey.Verify(sig, []byte("wrong message"), hFunc)
+
+			return flag && !wrong
+		},
+	))
+
+	properties.Property("[BLS12-377] test the signing and verification (no pre-hash)", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			sig, _ := privKey.Sign(msg, nil)
+			flag, _ := publicKey.Verify(sig, msg, nil)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-377] test the proof of possession", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			other, _ := GenerateKey(rand.Reader)
+
+			proof, _ := privKey.ProvePossession()
+			flag, _ := privKey.PublicKey.VerifyPossession(proof)
+			wrong, _ := other.PublicKey.VerifyPossession(proof)
+
+			// a signature on the public key bytes is not a valid proof of possession
+			sig, _ := privKey.Sign(privKey.PublicKey.Bytes(), nil)
+			wrongDST, _ := privKey.PublicKey.VerifyPossession(sig)
+
+			return flag && !wrong && !wrongDST
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+	const n = 4
+	privKeys := make([]*PrivateKey, n)
+	pubKeys := make([]PublicKey, n)
+	msgs := make([][]byte, n)
+	sameMsgSigs := make([][]byte, n)
+	distinctMsgSigs := make([][]byte, n)
+	msg := []byte("common message")
+	for i := range privKeys {
+		var err error
+		privKeys[i], err = GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKeys[i].PublicKey
+		msgs[i] = []byte{byte(i), 'm', 's', 'g'}
+		if sameMsgSigs[i], err = privKeys[i].Sign(msg, nil); err != nil {
+			t.Fatal(err)
+		}
+		if distinctMsgSigs[i], err = privKeys[i].Sign(msgs[i], nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("fast_aggregate_verify", func(t *testing.T) {
+		aggSig, err := Aggregate(sameMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := FastAggregateVerify(pubKeys, msg, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		ok, _ = FastAggregateVerify(pubKeys[1:], msg, aggSig, nil)
+		if ok {
+			t.Fatal("aggregate signature should not verify with a missing key")
+		}
+	})
+
+	t.Run("aggregate_verify", func(t *testing.T) {
+		aggSig, err := Aggregate(distinctMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := AggregateVerify(pubKeys, msgs, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		ok, _ = AggregateVerify(pubKeys, msgs, aggSig, nil)
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		if ok {
+			t.Fatal("aggregate signature should not verify with swapped messages")
+		}
+		if _, err = AggregateVerify(pubKeys, msgs[1:], aggSig, nil); err != errLengthMismatch {
+			t.Fatal("expected length mismatch error")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if _, err := Aggregate(nil); err != errNoSignatures {
+			t.Fatal("expected error on empty signature list")
+		}
+		if _, err := AggregatePublicKeys(nil); err != errNoPublicKeys {
+			t.Fatal("expected error on empty public key list")
+		}
+	})
+
+	t.Run("identity_public_key", func(t *testing.T) {
+		var pk PublicKey
+		ok, _ := pk.Verify(sameMsgSigs[0], msg, nil)
+		if ok {
+			t.Fatal("identity public key should be rejected")
+		}
+	})
+}
+
+func BenchmarkSign(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking BLS sign()")
+	b.ResetTimer()
+	for range b.N {
+		privKey.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking BLS verify()")
+	sig, _ := privKey.Sign(msg, nil)
+	b.ResetTimer()
+	for range b.N {
+		privKey.PublicKey.Verify(sig, msg, nil)
+	}
+}
+
+func TestBLSMinSig(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = 10
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] test the signing and verification", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKeyMinSig(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+			wrong, _ := publicKey.Verify(sig, []byte("wrong message"), hFunc)
+
+			return flag && !wrong
+		},
+	))
+
+	properties.Property("[BLS12-377] test the signing and verification (no pre-hash)", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKeyMinSig(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			sig, _ := privKey.Sign(msg, nil)
+			flag, _ := publicKey.Verify(sig, msg, nil)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-377] test the proof of possession", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKeyMinSig(rand.Reader)
+			other, _ := GenerateKeyMinSig(rand.Reader)
+
+			proof, _ := privKey.ProvePossession()
+			flag, _ := privKey.PublicKey.VerifyPossession(proof)
+			wrong, _ := other.PublicKey.VerifyPossession(proof)
+
+			// a signature on the public key bytes is not a valid proof of possession
+			sig, _ := privKey.Sign(privKey.PublicKey.Bytes(), nil)
+			wrongDST, _ := privKey.PublicKey.VerifyPossession(sig)
+
+			return flag && !wrong && !wrongDST
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestAggregateMinSig(t *testing.T) {
+	t.Parallel()
+	const n = 4
+	privKeys := make([]*PrivateKeyMinSig, n)
+	pubKeys := make([]PublicKeyMinSig, n)
+	msgs := make([][]byte, n)
+	sameMsgSigs := make([][]byte, n)
+	distinctMsgSigs := make([][]byte, n)
+	msg := []byte("common message")
+	for i := range privKeys {
+		var err error
+		privKeys[i], err = GenerateKeyMinSig(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKeys[i].PublicKey
+		msgs[i] = []byte{byte(i), 'm', 's', 'g'}
+		if sameMsgSigs[i], err = privKeys[i].Sign(msg, nil); err != nil {
+			t.Fatal(err)
+		}
+		if distinctMsgSigs[i], err = privKeys[i].Sign(msgs[i], nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("fast_aggregate_verify", func(t *testing.T) {
+		aggSig, err := AggregateMinSig(sameMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := FastAggregateVerifyMinSig(pubKeys, msg, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		ok, _ = FastAggregateVerifyMinSig(pubKeys[1:], msg, aggSig, nil)
+		if ok {
+			t.Fatal("aggregate signature should not verify with a missing key")
+		}
+	})
+
+	t.Run("aggregate_verify", func(t *testing.T) {
+		aggSig, err := AggregateMinSig(distinctMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := AggregateVerifyMinSig(pubKeys, msgs, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		ok, _ = AggregateVerifyMinSig(pubKeys, msgs, aggSig, nil)
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		if ok {
+			t.Fatal("aggregate signature should not verify with swapped messages")
+		}
+		if _, err = AggregateVerifyMinSig(pubKeys, msgs[1:], aggSig, nil); err != errLengthMismatch {
+			t.Fatal("expected length mismatch error")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if _, err := AggregateMinSig(nil); err != errNoSignatures {
+			t.Fatal("expected error on empty signature list")
+		}
+		if _, err := AggregatePublicKeysMinSig(nil); err != errNoPublicKeys {
+			t.Fatal("expected error on empty public key list")
+		}
+	})
+
+	t.Run("identity_public_key", func(t *testing.T) {
+		var pk PublicKeyMinSig
+		ok, _ := pk.Verify(sameMsgSigs[0], msg, nil)
+		if ok {
+			t.Fatal("identity public key should be rejected")
+		}
+	})
+}
+
+func BenchmarkSignMinSig(b *testing.B) {
+	privKey, _ := GenerateKeyMinSig(rand.Reader)
+	msg := []byte("benchmarking BLS sign()")
+	b.ResetTimer()
+	for range b.N {
+		privKey.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerifyMinSig(b *testing.B) {
+	privKey, _ := GenerateKeyMinSig(rand.Reader)
+	msg := []byte("benchmarking BLS verify()")
+	sig, _ := privKey.Sign(msg, nil)
+	b.ResetTimer()
+	for range b.N {
+		privKey.PublicKey.Verify(sig, msg, nil)
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+	t.Parallel()
+	ikm := make([]byte, 32)
+	if _, err := KeyGen(ikm[:31], nil); err != errShortIKM {
+		t.Fatal("expected error for short input keying material")
+	}
+	sk1, err := KeyGen(ikm, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk2, err := KeyGenMinSig(ikm, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.scalar != sk2.scalar {
+		t.Fatal("both variants should derive the same secret scalar")
+	}
+	sk3, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.scalar == sk3.scalar {
+		t.Fatal("key info should change the secret scalar")
+	}
+}
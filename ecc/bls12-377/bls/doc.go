@@ -0,0 +1,20 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package bls provides BLS signatures on the bls12-377 curve.
+//
+// The implementation follows the proof-of-possession scheme of the IETF draft
+// and is available in two variants:
+//   - minimal-pubkey-size: public keys in G1, signatures in G2 ([PrivateKey], [PublicKey]);
+//   - minimal-signature-size: public keys in G2, signatures in G1 ([PrivateKeyMinSig], [PublicKeyMinSig]).
+//
+// Messages are mapped to the signature group with the hash-to-curve suites of
+// the bls12377 package, using the ciphersuite identifiers as domain
+// separation tags.
+//
+// Documentation:
+// - IETF draft: https://datatracker.ietf.org/doc/draft-irtf-cfrg-bls-signature/
+// - Hashing to elliptic curves: https://datatracker.ietf.org/doc/rfc9380/
+package bls
@@ -0,0 +1,199 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/subtle"
+	"io"
+)
+
+// Bytes returns the binary representation of the public key. The serialization
+// follows [ZCash serialization] format: it is the compressed representation of
+// the point.
+//
+// [ZCash serialization]: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-pairing-friendly-curves-11#zcash_rep_bls12_381
+func (pk *PublicKey) Bytes() []byte {
+	var res [sizePublicKey]byte
+	pkBin := pk.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:], pkBin[:])
+	return res[:]
+}
+
+// SetBytes sets the public key from the serialized representation obtained
+// using [PublicKey.Bytes].
+//
+// The length of the input buffer must be at least the size of the compressed
+// public key. It returns an error if:
+// * the buffer is too short
+// * computing valid point from compressed representation fails
+// * the point is not in the prime order subgroup
+//
+// Any excess bytes in the input buffer are ignored. The method returns the number of bytes
+// read.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := pk.A.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	return sizePublicKey, nil
+}
+
+// Bytes returns the binary representation of the private key. The binary representation
+// of the private key consists of the concatenation of the binary representation of the
+// corresponding public key and the private key scalar encoded in big-endian format.
+//
+// See also [PublicKey.Bytes].
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := privKey.PublicKey.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pubkBin[:])
+	subtle.ConstantTimeCopy(1, res[sizePublicKey:], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets the private key from the serialized representation obtained
+// using [PrivateKey.Bytes].
+//
+// The length of the input buffer must be at least the size of the private key. It returns an error if:
+// * the buffer is too short
+// * computing valid point from compressed representation fails
+//
+// Any excess bytes in the input buffer are ignored. The method returns the number of bytes
+// read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := privKey.PublicKey.A.SetBytes(buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	subtle.ConstantTimeCopy(1, privKey.scalar[:], buf[sizePublicKey:sizePrivateKey])
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of the signature, that is the
+// compressed representation of the point.
+func (sig *Signature) Bytes() []byte {
+	var res [sizeSignature]byte
+	sigBin := sig.S.Bytes()
+	subtle.ConstantTimeCopy(1, res[:], sigBin[:])
+	return res[:]
+}
+
+// SetBytes sets the signature from the binary representation obtained using
+// [Signature.Bytes].
+//
+// It returns an error if:
+// * the buffer does not have the expected signature size
+// * computing valid point from compressed representation fails
+// * the point is not in the prime order subgroup
+//
+// The method returns the number of bytes read.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignature {
+		return 0, errWrongSize
+	}
+	if _, err := sig.S.SetBytes(buf); err != nil {
+		return 0, err
+	}
+	return sizeSignature, nil
+}
+
+// Bytes returns the binary representation of the public key. The serialization
+// follows [ZCash serialization] format: it is the compressed representation of
+// the point.
+//
+// [ZCash serialization]: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-pairing-friendly-curves-11#zcash_rep_bls12_381
+func (pk *PublicKeyMinSig) Bytes() []byte {
+	var res [sizePublicKeyMinSig]byte
+	pkBin := pk.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:], pkBin[:])
+	return res[:]
+}
+
+// SetBytes sets the public key from the serialized representation obtained
+// using [PublicKeyMinSig.Bytes].
+//
+// The length of the input buffer must be at least the size of the compressed
+// public key. It returns an error if:
+// * the buffer is too short
+// * computing valid point from compressed representation fails
+// * the point is not in the prime order subgroup
+//
+// Any excess bytes in the input buffer are ignored. The method returns the number of bytes
+// read.
+func (pk *PublicKeyMinSig) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKeyMinSig {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := pk.A.SetBytes(buf[:sizePublicKeyMinSig]); err != nil {
+		return 0, err
+	}
+	return sizePublicKeyMinSig, nil
+}
+
+// Bytes returns the binary representation of the private key. The binary representation
+// of the private key consists of the concatenation of the binary representation of the
+// corresponding public key and the private key scalar encoded in big-endian format.
+//
+// See also [PublicKeyMinSig.Bytes].
+func (privKey *PrivateKeyMinSig) Bytes() []byte {
+	var res [sizePrivateKeyMinSig]byte
+	pubkBin := privKey.PublicKey.A.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKeyMinSig], pubkBin[:])
+	subtle.ConstantTimeCopy(1, res[sizePublicKeyMinSig:], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets the private key from the serialized representation obtained
+// using [PrivateKeyMinSig.Bytes].
+//
+// The length of the input buffer must be at least the size of the private key. It returns an error if:
+// * the buffer is too short
+// * computing valid point from compressed representation fails
+//
+// Any excess bytes in the input buffer are ignored. The method returns the number of bytes
+// read.
+func (privKey *PrivateKeyMinSig) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKeyMinSig {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := privKey.PublicKey.A.SetBytes(buf[:sizePublicKeyMinSig]); err != nil {
+		return 0, err
+	}
+	subtle.ConstantTimeCopy(1, privKey.scalar[:], buf[sizePublicKeyMinSig:sizePrivateKeyMinSig])
+	return sizePrivateKeyMinSig, nil
+}
+
+// Bytes returns the binary representation of the signature, that is the
+// compressed representation of the point.
+func (sig *SignatureMinSig) Bytes() []byte {
+	var res [sizeSignatureMinSig]byte
+	sigBin := sig.S.Bytes()
+	subtle.ConstantTimeCopy(1, res[:], sigBin[:])
+	return res[:]
+}
+
+// SetBytes sets the signature from the binary representation obtained using
+// [SignatureMinSig.Bytes].
+//
+// It returns an error if:
+// * the buffer does not have the expected signature size
+// * computing valid point from compressed representation fails
+// * the point is not in the prime order subgroup
+//
+// The method returns the number of bytes read.
+func (sig *SignatureMinSig) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignatureMinSig {
+		return 0, errWrongSize
+	}
+	if _, err := sig.S.SetBytes(buf); err != nil {
+		return 0, err
+	}
+	return sizeSignatureMinSig, nil
+}
@@ -75,6 +75,21 @@ func Fold(p [][]fr.Element) []fr.Element {
 // digests is the list (FoldAndCommit(p[i]))ᵢ. It is assumed that the list has been computed beforehand
 // and provided as an input to not duplicate computations.
 func BatchOpen(p [][][]fr.Element, digests []kzg.Digest, points [][]fr.Element, hf hash.Hash, pk kzg.ProvingKey, dataTranscript ...[]byte) (OpeningProof, error) {
+	return batchOpen(p, points, func(foldedPolynomials [][]fr.Element, newPoints [][]fr.Element) (shplonk.OpeningProof, error) {
+		return shplonk.BatchOpen(foldedPolynomials, digests, newPoints, hf, pk, dataTranscript...)
+	})
+}
+
+// BatchOpenWithTranscript is BatchOpen, where the challenges are squeezed from the transcript t.
+func BatchOpenWithTranscript(p [][][]fr.Element, digests []kzg.Digest, points [][]fr.Element, t kzg.Transcript, pk kzg.ProvingKey) (OpeningProof, error) {
+	return batchOpen(p, points, func(foldedPolynomials [][]fr.Element, newPoints [][]fr.Element) (shplonk.OpeningProof, error) {
+		return shplonk.BatchOpenWithTranscript(foldedPolynomials, digests, newPoints, t, pk)
+	})
+}
+
+// batchOpen folds the polynomials and extends the sets of points, and opens the
+// folded polynomials on the extended sets with shplonkOpen.
+func batchOpen(p [][][]fr.Element, points [][]fr.Element, shplonkOpen func(foldedPolynomials [][]fr.Element, newPoints [][]fr.Element) (shplonk.OpeningProof, error)) (OpeningProof, error) {
 
 	var res OpeningProof
 
@@ -134,7 +149,7 @@ func BatchOpen(p [][][]fr.Element, digests []kzg.Digest, points [][]fr.Element,
 	}
 
 	// step 5: shplonk open the list of single polynomials on the new sets
-	res.SOpeningProof, err = shplonk.BatchOpen(foldedPolynomials, digests, newPoints, hf, pk, dataTranscript...)
+	res.SOpeningProof, err = shplonkOpen(foldedPolynomials, newPoints)
 
 	return res, err
 
@@ -147,6 +162,21 @@ func BatchOpen(p [][][]fr.Element, digests []kzg.Digest, points [][]fr.Element,
 // folding. Namely, the outer claimed values are the evaluation of the original polynomials (so before they
 // were folded) at the relevant powers of the points.
 func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element, hf hash.Hash, vk kzg.VerifyingKey, dataTranscript ...[]byte) error {
+	return batchVerify(proof, points, func(extendedPoints [][]fr.Element) error {
+		return shplonk.BatchVerify(proof.SOpeningProof, digests, extendedPoints, hf, vk, dataTranscript...)
+	})
+}
+
+// BatchVerifyWithTranscript is BatchVerify, where the challenges are squeezed from the transcript t.
+func BatchVerifyWithTranscript(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element, t kzg.Transcript, vk kzg.VerifyingKey) error {
+	return batchVerify(proof, points, func(extendedPoints [][]fr.Element) error {
+		return shplonk.BatchVerifyWithTranscript(proof.SOpeningProof, digests, extendedPoints, t, vk)
+	})
+}
+
+// batchVerify checks the consistency of the claimed values with the folded ones,
+// and verifies the embedded shplonk proof on the extended sets with shplonkVerify.
+func batchVerify(proof OpeningProof, points [][]fr.Element, shplonkVerify func(extendedPoints [][]fr.Element) error) error {
 
 	// step 0: consistency checks between the folded claimed values of shplonk and the claimed
 	// values at the powers of the Sᵢ
@@ -202,9 +232,7 @@ func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element
 			return err
 		}
 	}
-	err = shplonk.BatchVerify(proof.SOpeningProof, digests, extendedPoints, hf, vk, dataTranscript...)
-
-	return err
+	return shplonkVerify(extendedPoints)
 }
 
 // utils
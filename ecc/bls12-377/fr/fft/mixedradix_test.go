@@ -0,0 +1,134 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// mixedRadixSizes returns the cardinalities of mixed-radix domains used in the tests
+func mixedRadixSizes(t *testing.T) []uint64 {
+	var sizes []uint64
+	for _, m := range []uint64{1, 2, 3, 6, 9, 12, 16, 18, 20, 27, 28, 35, 45, 96, 210, 3 * 256, 7 * 64, 3 << 12, 5 << 11, 1 << 13} {
+		if _, err := mixedRadices(m); err == nil {
+			sizes = append(sizes, m)
+		}
+	}
+	require.NotEmpty(t, sizes)
+	return sizes
+}
+
+func TestMixedRadixFFT(t *testing.T) {
+	assert := require.New(t)
+
+	for _, m := range mixedRadixSizes(t) {
+		domain, err := NewMixedRadixDomain(m)
+		assert.NoError(err)
+
+		var one, omega fr.Element
+		one.SetOne()
+		omega.Exp(domain.Generator, new(big.Int).SetUint64(m))
+		assert.True(omega.Equal(&one), "generator of order %d", m)
+
+		pol := make([]fr.Element, m)
+		for i := range pol {
+			pol[i].MustSetRandom()
+		}
+		backup := make([]fr.Element, m)
+
+		for _, coset := range []bool{false, true} {
+			var opts []Option
+			var x fr.Element
+			x.SetOne()
+			if coset {
+				opts = append(opts, OnCoset())
+				x.Set(&domain.FrMultiplicativeGen)
+			}
+
+			copy(backup, pol)
+			domain.FFT(pol, opts...)
+			for i := range pol {
+				// for large domains, only some evaluations are checked
+				if m <= 1<<10 || i%257 == 0 || i == len(pol)-1 {
+					eval := evaluatePolynomial(backup, x)
+					assert.True(eval.Equal(&pol[i]), "size %d, coset %t, evaluation %d", m, coset, i)
+				}
+				x.Mul(&x, &domain.Generator)
+			}
+
+			domain.FFTInverse(pol, opts...)
+			for i := range pol {
+				assert.True(pol[i].Equal(&backup[i]), "size %d, coset %t, inverse %d", m, coset, i)
+			}
+		}
+	}
+
+	// the input must have the size of the domain
+	domain, err := NewMixedRadixDomain(2)
+	assert.NoError(err)
+	assert.Panics(func() { domain.FFT(make([]fr.Element, 4)) })
+}
+
+func TestMixedRadixDomainSize(t *testing.T) {
+	assert := require.New(t)
+
+	// power of 2 sizes are always available
+	for _, m := range []uint64{1, 2, 1 << 10} {
+		_, err := NewMixedRadixDomain(m)
+		assert.NoError(err)
+	}
+
+	// factors larger than 7 are not supported
+	for _, m := range []uint64{0, 11, 13 * 4, 121} {
+		_, err := NewMixedRadixDomain(m)
+		assert.ErrorIs(err, ErrMixedRadixSize)
+	}
+
+	for m := uint64(1); m <= 1<<12; m += 7 {
+		n, err := NextMixedRadixSize(m)
+		assert.NoError(err)
+		assert.GreaterOrEqual(n, m)
+		assert.LessOrEqual(n, ecc.NextPowerOfTwo(m))
+		_, err = mixedRadices(n)
+		assert.NoError(err, "size %d for %d", n, m)
+
+		// n is the smallest valid size
+		for k := m; k < n; k++ {
+			_, err = mixedRadices(k)
+			assert.Error(err, "size %d for %d", k, m)
+		}
+	}
+
+	_, err := NextMixedRadixSize(math.MaxUint64)
+	assert.ErrorIs(err, ErrMixedRadixSize)
+}
+
+func BenchmarkMixedRadixFFT(b *testing.B) {
+	const logSize = 18
+	for _, m := range []uint64{1 << logSize, 3 << (logSize - 2)} {
+		domain, err := NewMixedRadixDomain(m)
+		if err != nil {
+			continue
+		}
+		pol := make([]fr.Element, m)
+		for i := range pol {
+			pol[i].MustSetRandom()
+		}
+		b.Run(fmt.Sprintf("size=%d", m), func(b *testing.B) {
+			for b.Loop() {
+				domain.FFT(pol)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package gkr implements the GKR protocol for layered arithmetic circuits.
+//
+// A circuit is a list of wires. Input wires have no inputs; every other wire
+// applies a low degree gate to the values of previous wires. The same circuit is
+// evaluated on 2ⁿ instances, and the assignment of each wire is seen as a
+// multilinear polynomial in n variables.
+//
+// The prover convinces the verifier that the outputs are correctly computed from
+// the inputs by reducing a random evaluation of the outputs to evaluations of the
+// inputs, one wire at a time, with a sumcheck for each wire. The protocol is made
+// non-interactive with a Fiat-Shamir transcript.
+//
+// See https://people.cs.georgetown.edu/jthaler/GKRNote.pdf for a description of
+// the protocol.
+package gkr
@@ -0,0 +1,98 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// Gate is a low degree multivariate polynomial g, computing the value of a wire
+// from the values of its inputs.
+type Gate interface {
+	// Evaluate returns g(x₁, …, xₖ). It must not modify its inputs.
+	Evaluate(x ...fr.Element) fr.Element
+	// Degree returns the total degree of g.
+	Degree() int
+}
+
+// DefaultGates returns the built-in gates, indexed by name. The returned map can
+// be extended with custom gates before calling NewCircuit.
+func DefaultGates() map[string]Gate {
+	return map[string]Gate{
+		"identity": IdentityGate{},
+		"add":      AddGate{},
+		"sub":      SubGate{},
+		"neg":      NegGate{},
+		"mul":      MulGate{},
+	}
+}
+
+// IdentityGate is the gate x ↦ x
+type IdentityGate struct{}
+
+func (IdentityGate) Evaluate(x ...fr.Element) fr.Element {
+	return x[0]
+}
+
+func (IdentityGate) Degree() int {
+	return 1
+}
+
+// AddGate is the gate (x₁, …, xₖ) ↦ x₁ + … + xₖ
+type AddGate struct{}
+
+func (AddGate) Evaluate(x ...fr.Element) fr.Element {
+	var res fr.Element
+	for i := range x {
+		res.Add(&res, &x[i])
+	}
+	return res
+}
+
+func (AddGate) Degree() int {
+	return 1
+}
+
+// SubGate is the gate (x₁, …, xₖ) ↦ x₁ - x₂ - … - xₖ
+type SubGate struct{}
+
+func (SubGate) Evaluate(x ...fr.Element) fr.Element {
+	res := x[0]
+	for i := 1; i < len(x); i++ {
+		res.Sub(&res, &x[i])
+	}
+	return res
+}
+
+func (SubGate) Degree() int {
+	return 1
+}
+
+// NegGate is the gate x ↦ -x
+type NegGate struct{}
+
+func (NegGate) Evaluate(x ...fr.Element) fr.Element {
+	var res fr.Element
+	res.Neg(&x[0])
+	return res
+}
+
+func (NegGate) Degree() int {
+	return 1
+}
+
+// MulGate is the gate (x₁, x₂) ↦ x₁⋅x₂
+type MulGate struct{}
+
+func (MulGate) Evaluate(x ...fr.Element) fr.Element {
+	var res fr.Element
+	res.Mul(&x[0], &x[1])
+	return res
+}
+
+func (MulGate) Degree() int {
+	return 2
+}
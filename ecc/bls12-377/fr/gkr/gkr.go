@@ -0,0 +1,594 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/sumcheck"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+var (
+	errEmptyCircuit      = errors.New("gkr: empty circuit")
+	errUnknownGate       = errors.New("gkr: unknown gate")
+	errMissingGate       = errors.New("gkr: missing gate")
+	errWrongInput        = errors.New("gkr: wire inputs must be previous wires")
+	errWrongAssignment   = errors.New("gkr: wrong assignment size")
+	errMissingAssignment = errors.New("gkr: missing wire assignment")
+	errWrongProofSize    = errors.New("gkr: proof and circuit sizes differ")
+	errUnexpectedProof   = errors.New("gkr: unexpected proof for an input wire")
+	errWrongFinalEval    = errors.New("gkr: wrong number of final evaluations")
+	errFinalEvalMismatch = errors.New("gkr: final evaluations do not match the last round")
+	errInputEvalMismatch = errors.New("gkr: input evaluation mismatch")
+)
+
+// Wire is a wire of a circuit. If Inputs is empty, the wire is an input of the
+// circuit and Gate is ignored. Otherwise, the value of the wire is the gate
+// applied to the values of the wires Inputs, which are indices of previous
+// wires in the circuit. A wire may appear several times in Inputs.
+type Wire struct {
+	Gate   Gate
+	Inputs []int
+}
+
+// IsInput returns true if the wire is an input of the circuit.
+func (w *Wire) IsInput() bool {
+	return len(w.Inputs) == 0
+}
+
+// Circuit is a list of wires in topological order, i.e. the inputs of a wire
+// come before it. The wires that are not the input of any other wire are the
+// outputs of the circuit.
+type Circuit []Wire
+
+// WireInfo is a serializable description of a wire: the name of its gate and
+// the indices of its inputs. Input wires have no gate and no inputs.
+type WireInfo struct {
+	Gate   string `json:"gate,omitempty"`
+	Inputs []int  `json:"inputs,omitempty"`
+}
+
+// CircuitInfo is a serializable description of a circuit.
+type CircuitInfo []WireInfo
+
+// NewCircuit builds a circuit from its description, looking the gates up by
+// name. See DefaultGates for the built-in gates.
+func NewCircuit(info CircuitInfo, gates map[string]Gate) (Circuit, error) {
+	c := make(Circuit, len(info))
+	for i := range info {
+		c[i].Inputs = info[i].Inputs
+		if c[i].IsInput() {
+			continue
+		}
+		gate, ok := gates[info[i].Gate]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownGate, info[i].Gate)
+		}
+		c[i].Gate = gate
+	}
+	return c, c.validate()
+}
+
+// validate checks that the circuit is not empty, that all its non-input wires
+// have a gate and that they only depend on previous wires.
+func (c Circuit) validate() error {
+	if len(c) == 0 {
+		return errEmptyCircuit
+	}
+	for i := range c {
+		if c[i].IsInput() {
+			continue
+		}
+		if c[i].Gate == nil {
+			return fmt.Errorf("%w: wire %d", errMissingGate, i)
+		}
+		for _, in := range c[i].Inputs {
+			if in < 0 || in >= i {
+				return fmt.Errorf("%w: wire %d", errWrongInput, i)
+			}
+		}
+	}
+	return nil
+}
+
+// isOutput returns, for each wire, whether it is an output of the circuit.
+func (c Circuit) isOutput() []bool {
+	res := make([]bool, len(c))
+	for i := range res {
+		res[i] = true
+	}
+	for i := range c {
+		for _, in := range c[i].Inputs {
+			res[in] = false
+		}
+	}
+	return res
+}
+
+// uniqueInputs returns the distinct inputs of the wire, and for each of its
+// inputs, its index among the distinct ones.
+func (w *Wire) uniqueInputs() (unique []int, indices []int) {
+	indices = make([]int, len(w.Inputs))
+	position := make(map[int]int, len(w.Inputs))
+	for k, in := range w.Inputs {
+		p, ok := position[in]
+		if !ok {
+			p = len(unique)
+			position[in] = p
+			unique = append(unique, in)
+		}
+		indices[k] = p
+	}
+	return
+}
+
+// WireAssignment holds the values of the wires of a circuit on all the
+// instances: assignment[i][j] is the value of wire i on instance j. The number
+// of instances must be a power of two, at least 2.
+type WireAssignment []polynomial.MultiLin
+
+// Complete computes the values of the non-input wires from the values of the
+// input wires, which must all be assigned.
+func (a WireAssignment) Complete(c Circuit) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if len(a) != len(c) {
+		return errWrongAssignment
+	}
+	size := -1
+	for i := range c {
+		if !c[i].IsInput() {
+			continue
+		}
+		if a[i] == nil {
+			return fmt.Errorf("%w: wire %d", errMissingAssignment, i)
+		}
+		if size != -1 && len(a[i]) != size {
+			return errWrongAssignment
+		}
+		size = len(a[i])
+	}
+	if size == -1 {
+		return errMissingAssignment
+	}
+
+	for i := range c {
+		if c[i].IsInput() {
+			continue
+		}
+		w := &c[i]
+		a[i] = make(polynomial.MultiLin, size)
+		parallel.Execute(size, func(start, end int) {
+			inputs := make([]fr.Element, len(w.Inputs))
+			for j := start; j < end; j++ {
+				for k, in := range w.Inputs {
+					inputs[k] = a[in][j]
+				}
+				a[i][j] = w.Gate.Evaluate(inputs...)
+			}
+		})
+	}
+	return nil
+}
+
+// nbVars checks that the wires in required are assigned with the same power
+// of two number of instances, at least 2, and returns its logarithm.
+func (a WireAssignment) nbVars(c Circuit, required func(i int) bool) (int, error) {
+	if len(a) != len(c) {
+		return 0, errWrongAssignment
+	}
+	size := -1
+	for i := range c {
+		if !required(i) {
+			continue
+		}
+		if a[i] == nil {
+			return 0, fmt.Errorf("%w: wire %d", errMissingAssignment, i)
+		}
+		if size != -1 && len(a[i]) != size {
+			return 0, errWrongAssignment
+		}
+		size = len(a[i])
+	}
+	if size < 2 || size&(size-1) != 0 {
+		return 0, errWrongAssignment
+	}
+	return bits.TrailingZeros(uint(size)), nil
+}
+
+// Proof of the correct evaluation of a circuit. It contains a sumcheck proof
+// for each wire, empty for the input wires.
+type Proof []sumcheck.Proof
+
+// Prove produces a proof that the assignment of the circuit is correct. All the
+// wires must be assigned, see WireAssignment.Complete.
+//
+// The transcript settings are used to derive the challenges. The base
+// challenges should commit to the inputs and outputs of the circuit.
+func Prove(c Circuit, assignment WireAssignment, transcriptSettings fiatshamir.Settings) (Proof, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	nbVars, err := assignment.nbVars(c, func(int) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	firstChallenge, err := setup(nbVars, &transcriptSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make(claimsManager, len(c))
+	isOutput := c.isOutput()
+	for i := range c {
+		if isOutput[i] && !c[i].IsInput() {
+			claims.add(i, firstChallenge, assignment[i].Evaluate(firstChallenge, nil))
+		}
+	}
+
+	proof := make(Proof, len(c))
+	var baseChallenges [][]byte
+	for i := len(c) - 1; i >= 0; i-- {
+		if c[i].IsInput() {
+			// the verifier checks the claims about input wires itself
+			continue
+		}
+		wireClaims := newProverClaims(c, i, assignment, claims, nbVars)
+		settings := fiatshamir.WithTranscript(transcriptSettings.Transcript, wirePrefix(&transcriptSettings, i), baseChallenges...)
+		if proof[i], err = sumcheck.Prove(wireClaims, settings); err != nil {
+			return nil, err
+		}
+		baseChallenges = toBytes(proof[i].FinalEvalProof)
+	}
+
+	return proof, nil
+}
+
+// Verify checks a proof of the correct evaluation of the circuit. The input and
+// output wires must be assigned; the other ones are ignored.
+//
+// The transcript settings must match the ones used by the prover.
+func Verify(c Circuit, assignment WireAssignment, proof Proof, transcriptSettings fiatshamir.Settings) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if len(proof) != len(c) {
+		return errWrongProofSize
+	}
+	isOutput := c.isOutput()
+	nbVars, err := assignment.nbVars(c, func(i int) bool { return isOutput[i] || c[i].IsInput() })
+	if err != nil {
+		return err
+	}
+
+	firstChallenge, err := setup(nbVars, &transcriptSettings)
+	if err != nil {
+		return err
+	}
+
+	claims := make(claimsManager, len(c))
+	for i := range c {
+		if isOutput[i] && !c[i].IsInput() {
+			claims.add(i, firstChallenge, assignment[i].Evaluate(firstChallenge, nil))
+		}
+	}
+
+	var baseChallenges [][]byte
+	for i := len(c) - 1; i >= 0; i-- {
+		if c[i].IsInput() {
+			if len(proof[i].PartialSumPolys) != 0 || len(proof[i].FinalEvalProof) != 0 {
+				return fmt.Errorf("%w: wire %d", errUnexpectedProof, i)
+			}
+			for _, cl := range claims[i] {
+				v := assignment[i].Evaluate(cl.point, nil)
+				if !v.Equal(&cl.value) {
+					return fmt.Errorf("%w: wire %d", errInputEvalMismatch, i)
+				}
+			}
+			continue
+		}
+		wireClaims := newLazyClaims(c, i, claims, nbVars)
+		settings := fiatshamir.WithTranscript(transcriptSettings.Transcript, wirePrefix(&transcriptSettings, i), baseChallenges...)
+		if err = sumcheck.Verify(wireClaims, proof[i], settings); err != nil {
+			return fmt.Errorf("wire %d: %w", i, err)
+		}
+		baseChallenges = toBytes(proof[i].FinalEvalProof)
+	}
+
+	return nil
+}
+
+// setup registers the challenges of the first evaluation point in the
+// transcript, creating it if needed, binds the base challenges to the first
+// one and returns the evaluation point.
+func setup(nbVars int, settings *fiatshamir.Settings) ([]fr.Element, error) {
+	challengeNames := make([]string, nbVars)
+	for i := range challengeNames {
+		challengeNames[i] = settings.Prefix + "fC." + strconv.Itoa(i)
+	}
+
+	if settings.Transcript == nil {
+		settings.Transcript = fiatshamir.NewTranscript(settings.Hash, challengeNames...)
+	} else {
+		for _, name := range challengeNames {
+			if err := settings.Transcript.NewChallenge(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range settings.BaseChallenges {
+		if err := settings.Transcript.Bind(challengeNames[0], settings.BaseChallenges[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]fr.Element, nbVars)
+	for i, name := range challengeNames {
+		bytes, err := settings.Transcript.ComputeChallenge(name)
+		if err != nil {
+			return nil, err
+		}
+		res[i].SetBytes(bytes)
+	}
+	return res, nil
+}
+
+// wirePrefix returns the prefix of the names of the sumcheck challenges for wire i
+func wirePrefix(settings *fiatshamir.Settings, i int) string {
+	return settings.Prefix + "w" + strconv.Itoa(i) + "."
+}
+
+func toBytes(v fr.Vector) [][]byte {
+	res := make([][]byte, len(v))
+	for i := range v {
+		b := v[i].Bytes()
+		res[i] = b[:]
+	}
+	return res
+}
+
+// claim states that the multilinear extension of a wire assignment evaluates
+// to value at point.
+type claim struct {
+	point []fr.Element
+	value fr.Element
+}
+
+// claimsManager tracks the claims about each wire
+type claimsManager [][]claim
+
+func (m claimsManager) add(wire int, point []fr.Element, value fr.Element) {
+	m[wire] = append(m[wire], claim{point: point, value: value})
+}
+
+// proverClaims is the prover side of the sumcheck for a non-input wire w with
+// claims w(pⱼ) = vⱼ, 1 ≤ j ≤ m, i.e.
+//
+//	∑_{x∈{0,1}ⁿ} ∑_{1≤j≤m} aʲ⁻¹ Eq(pⱼ, x) g(w₁(x), …, wₖ(x)) = ∑_{1≤j≤m} aʲ⁻¹ vⱼ
+//
+// where g is the gate of w and the wᵢ its inputs.
+type proverClaims struct {
+	gate         Gate
+	points       [][]fr.Element
+	uniqueInputs []int
+	inputIndices []int
+	inputTables  []polynomial.MultiLin // assignments of the unique inputs, folded as the challenges come
+	eq           polynomial.MultiLin   // ∑ⱼ aʲ⁻¹ Eq(pⱼ, ·), folded as the challenges come
+	manager      claimsManager
+	nbVars       int
+}
+
+func newProverClaims(c Circuit, wire int, assignment WireAssignment, manager claimsManager, nbVars int) *proverClaims {
+	res := &proverClaims{
+		gate:    c[wire].Gate,
+		manager: manager,
+		nbVars:  nbVars,
+	}
+	res.points = make([][]fr.Element, len(manager[wire]))
+	for j := range manager[wire] {
+		res.points[j] = manager[wire][j].point
+	}
+	res.uniqueInputs, res.inputIndices = c[wire].uniqueInputs()
+	res.inputTables = make([]polynomial.MultiLin, len(res.uniqueInputs))
+	for i, in := range res.uniqueInputs {
+		res.inputTables[i] = assignment[in].Clone()
+	}
+	return res
+}
+
+func (c *proverClaims) NbClaims() int {
+	return len(c.points)
+}
+
+func (c *proverClaims) NbVars() int {
+	return c.nbVars
+}
+
+func (c *proverClaims) Combine(a fr.Element) fr.Vector {
+	// eq = ∑ⱼ aʲ⁻¹ Eq(pⱼ, ·)
+	c.eq = make(polynomial.MultiLin, 1<<c.nbVars)
+	c.eq[0].SetOne()
+	c.eq.Eq(c.points[0])
+
+	if len(c.points) > 1 {
+		tmp := make(polynomial.MultiLin, len(c.eq))
+		var coeff fr.Element
+		coeff.Set(&a)
+		for j := 1; j < len(c.points); j++ {
+			tmp[0] = coeff
+			tmp.Eq(c.points[j])
+			c.eq.Add(c.eq, tmp)
+			coeff.Mul(&coeff, &a)
+		}
+	}
+
+	return c.computeGJ()
+}
+
+func (c *proverClaims) Next(r fr.Element) fr.Vector {
+	c.fold(r)
+	return c.computeGJ()
+}
+
+// ProverFinalEval returns the evaluations of the unique inputs of the wire at
+// r, and records them as claims about these inputs.
+func (c *proverClaims) ProverFinalEval(r []fr.Element) fr.Vector {
+	c.fold(r[len(r)-1])
+	point := make([]fr.Element, len(r))
+	copy(point, r)
+
+	evaluations := make(fr.Vector, len(c.inputTables))
+	for i := range c.inputTables {
+		evaluations[i] = c.inputTables[i][0]
+		c.manager.add(c.uniqueInputs[i], point, evaluations[i])
+	}
+	return evaluations
+}
+
+func (c *proverClaims) fold(r fr.Element) {
+	c.eq.Fold(r)
+	for i := range c.inputTables {
+		c.inputTables[i].Fold(r)
+	}
+}
+
+// computeGJ returns the evaluations at 1, …, deg(g)+1 of the partial sum
+// polynomial ∑_{x∈{0,1}ⁿ⁻ʲ} eq(X, x) g(w₁(X, x), …, wₖ(X, x)), where eq and
+// the wᵢ have already been folded on the first j-1 variables.
+func (c *proverClaims) computeGJ() fr.Vector {
+	degree := c.gate.Degree() + 1
+	mid := len(c.eq) / 2
+
+	res := make(fr.Vector, degree)
+	var lock sync.Mutex
+
+	parallel.Execute(mid, func(start, end int) {
+		partial := make(fr.Vector, degree)
+		values := make([]fr.Element, len(c.inputTables))
+		steps := make([]fr.Element, len(c.inputTables))
+		gateInputs := make([]fr.Element, len(c.inputIndices))
+		var eq, eqStep, v fr.Element
+
+		for x := start; x < end; x++ {
+			// p(X, x) = p(0, x) + X⋅(p(1, x) - p(0, x))
+			eq = c.eq[mid+x]
+			eqStep.Sub(&c.eq[mid+x], &c.eq[x])
+			for i, t := range c.inputTables {
+				values[i] = t[mid+x]
+				steps[i].Sub(&t[mid+x], &t[x])
+			}
+			for e := range degree {
+				if e != 0 {
+					eq.Add(&eq, &eqStep)
+					for i := range values {
+						values[i].Add(&values[i], &steps[i])
+					}
+				}
+				for k, i := range c.inputIndices {
+					gateInputs[k] = values[i]
+				}
+				v = c.gate.Evaluate(gateInputs...)
+				v.Mul(&v, &eq)
+				partial[e].Add(&partial[e], &v)
+			}
+		}
+
+		lock.Lock()
+		for e := range res {
+			res[e].Add(&res[e], &partial[e])
+		}
+		lock.Unlock()
+	})
+
+	return res
+}
+
+// lazyClaims is the verifier side of the sumcheck for a non-input wire, see
+// proverClaims.
+type lazyClaims struct {
+	gate         Gate
+	claims       []claim
+	uniqueInputs []int
+	inputIndices []int
+	manager      claimsManager
+	nbVars       int
+}
+
+func newLazyClaims(c Circuit, wire int, manager claimsManager, nbVars int) *lazyClaims {
+	res := &lazyClaims{
+		gate:    c[wire].Gate,
+		claims:  manager[wire],
+		manager: manager,
+		nbVars:  nbVars,
+	}
+	res.uniqueInputs, res.inputIndices = c[wire].uniqueInputs()
+	return res
+}
+
+func (c *lazyClaims) NbClaims() int {
+	return len(c.claims)
+}
+
+func (c *lazyClaims) NbVars() int {
+	return c.nbVars
+}
+
+func (c *lazyClaims) CombinedSum(a fr.Element) fr.Element {
+	var res fr.Element
+	for j := len(c.claims) - 1; j >= 0; j-- {
+		res.Mul(&res, &a)
+		res.Add(&res, &c.claims[j].value)
+	}
+	return res
+}
+
+func (c *lazyClaims) Degree(int) int {
+	return c.gate.Degree() + 1
+}
+
+// VerifyFinalEval checks that ∑ⱼ aʲ⁻¹ Eq(pⱼ, r) g(w₁(r), …, wₖ(r)) is the
+// purported value, where the wᵢ(r) are given by the proof, and records them as
+// claims about the inputs.
+func (c *lazyClaims) VerifyFinalEval(r []fr.Element, combinationCoeff, purportedValue fr.Element, proof fr.Vector) error {
+	if len(proof) != len(c.uniqueInputs) {
+		return errWrongFinalEval
+	}
+
+	var eq, v fr.Element
+	for j := len(c.claims) - 1; j >= 0; j-- {
+		v = polynomial.EvalEq(c.claims[j].point, r)
+		eq.Mul(&eq, &combinationCoeff)
+		eq.Add(&eq, &v)
+	}
+
+	gateInputs := make([]fr.Element, len(c.inputIndices))
+	for k, i := range c.inputIndices {
+		gateInputs[k] = proof[i]
+	}
+	v = c.gate.Evaluate(gateInputs...)
+	v.Mul(&v, &eq)
+	if !v.Equal(&purportedValue) {
+		return errFinalEvalMismatch
+	}
+
+	point := make([]fr.Element, len(r))
+	copy(point, r)
+	for i, in := range c.uniqueInputs {
+		c.manager.add(in, point, proof[i])
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/stretchr/testify/require"
+)
+
+// pow5Gate is the gate x ↦ x⁵, as used in MiMC rounds
+type pow5Gate struct{}
+
+func (pow5Gate) Evaluate(x ...fr.Element) fr.Element {
+	var res fr.Element
+	res.Square(&x[0]).Square(&res).Mul(&res, &x[0])
+	return res
+}
+
+func (pow5Gate) Degree() int {
+	return 5
+}
+
+func testGates() map[string]Gate {
+	gates := DefaultGates()
+	gates["pow5"] = pow5Gate{}
+	return gates
+}
+
+var testCircuits = map[string]CircuitInfo{
+	"identity":     {{}, {Gate: "identity", Inputs: []int{0}}},
+	"mul":          {{}, {}, {Gate: "mul", Inputs: []int{0, 1}}},
+	"square":       {{}, {Gate: "mul", Inputs: []int{0, 0}}},
+	"two_layers":   {{}, {}, {Gate: "add", Inputs: []int{0, 1}}, {Gate: "mul", Inputs: []int{2, 0}}},
+	"two_outputs":  {{}, {}, {Gate: "mul", Inputs: []int{0, 1}}, {Gate: "sub", Inputs: []int{0, 1}}},
+	"input_output": {{}, {}, {Gate: "neg", Inputs: []int{1}}},
+	"mimc_rounds": {
+		{}, {},
+		{Gate: "add", Inputs: []int{0, 1}}, {Gate: "pow5", Inputs: []int{2}},
+		{Gate: "add", Inputs: []int{3, 1}}, {Gate: "pow5", Inputs: []int{4}},
+		{Gate: "add", Inputs: []int{5, 0, 1}},
+	},
+}
+
+// randomAssignment returns a complete assignment of the circuit with random inputs
+func randomAssignment(t *testing.T, c Circuit, nbVars int) WireAssignment {
+	assignment := make(WireAssignment, len(c))
+	for i := range c {
+		if c[i].IsInput() {
+			assignment[i] = make(polynomial.MultiLin, 1<<nbVars)
+			fr.Vector(assignment[i]).MustSetRandom()
+		}
+	}
+	require.NoError(t, assignment.Complete(c))
+	return assignment
+}
+
+// verifierAssignment returns the assignment restricted to the inputs and outputs
+func verifierAssignment(c Circuit, assignment WireAssignment) WireAssignment {
+	isOutput := c.isOutput()
+	res := make(WireAssignment, len(c))
+	for i := range c {
+		if c[i].IsInput() || isOutput[i] {
+			res[i] = assignment[i]
+		}
+	}
+	return res
+}
+
+func TestGkr(t *testing.T) {
+	t.Parallel()
+	for name, info := range testCircuits {
+		for _, nbVars := range []int{1, 3} {
+			t.Run(fmt.Sprintf("%s/vars=%d", name, nbVars), func(t *testing.T) {
+				c, err := NewCircuit(info, testGates())
+				require.NoError(t, err)
+				assignment := randomAssignment(t, c, nbVars)
+
+				proof, err := Prove(c, assignment, fiatshamir.WithHash(sha256.New(), []byte("base")))
+				require.NoError(t, err)
+
+				vAssignment := verifierAssignment(c, assignment)
+				require.NoError(t, Verify(c, vAssignment, proof, fiatshamir.WithHash(sha256.New(), []byte("base"))))
+				require.Error(t, Verify(c, vAssignment, proof, fiatshamir.WithHash(sha256.New(), []byte("other"))))
+
+				// wrong output
+				last := len(c) - 1
+				wrong := make(WireAssignment, len(vAssignment))
+				copy(wrong, vAssignment)
+				wrong[last] = vAssignment[last].Clone()
+				wrong[last][0].SetOne()
+				require.Error(t, Verify(c, wrong, proof, fiatshamir.WithHash(sha256.New(), []byte("base"))))
+
+				// wrong input
+				isOutput := c.isOutput()
+				for i := range c {
+					if c[i].IsInput() && !isOutput[i] {
+						copy(wrong, vAssignment)
+						wrong[i] = vAssignment[i].Clone()
+						wrong[i][1].SetOne()
+						require.Error(t, Verify(c, wrong, proof, fiatshamir.WithHash(sha256.New(), []byte("base"))))
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGkrTamperedProof(t *testing.T) {
+	t.Parallel()
+	c, err := NewCircuit(testCircuits["mimc_rounds"], testGates())
+	require.NoError(t, err)
+	assignment := randomAssignment(t, c, 4)
+	vAssignment := verifierAssignment(c, assignment)
+
+	proof, err := Prove(c, assignment, fiatshamir.WithHash(sha256.New()))
+	require.NoError(t, err)
+	require.NoError(t, Verify(c, vAssignment, proof, fiatshamir.WithHash(sha256.New())))
+
+	for i := range proof {
+		if c[i].IsInput() {
+			continue
+		}
+		// a wrong final evaluation of the inputs is either caught by the
+		// final check, or carried over to the next wires
+		saved := proof[i].FinalEvalProof[0]
+		proof[i].FinalEvalProof[0].SetOne()
+		require.Error(t, Verify(c, vAssignment, proof, fiatshamir.WithHash(sha256.New())), "wire %d", i)
+		proof[i].FinalEvalProof[0] = saved
+
+		saved = proof[i].PartialSumPolys[0][0]
+		proof[i].PartialSumPolys[0][0].SetOne()
+		require.Error(t, Verify(c, vAssignment, proof, fiatshamir.WithHash(sha256.New())), "wire %d", i)
+		proof[i].PartialSumPolys[0][0] = saved
+	}
+	require.NoError(t, Verify(c, vAssignment, proof, fiatshamir.WithHash(sha256.New())))
+
+	proof[0].FinalEvalProof = fr.Vector{fr.One()}
+	require.ErrorIs(t, Verify(c, vAssignment, proof, fiatshamir.WithHash(sha256.New())), errUnexpectedProof)
+	require.ErrorIs(t, Verify(c, vAssignment, proof[1:], fiatshamir.WithHash(sha256.New())), errWrongProofSize)
+}
+
+func TestGkrSharedTranscript(t *testing.T) {
+	t.Parallel()
+	c, err := NewCircuit(testCircuits["two_layers"], testGates())
+	require.NoError(t, err)
+	assignment := randomAssignment(t, c, 3)
+
+	newTranscript := func() *fiatshamir.Transcript {
+		transcript := fiatshamir.NewTranscript(sha256.New(), "alpha")
+		_, err := transcript.ComputeChallenge("alpha")
+		require.NoError(t, err)
+		return transcript
+	}
+
+	proof, err := Prove(c, assignment, fiatshamir.WithTranscript(newTranscript(), "gkr."))
+	require.NoError(t, err)
+	require.NoError(t, Verify(c, verifierAssignment(c, assignment), proof, fiatshamir.WithTranscript(newTranscript(), "gkr.")))
+}
+
+func TestProofSerialization(t *testing.T) {
+	t.Parallel()
+	c, err := NewCircuit(testCircuits["mimc_rounds"], testGates())
+	require.NoError(t, err)
+	assignment := randomAssignment(t, c, 3)
+
+	proof, err := Prove(c, assignment, fiatshamir.WithHash(sha256.New()))
+	require.NoError(t, err)
+
+	data, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Proof
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, len(proof), len(decoded))
+
+	require.NoError(t, Verify(c, verifierAssignment(c, assignment), decoded, fiatshamir.WithHash(sha256.New())))
+}
+
+func TestNewCircuitErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewCircuit(CircuitInfo{}, DefaultGates())
+	require.ErrorIs(t, err, errEmptyCircuit)
+
+	_, err = NewCircuit(CircuitInfo{{}, {Gate: "pow5", Inputs: []int{0}}}, DefaultGates())
+	require.ErrorIs(t, err, errUnknownGate)
+
+	_, err = NewCircuit(CircuitInfo{{}, {Gate: "identity", Inputs: []int{1}}}, DefaultGates())
+	require.ErrorIs(t, err, errWrongInput)
+
+	c := Circuit{{}, {Inputs: []int{0}}}
+	require.ErrorIs(t, c.validate(), errMissingGate)
+
+	c, err = NewCircuit(testCircuits["mul"], DefaultGates())
+	require.NoError(t, err)
+	assignment := make(WireAssignment, len(c))
+	assignment[0] = make(polynomial.MultiLin, 4)
+	require.ErrorIs(t, assignment.Complete(c), errMissingAssignment)
+	assignment[1] = make(polynomial.MultiLin, 2)
+	require.ErrorIs(t, assignment.Complete(c), errWrongAssignment)
+}
+
+func BenchmarkProve(b *testing.B) {
+	const nbVars = 14
+	c, _ := NewCircuit(testCircuits["mimc_rounds"], testGates())
+	assignment := make(WireAssignment, len(c))
+	for i := range c {
+		if c[i].IsInput() {
+			assignment[i] = make(polynomial.MultiLin, 1<<nbVars)
+			fr.Vector(assignment[i]).MustSetRandom()
+		}
+	}
+	_ = assignment.Complete(c)
+
+	b.ResetTimer()
+	for range b.N {
+		_, _ = Prove(c, assignment, fiatshamir.WithHash(sha256.New()))
+	}
+}
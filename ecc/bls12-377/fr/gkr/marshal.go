@@ -0,0 +1,62 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// WriteTo writes the binary encoding of the proof to w: the number of wires as
+// a big-endian uint32, followed by the sumcheck proof of each wire.
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(*proof))); err != nil {
+		return 0, err
+	}
+	n := int64(4)
+	for i := range *proof {
+		m, err := (*proof)[i].WriteTo(w)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadFrom reads the binary encoding of a proof from r, as written by WriteTo.
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var nbWires uint32
+	if err := binary.Read(r, binary.BigEndian, &nbWires); err != nil {
+		return 0, err
+	}
+	n := int64(4)
+	*proof = make(Proof, nbWires)
+	for i := range *proof {
+		m, err := (*proof)[i].ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (proof *Proof) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (proof *Proof) UnmarshalBinary(data []byte) error {
+	_, err := proof.ReadFrom(bytes.NewReader(data))
+	return err
+}
@@ -0,0 +1,314 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"errors"
+	"math/bits"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/parallel"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+)
+
+// errors related to the lookup arguments.
+var (
+	ErrNumberColumns = errors.New("the table and the queries must have the same number of columns")
+	ErrNotInTable    = errors.New("a queried row is not in the table")
+)
+
+// BuildLookupMultiplicities returns the multiplicity polynomial m of a lookup of
+// the rows of queries in the rows of table: m(ωⁱ) is the number of rows of the
+// queries equal to the i-th row of the table. When a row appears several times in
+// the table, its multiplicity is carried by its first occurrence.
+// * table, queries the columns of the table and of the queries, of the same size
+// * expectedForm expected form of the resulting polynomial
+// The polynomials are put in Lagrange form in the process.
+// It returns ErrNotInTable if a row of the queries is not in the table.
+func BuildLookupMultiplicities(table, queries []*Polynomial, expectedForm Form, domain *fft.Domain) (*Polynomial, error) {
+	domain, err := checkLookup(table, queries, domain)
+	if err != nil {
+		return nil, err
+	}
+	n := int(domain.Cardinality)
+
+	index := make(map[string]int, n)
+	for i := range n {
+		key := lookupRowKey(table, i)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	coeffs := make([]fr.Element, n)
+	var one fr.Element
+	one.SetOne()
+	for i := range n {
+		j, ok := index[lookupRowKey(queries, i)]
+		if !ok {
+			return nil, ErrNotInTable
+		}
+		coeffs[j].Add(&coeffs[j], &one)
+	}
+
+	res := NewPolynomial(&coeffs, expectedForm)
+	putInExpectedFormFromLagrangeRegular(res, domain, expectedForm)
+	return res, nil
+}
+
+// BuildLogUpAccumulator builds the accumulator polynomial of the LogUp
+// (log-derivative) lookup argument. The rows of the columns are compressed as
+// f = ∑ₖ αᵏ⋅queriesₖ and t = ∑ₖ αᵏ⋅tableₖ, and the function returns the
+// polynomial Z whose evaluation on the j-th root of unity is
+// Z(ωʲ) = ∑_{i<j} 1/(β-f(ωⁱ)) - m(ωⁱ)/(β-t(ωⁱ))
+// * table, queries the columns of the table and of the queries, of the same size
+// * multiplicities the multiplicities of the rows of the table, see BuildLookupMultiplicities
+// * alpha, beta challenges
+// * expectedForm expected form of the resulting polynomial
+// The polynomials are put in Lagrange form in the process.
+//
+// If the lookup holds, Z(1) = 0 and for all x in the domain,
+// (Z(ωx)-Z(x))⋅(β-f(x))⋅(β-t(x)) = (β-t(x)) - m(x)⋅(β-f(x)).
+func BuildLogUpAccumulator(table, queries []*Polynomial, multiplicities *Polynomial, alpha, beta fr.Element, expectedForm Form, domain *fft.Domain) (*Polynomial, error) {
+	domain, err := checkLookup(table, queries, domain)
+	if err != nil {
+		return nil, err
+	}
+	n := int(domain.Cardinality)
+	if multiplicities.coefficients.Len() != n {
+		return nil, ErrInconsistentSize
+	}
+	multiplicities.ToLagrange(domain)
+
+	f := compressColumns(queries, alpha)
+	t := compressColumns(table, alpha)
+
+	// (β-f(ωⁱ)) and (β-t(ωⁱ)) are inverted at once
+	den := make([]fr.Element, 2*n)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			den[i].Sub(&beta, &f[i])
+			den[n+i].Sub(&beta, &t[i])
+		}
+	})
+	den = fr.BatchInvert(den)
+
+	nn := uint64(64 - bits.TrailingZeros(uint(n)))
+	parallel.Execute(n, func(start, end int) {
+		var m fr.Element
+		for i := start; i < end; i++ {
+			m = multiplicities.Coefficients()[layoutIndex(multiplicities, i, nn)]
+			m.Mul(&m, &den[n+i])
+			den[i].Sub(&den[i], &m)
+		}
+	})
+
+	coeffs := make([]fr.Element, n)
+	for i := range n - 1 {
+		coeffs[i+1].Add(&coeffs[i], &den[i])
+	}
+
+	res := NewPolynomial(&coeffs, expectedForm)
+	putInExpectedFormFromLagrangeRegular(res, domain, expectedForm)
+	return res, nil
+}
+
+// BuildPlookupSortedVectors returns the polynomials h₁, h₂ of the Plookup
+// argument. The rows of the columns are compressed as f = ∑ₖ αᵏ⋅queriesₖ and
+// t = ∑ₖ αᵏ⋅tableₖ, and s is the concatenation of f and t sorted by t, that is,
+// each entry of f is placed next to its occurrence in t. s is then split in
+// alternation: h₁(ωⁱ) = s₂ᵢ and h₂(ωⁱ) = s₂ᵢ₊₁.
+// * table, queries the columns of the table and of the queries, of the same size
+// * alpha challenge
+// * expectedForm expected form of the resulting polynomials
+// The polynomials are put in Lagrange form in the process.
+// It returns ErrNotInTable if a row of the queries is not in the table.
+func BuildPlookupSortedVectors(table, queries []*Polynomial, alpha fr.Element, expectedForm Form, domain *fft.Domain) (h1, h2 *Polynomial, err error) {
+	domain, err = checkLookup(table, queries, domain)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := int(domain.Cardinality)
+
+	f := compressColumns(queries, alpha)
+	t := compressColumns(table, alpha)
+
+	// count the queries per value of the table
+	counts := make(map[fr.Element]int, n)
+	for i := range t {
+		counts[t[i]] = 0
+	}
+	for i := range f {
+		c, ok := counts[f[i]]
+		if !ok {
+			return nil, nil, ErrNotInTable
+		}
+		counts[f[i]] = c + 1
+	}
+
+	s := make([]fr.Element, 0, 2*n)
+	for i := range t {
+		s = append(s, t[i])
+		for range counts[t[i]] {
+			s = append(s, t[i])
+		}
+		// the queries are placed next to the first occurrence only
+		counts[t[i]] = 0
+	}
+
+	c1 := make([]fr.Element, n)
+	c2 := make([]fr.Element, n)
+	for i := range n {
+		c1[i] = s[2*i]
+		c2[i] = s[2*i+1]
+	}
+
+	h1 = NewPolynomial(&c1, expectedForm)
+	h2 = NewPolynomial(&c2, expectedForm)
+	putInExpectedFormFromLagrangeRegular(h1, domain, expectedForm)
+	putInExpectedFormFromLagrangeRegular(h2, domain, expectedForm)
+	return h1, h2, nil
+}
+
+// BuildPlookupAccumulator builds the accumulator polynomial of the Plookup
+// argument. With f, t the compressed queries and table, and h₁, h₂ the sorted
+// vectors returned by BuildPlookupSortedVectors, it returns the polynomial Z
+// whose evaluation on the j-th root of unity is
+// Z(ωʲ) = Π_{i<j} (1+β)⋅(γ+f(ωⁱ))⋅(γ(1+β)+t(ωⁱ)+β⋅t(ωⁱ⁺¹)) /
+// ((γ(1+β)+h₁(ωⁱ)+β⋅h₂(ωⁱ))⋅(γ(1+β)+h₂(ωⁱ)+β⋅h₁(ωⁱ⁺¹)))
+// * table, queries the columns of the table and of the queries, of the same size
+// * h1, h2 the sorted vectors
+// * alpha, beta, gamma challenges
+// * expectedForm expected form of the resulting polynomial
+// The polynomials are put in Lagrange form in the process.
+//
+// If the lookup holds, Z(1) = 1 and for all x in the domain,
+// Z(ωx)⋅(γ(1+β)+h₁(x)+β⋅h₂(x))⋅(γ(1+β)+h₂(x)+β⋅h₁(ωx)) =
+// Z(x)⋅(1+β)⋅(γ+f(x))⋅(γ(1+β)+t(x)+β⋅t(ωx)).
+func BuildPlookupAccumulator(table, queries []*Polynomial, h1, h2 *Polynomial, alpha, beta, gamma fr.Element, expectedForm Form, domain *fft.Domain) (*Polynomial, error) {
+	domain, err := checkLookup(table, queries, domain)
+	if err != nil {
+		return nil, err
+	}
+	n := int(domain.Cardinality)
+	if h1.coefficients.Len() != n || h2.coefficients.Len() != n {
+		return nil, ErrInconsistentSize
+	}
+	h1.ToLagrange(domain)
+	h2.ToLagrange(domain)
+
+	f := compressColumns(queries, alpha)
+	t := compressColumns(table, alpha)
+
+	// γ(1+β) and 1+β
+	var onePlusBeta, gammaOnePlusBeta fr.Element
+	onePlusBeta.SetOne().Add(&onePlusBeta, &beta)
+	gammaOnePlusBeta.Mul(&gamma, &onePlusBeta)
+
+	coeffs := make([]fr.Element, n)
+	den := make([]fr.Element, n)
+	coeffs[0].SetOne()
+	den[0].SetOne()
+	nn := uint64(64 - bits.TrailingZeros(uint(n)))
+	parallel.Execute(n-1, func(start, end int) {
+		var a, b, h1i, h2i, h1Next fr.Element
+		for i := start; i < end; i++ {
+			h1i = h1.Coefficients()[layoutIndex(h1, i, nn)]
+			h2i = h2.Coefficients()[layoutIndex(h2, i, nn)]
+			h1Next = h1.Coefficients()[layoutIndex(h1, i+1, nn)]
+
+			// (1+β)⋅(γ+f(ωⁱ))⋅(γ(1+β)+t(ωⁱ)+β⋅t(ωⁱ⁺¹))
+			a.Add(&gamma, &f[i]).Mul(&a, &onePlusBeta)
+			b.Mul(&beta, &t[i+1]).Add(&b, &t[i]).Add(&b, &gammaOnePlusBeta)
+			coeffs[i+1].Mul(&a, &b)
+
+			// (γ(1+β)+h₁(ωⁱ)+β⋅h₂(ωⁱ))⋅(γ(1+β)+h₂(ωⁱ)+β⋅h₁(ωⁱ⁺¹))
+			a.Mul(&beta, &h2i).Add(&a, &h1i).Add(&a, &gammaOnePlusBeta)
+			b.Mul(&beta, &h1Next).Add(&b, &h2i).Add(&b, &gammaOnePlusBeta)
+			den[i+1].Mul(&a, &b)
+		}
+	})
+
+	for i := 2; i < n; i++ {
+		coeffs[i].Mul(&coeffs[i], &coeffs[i-1])
+		den[i].Mul(&den[i], &den[i-1])
+	}
+	den = fr.BatchInvert(den)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			coeffs[i].Mul(&coeffs[i], &den[i])
+		}
+	})
+
+	res := NewPolynomial(&coeffs, expectedForm)
+	putInExpectedFormFromLagrangeRegular(res, domain, expectedForm)
+	return res, nil
+}
+
+// checkLookup checks that the table and the queries have the same number of
+// columns and the same size, puts them in Lagrange form and returns the domain.
+func checkLookup(table, queries []*Polynomial, domain *fft.Domain) (*fft.Domain, error) {
+	if len(table) == 0 || len(table) != len(queries) {
+		return nil, ErrNumberColumns
+	}
+	n := table[0].coefficients.Len()
+	for _, p := range slices.Concat(table, queries) {
+		if p.coefficients.Len() != n {
+			return nil, ErrInconsistentSize
+		}
+	}
+	domain, err := buildDomain(n, domain)
+	if err != nil {
+		return nil, err
+	}
+	for i := range table {
+		table[i].ToLagrange(domain)
+		queries[i].ToLagrange(domain)
+	}
+	return domain, nil
+}
+
+// compressColumns returns the evaluations ∑ₖ αᵏ⋅columnsₖ(ωⁱ) in regular layout.
+// The columns are assumed to be in Lagrange form.
+func compressColumns(columns []*Polynomial, alpha fr.Element) []fr.Element {
+	n := columns[0].coefficients.Len()
+	res := make([]fr.Element, n)
+	nn := uint64(64 - bits.TrailingZeros(uint(n)))
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			for k := len(columns) - 1; k >= 0; k-- {
+				res[i].Mul(&res[i], &alpha).
+					Add(&res[i], &columns[k].Coefficients()[layoutIndex(columns[k], i, nn)])
+			}
+		}
+	})
+	return res
+}
+
+// lookupRowKey returns a key identifying the i-th row of the columns, which are
+// assumed to be in Lagrange form.
+func lookupRowKey(columns []*Polynomial, i int) string {
+	n := columns[0].coefficients.Len()
+	nn := uint64(64 - bits.TrailingZeros(uint(n)))
+	key := make([]byte, 0, len(columns)*fr.Bytes)
+	for _, c := range columns {
+		b := c.Coefficients()[layoutIndex(c, i, nn)].Bytes()
+		key = append(key, b[:]...)
+	}
+	return string(key)
+}
+
+// layoutIndex returns the index of the i-th evaluation of p (mod its size), nn
+// being 64-log₂(size).
+func layoutIndex(p *Polynomial, i int, nn uint64) int {
+	i %= p.coefficients.Len()
+	if p.Layout == BitReverse {
+		return int(bits.Reverse64(uint64(i)) >> nn)
+	}
+	return i
+}
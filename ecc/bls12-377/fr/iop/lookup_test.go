@@ -0,0 +1,214 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package iop
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// getLookupInstance returns a table of nbColumns random columns of size n with a
+// repeated row, and queries whose rows are rows of the table.
+func getLookupInstance(n, nbColumns int) (table, queries []*Polynomial) {
+	form := Form{Basis: Lagrange, Layout: Regular}
+	table = make([]*Polynomial, nbColumns)
+	queries = make([]*Polynomial, nbColumns)
+	for k := range nbColumns {
+		table[k] = NewPolynomial(randomVector(n), form)
+		table[k].Coefficients()[n-1] = table[k].Coefficients()[1]
+		q := make([]fr.Element, n)
+		queries[k] = NewPolynomial(&q, form)
+	}
+	for i := range n {
+		j := (i * i) % 5
+		for k := range nbColumns {
+			queries[k].Coefficients()[i] = table[k].Coefficients()[j]
+		}
+	}
+	return table, queries
+}
+
+func TestLogUp(t *testing.T) {
+
+	const n = 16
+	table, queries := getLookupInstance(n, 3)
+	domain := fft.NewDomain(n)
+	form := Form{Basis: Lagrange, Layout: Regular}
+
+	m, err := BuildLookupMultiplicities(table, queries, form, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum, nbRows fr.Element
+	for i := range n {
+		sum.Add(&sum, &m.Coefficients()[i])
+	}
+	nbRows.SetUint64(n)
+	if !sum.Equal(&nbRows) {
+		t.Fatal("the multiplicities should sum to the number of queries")
+	}
+	if !m.Coefficients()[n-1].IsZero() {
+		t.Fatal("the multiplicity of a repeated row should be carried by its first occurrence")
+	}
+
+	var alpha, beta fr.Element
+	alpha.MustSetRandom()
+	beta.MustSetRandom()
+	z, err := BuildLogUpAccumulator(table, queries, m, alpha, beta, form, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (Z(ωx)-Z(x))⋅(β-f(x))⋅(β-t(x)) = (β-t(x)) - m(x)⋅(β-f(x)) on the domain
+	f := compressColumns(queries, alpha)
+	tt := compressColumns(table, alpha)
+	if !z.Coefficients()[0].IsZero() {
+		t.Fatal("Z(1) should be 0")
+	}
+	var l, r, bf, bt fr.Element
+	for i := range n {
+		bf.Sub(&beta, &f[i])
+		bt.Sub(&beta, &tt[i])
+		l.Sub(&z.Coefficients()[(i+1)%n], &z.Coefficients()[i]).Mul(&l, &bf).Mul(&l, &bt)
+		r.Mul(&m.Coefficients()[i], &bf).Sub(&bt, &r)
+		if !l.Equal(&r) {
+			t.Fatal("the LogUp identity does not vanish on the domain")
+		}
+	}
+
+	// inputs in other forms
+	checkLookupForms(t, table, queries, []*Polynomial{m}, domain, func(table, queries, extra []*Polynomial) (*Polynomial, error) {
+		return BuildLogUpAccumulator(table, queries, extra[0], alpha, beta, form, domain)
+	}, z)
+
+	// the rows of the queries must be in the table
+	queries[1].Coefficients()[3].SetOne()
+	if _, err := BuildLookupMultiplicities(table, queries, form, domain); err != ErrNotInTable {
+		t.Fatal("expected ErrNotInTable")
+	}
+	if _, err := BuildLookupMultiplicities(table, queries[1:], form, domain); err != ErrNumberColumns {
+		t.Fatal("expected ErrNumberColumns")
+	}
+}
+
+func TestPlookup(t *testing.T) {
+
+	const n = 16
+	table, queries := getLookupInstance(n, 2)
+	domain := fft.NewDomain(n)
+	form := Form{Basis: Lagrange, Layout: Regular}
+
+	var alpha, beta, gamma fr.Element
+	alpha.MustSetRandom()
+	beta.MustSetRandom()
+	gamma.MustSetRandom()
+	h1, h2, err := BuildPlookupSortedVectors(table, queries, alpha, form, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := BuildPlookupAccumulator(table, queries, h1, h2, alpha, beta, gamma, form, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Z(ωx)⋅(γ(1+β)+h₁(x)+β⋅h₂(x))⋅(γ(1+β)+h₂(x)+β⋅h₁(ωx)) =
+	// Z(x)⋅(1+β)⋅(γ+f(x))⋅(γ(1+β)+t(x)+β⋅t(ωx)) on the domain
+	f := compressColumns(queries, alpha)
+	tt := compressColumns(table, alpha)
+	var one, onePlusBeta, gammaOnePlusBeta fr.Element
+	one.SetOne()
+	onePlusBeta.Add(&one, &beta)
+	gammaOnePlusBeta.Mul(&gamma, &onePlusBeta)
+	if !z.Coefficients()[0].Equal(&one) {
+		t.Fatal("Z(1) should be 1")
+	}
+	var l, r, a fr.Element
+	for i := range n {
+		next := (i + 1) % n
+		a.Mul(&beta, &h2.Coefficients()[i]).Add(&a, &h1.Coefficients()[i]).Add(&a, &gammaOnePlusBeta)
+		l.Mul(&z.Coefficients()[next], &a)
+		a.Mul(&beta, &h1.Coefficients()[next]).Add(&a, &h2.Coefficients()[i]).Add(&a, &gammaOnePlusBeta)
+		l.Mul(&l, &a)
+
+		a.Add(&gamma, &f[i]).Mul(&a, &onePlusBeta)
+		r.Mul(&z.Coefficients()[i], &a)
+		a.Mul(&beta, &tt[next]).Add(&a, &tt[i]).Add(&a, &gammaOnePlusBeta)
+		r.Mul(&r, &a)
+		if !l.Equal(&r) {
+			t.Fatal("the Plookup identity does not vanish on the domain")
+		}
+	}
+
+	// inputs in other forms
+	checkLookupForms(t, table, queries, []*Polynomial{h1, h2}, domain, func(table, queries, extra []*Polynomial) (*Polynomial, error) {
+		return BuildPlookupAccumulator(table, queries, extra[0], extra[1], alpha, beta, gamma, form, domain)
+	}, z)
+
+	// the rows of the queries must be in the table
+	queries[0].Coefficients()[5].SetOne()
+	if _, _, err := BuildPlookupSortedVectors(table, queries, alpha, form, domain); err != ErrNotInTable {
+		t.Fatal("expected ErrNotInTable")
+	}
+}
+
+// checkLookupForms checks that build returns expected when its inputs, given in
+// Lagrange form and regular layout, are in bit reversed layout or canonical form.
+func checkLookupForms(t *testing.T, table, queries, extra []*Polynomial, domain *fft.Domain, build func(table, queries, extra []*Polynomial) (*Polynomial, error), expected *Polynomial) {
+	t.Helper()
+	for _, toForm := range []func(p *Polynomial){
+		func(p *Polynomial) {
+			utils.BitReverse(p.Coefficients())
+			p.Layout = BitReverse
+		},
+		func(p *Polynomial) {
+			domain.FFTInverse(p.Coefficients(), fft.DIF)
+			utils.BitReverse(p.Coefficients())
+			p.Basis = Canonical
+		},
+		func(p *Polynomial) {
+			domain.FFTInverse(p.Coefficients(), fft.DIF)
+			p.Basis = Canonical
+			p.Layout = BitReverse
+		},
+	} {
+		clone := func(ps []*Polynomial) []*Polynomial {
+			res := make([]*Polynomial, len(ps))
+			for i := range ps {
+				res[i] = ps[i].Clone()
+				toForm(res[i])
+			}
+			return res
+		}
+		res, err := build(clone(table), clone(queries), clone(extra))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cmpCoefficents(res.coefficients, expected.coefficients) {
+			t.Fatal("coefficients of the accumulator are not consistent")
+		}
+	}
+}
+
+func BenchmarkLogUp(b *testing.B) {
+	const n = 1 << 14
+	table, queries := getLookupInstance(n, 2)
+	domain := fft.NewDomain(n)
+	form := Form{Basis: Lagrange, Layout: Regular}
+	var alpha, beta fr.Element
+	alpha.MustSetRandom()
+	beta.MustSetRandom()
+	m, err := BuildLookupMultiplicities(table, queries, form, domain)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		BuildLogUpAccumulator(table, queries, m, alpha, beta, form, domain)
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package lookup provides an API to build lookup proofs, that the entries
+// of a vector are entries of a table, and multiset equality proofs, that two
+// lists of tuples are equal up to a permutation.
+package lookup
@@ -0,0 +1,272 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package lookup
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+)
+
+var (
+	ErrIncompatibleSize = errors.New("t1 and t2 should be of the same size")
+	ErrSize             = errors.New("t1 and t2 should be of size a power of 2")
+	ErrNotInTable       = errors.New("an entry of t1 is not in t2")
+	ErrProofShape       = errors.New("the number of claimed values in the proof is incorrect")
+	ErrLookupProof      = errors.New("lookup proof verification failed")
+)
+
+// LookupProof proof that the entries of the vector committed in T1 are entries
+// of the vector committed in T2.
+//
+// It is a LogUp argument: with m the multiplicities of the entries of t2 in t1,
+// ∑ᵢ 1/(β-t1ᵢ) = ∑ᵢ mᵢ/(β-t2ᵢ). The terms of the sums are committed in H1 and
+// H2, and Z accumulates their difference on the domain.
+type LookupProof struct {
+
+	// size of the vectors
+	Size uint64
+
+	// commitments of t1, t2 and of the multiplicities
+	T1, T2, M kzg.Digest
+
+	// commitments of 1/(β-t1), m/(β-t2) and of the accumulation polynomial
+	H1, H2, Z kzg.Digest
+
+	// commitment to the quotient polynomial
+	Q kzg.Digest
+
+	// opening proofs of t1, t2, m, h1, h2, z, q (in that order)
+	BatchedProof kzg.BatchOpeningProof
+
+	// shifted opening proof of z
+	ShiftedProof kzg.OpeningProof
+}
+
+// ProveLookup generates a proof that the entries of t1 are entries of t2.
+// The size of t1 and t2 should be the same and a power of 2; t1 can be padded
+// with any entry of t2.
+func ProveLookup(pk kzg.ProvingKey, t1, t2 []fr.Element) (LookupProof, error) {
+
+	var proof LookupProof
+	var err error
+
+	// size checking
+	if len(t1) != len(t2) {
+		return proof, ErrIncompatibleSize
+	}
+	d, err := newDomain(len(t1))
+	if err != nil {
+		return proof, err
+	}
+	s := len(t1)
+	proof.Size = uint64(s)
+
+	// multiplicities of the entries of t2 in t1, carried by their first occurrence
+	index := make(map[fr.Element]int, s)
+	for i := range t2 {
+		if _, ok := index[t2[i]]; !ok {
+			index[t2[i]] = i
+		}
+	}
+	m := make([]fr.Element, s)
+	var one fr.Element
+	one.SetOne()
+	for i := range t1 {
+		j, ok := index[t1[i]]
+		if !ok {
+			return proof, ErrNotInTable
+		}
+		m[j].Add(&m[j], &one)
+	}
+
+	// transcript to derive the challenges
+	fs, err := newTranscript(proof.Size, "beta", "alpha", "eta")
+	if err != nil {
+		return proof, err
+	}
+
+	// commit t1, t2, m
+	ct1 := toCanonical(t1, d)
+	ct2 := toCanonical(t2, d)
+	cm := toCanonical(m, d)
+	if proof.T1, err = kzg.Commit(ct1, pk); err != nil {
+		return proof, err
+	}
+	if proof.T2, err = kzg.Commit(ct2, pk); err != nil {
+		return proof, err
+	}
+	if proof.M, err = kzg.Commit(cm, pk); err != nil {
+		return proof, err
+	}
+
+	// derive the challenge of the sums
+	beta, err := deriveRandomness(fs, "beta", &proof.T1, &proof.T2, &proof.M)
+	if err != nil {
+		return proof, err
+	}
+
+	// h1 = 1/(β-t1), h2 = m/(β-t2), z(ωⁱ⁺¹) = z(ωⁱ) + h1(ωⁱ) - h2(ωⁱ), z(1) = 0
+	h := make([]fr.Element, 2*s)
+	for i := range s {
+		h[i].Sub(&beta, &t1[i])
+		h[s+i].Sub(&beta, &t2[i])
+	}
+	h = fr.BatchInvert(h)
+	h1, h2 := h[:s], h[s:]
+	z := make([]fr.Element, s)
+	for i := range s {
+		h2[i].Mul(&h2[i], &m[i])
+		if i+1 < s {
+			z[i+1].Add(&z[i], &h1[i]).Sub(&z[i+1], &h2[i])
+		}
+	}
+	ch1 := toCanonical(h1, d)
+	ch2 := toCanonical(h2, d)
+	cz := toCanonical(z, d)
+	if proof.H1, err = kzg.Commit(ch1, pk); err != nil {
+		return proof, err
+	}
+	if proof.H2, err = kzg.Commit(ch2, pk); err != nil {
+		return proof, err
+	}
+	if proof.Z, err = kzg.Commit(cz, pk); err != nil {
+		return proof, err
+	}
+
+	// derive the challenge used for the folding
+	alpha, err := deriveRandomness(fs, "alpha", &proof.H1, &proof.H2, &proof.Z)
+	if err != nil {
+		return proof, err
+	}
+
+	// numerator on the coset:
+	// h1(β-t1)-1 + α(h2(β-t2)-m + α(z(ωX)-z-h1+h2 + α⋅n⋅L₀⋅z))
+	lt1 := evaluateOnCoset(ct1, d)
+	lt2 := evaluateOnCoset(ct2, d)
+	lm := evaluateOnCoset(cm, d)
+	lh1 := evaluateOnCoset(ch1, d)
+	lh2 := evaluateOnCoset(ch2, d)
+	lz := evaluateOnCoset(cz, d)
+	num := lagrangeOneOnCoset(d)
+	var c1, c2, c3 fr.Element
+	for i := range s {
+		num[i].Mul(&num[i], &lz[i]).Mul(&num[i], &alpha)
+
+		c3.Sub(&lz[(i+1)%s], &lz[i]).Sub(&c3, &lh1[i]).Add(&c3, &lh2[i])
+		num[i].Add(&num[i], &c3).Mul(&num[i], &alpha)
+
+		c2.Sub(&beta, &lt2[i]).Mul(&c2, &lh2[i]).Sub(&c2, &lm[i])
+		num[i].Add(&num[i], &c2).Mul(&num[i], &alpha)
+
+		c1.Sub(&beta, &lt1[i]).Mul(&c1, &lh1[i]).Sub(&c1, &one)
+		num[i].Add(&num[i], &c1)
+	}
+
+	// get the quotient and commit it
+	cq := divideByVanishing(num, d)
+	if proof.Q, err = kzg.Commit(cq, pk); err != nil {
+		return proof, err
+	}
+
+	// derive the evaluation challenge
+	eta, err := deriveRandomness(fs, "eta", &proof.Q)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the opening proofs
+	hFunc := sha256.New()
+	proof.BatchedProof, err = kzg.BatchOpenSinglePoint(
+		[][]fr.Element{ct1, ct2, cm, ch1, ch2, cz, cq},
+		[]kzg.Digest{proof.T1, proof.T2, proof.M, proof.H1, proof.H2, proof.Z, proof.Q},
+		eta,
+		hFunc,
+		pk,
+	)
+	if err != nil {
+		return proof, err
+	}
+
+	var shiftedEta fr.Element
+	shiftedEta.Mul(&eta, &d.Generator)
+	proof.ShiftedProof, err = kzg.Open(cz, shiftedEta, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	return proof, nil
+}
+
+// VerifyLookup verifies a lookup proof.
+func VerifyLookup(vk kzg.VerifyingKey, proof LookupProof) error {
+
+	if len(proof.BatchedProof.ClaimedValues) != 7 {
+		return ErrProofShape
+	}
+	if proof.Size < 2 || proof.Size&(proof.Size-1) != 0 {
+		return ErrSize
+	}
+	generator, err := fr.Generator(proof.Size)
+	if err != nil {
+		return err
+	}
+
+	// derive the challenges
+	fs, err := newTranscript(proof.Size, "beta", "alpha", "eta")
+	if err != nil {
+		return err
+	}
+	beta, err := deriveRandomness(fs, "beta", &proof.T1, &proof.T2, &proof.M)
+	if err != nil {
+		return err
+	}
+	alpha, err := deriveRandomness(fs, "alpha", &proof.H1, &proof.H2, &proof.Z)
+	if err != nil {
+		return err
+	}
+	eta, err := deriveRandomness(fs, "eta", &proof.Q)
+	if err != nil {
+		return err
+	}
+
+	// check the relation
+	claimed := proof.BatchedProof.ClaimedValues
+	t1, t2, m, h1, h2, z, q := claimed[0], claimed[1], claimed[2], claimed[3], claimed[4], claimed[5], claimed[6]
+	zh, l0 := evaluateVanishing(eta, proof.Size)
+	var lhs, rhs, c, one fr.Element
+	one.SetOne()
+	lhs.Mul(&l0, &z).Mul(&lhs, &alpha)
+	c.Sub(&proof.ShiftedProof.ClaimedValue, &z).Sub(&c, &h1).Add(&c, &h2)
+	lhs.Add(&lhs, &c).Mul(&lhs, &alpha)
+	c.Sub(&beta, &t2).Mul(&c, &h2).Sub(&c, &m)
+	lhs.Add(&lhs, &c).Mul(&lhs, &alpha)
+	c.Sub(&beta, &t1).Mul(&c, &h1).Sub(&c, &one)
+	lhs.Add(&lhs, &c)
+	rhs.Mul(&zh, &q)
+	if !lhs.Equal(&rhs) {
+		return ErrLookupProof
+	}
+
+	// check the opening proofs
+	hFunc := sha256.New()
+	err = kzg.BatchVerifySinglePoint(
+		[]kzg.Digest{proof.T1, proof.T2, proof.M, proof.H1, proof.H2, proof.Z, proof.Q},
+		&proof.BatchedProof,
+		eta,
+		hFunc,
+		vk,
+	)
+	if err != nil {
+		return err
+	}
+
+	var shiftedEta fr.Element
+	shiftedEta.Mul(&eta, &generator)
+	return kzg.Verify(&proof.Z, &proof.ShiftedProof, shiftedEta, vk)
+}
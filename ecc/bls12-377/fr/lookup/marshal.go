@@ -0,0 +1,126 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package lookup
+
+import (
+	"io"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+// WriteTo writes binary encoding of the lookup proof.
+func (proof *LookupProof) WriteTo(w io.Writer) (int64, error) {
+	enc := bls12377.NewEncoder(w)
+	toEncode := []any{
+		proof.Size,
+		&proof.T1,
+		&proof.T2,
+		&proof.M,
+		&proof.H1,
+		&proof.H2,
+		&proof.Z,
+		&proof.Q,
+	}
+	if err := encode(enc, toEncode); err != nil {
+		return enc.BytesWritten(), err
+	}
+	return writeOpeningProofs(w, enc.BytesWritten(), &proof.BatchedProof, &proof.ShiftedProof)
+}
+
+// ReadFrom reads binary representation of the lookup proof from the reader.
+func (proof *LookupProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12377.NewDecoder(r)
+	toDecode := []any{
+		&proof.Size,
+		&proof.T1,
+		&proof.T2,
+		&proof.M,
+		&proof.H1,
+		&proof.H2,
+		&proof.Z,
+		&proof.Q,
+	}
+	if err := decode(dec, toDecode); err != nil {
+		return dec.BytesRead(), err
+	}
+	return readOpeningProofs(r, dec.BytesRead(), &proof.BatchedProof, &proof.ShiftedProof)
+}
+
+// WriteTo writes binary encoding of the multiset equality proof.
+func (proof *MultisetProof) WriteTo(w io.Writer) (int64, error) {
+	enc := bls12377.NewEncoder(w)
+	toEncode := []any{
+		proof.Size,
+		proof.T1,
+		proof.T2,
+		&proof.Z,
+		&proof.Q,
+	}
+	if err := encode(enc, toEncode); err != nil {
+		return enc.BytesWritten(), err
+	}
+	return writeOpeningProofs(w, enc.BytesWritten(), &proof.BatchedProof, &proof.ShiftedProof)
+}
+
+// ReadFrom reads binary representation of the multiset equality proof from the reader.
+func (proof *MultisetProof) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12377.NewDecoder(r)
+	toDecode := []any{
+		&proof.Size,
+		&proof.T1,
+		&proof.T2,
+		&proof.Z,
+		&proof.Q,
+	}
+	if err := decode(dec, toDecode); err != nil {
+		return dec.BytesRead(), err
+	}
+	return readOpeningProofs(r, dec.BytesRead(), &proof.BatchedProof, &proof.ShiftedProof)
+}
+
+func encode(enc *bls12377.Encoder, toEncode []any) error {
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decode(dec *bls12377.Decoder, toDecode []any) error {
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOpeningProofs writes the batched and the shifted opening proofs after n
+// bytes have been written, and returns the total number of bytes written.
+func writeOpeningProofs(w io.Writer, n int64, batched io.WriterTo, shifted io.WriterTo) (int64, error) {
+	for _, p := range []io.WriterTo{batched, shifted} {
+		m, err := p.WriteTo(w)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// readOpeningProofs reads the batched and the shifted opening proofs after n
+// bytes have been read, and returns the total number of bytes read.
+func readOpeningProofs(r io.Reader, n int64, batched io.ReaderFrom, shifted io.ReaderFrom) (int64, error) {
+	for _, p := range []io.ReaderFrom{batched, shifted} {
+		m, err := p.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,280 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package lookup
+
+import (
+	"crypto/sha256"
+	"errors"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+)
+
+var (
+	ErrNumberColumns = errors.New("t1 and t2 should have the same positive number of columns")
+	ErrMultisetProof = errors.New("multiset equality proof verification failed")
+)
+
+// MultisetProof proof that the rows of the columns committed in T1 are the rows
+// of the columns committed in T2, up to a permutation.
+//
+// The columns are compressed with a random challenge α as t = ∑ⱼ αʲ⋅tⱼ, and Z
+// is the accumulation polynomial of the permutation argument on the compressed
+// vectors.
+type MultisetProof struct {
+
+	// size of the columns
+	Size uint64
+
+	// commitments of the columns of t1 and t2
+	T1, T2 []kzg.Digest
+
+	// commitment of the accumulation polynomial
+	Z kzg.Digest
+
+	// commitment to the quotient polynomial
+	Q kzg.Digest
+
+	// opening proofs of t1, t2, z, q (in that order)
+	BatchedProof kzg.BatchOpeningProof
+
+	// shifted opening proof of z
+	ShiftedProof kzg.OpeningProof
+}
+
+// ProveMultisetEquality generates a proof that the rows of the columns t1 are
+// the rows of the columns t2, up to a permutation. t1 and t2 should have the same
+// number of columns, and all the columns the same size, a power of 2.
+func ProveMultisetEquality(pk kzg.ProvingKey, t1, t2 [][]fr.Element) (MultisetProof, error) {
+
+	var proof MultisetProof
+	var err error
+
+	// size checking
+	if len(t1) == 0 || len(t1) != len(t2) {
+		return proof, ErrNumberColumns
+	}
+	s := len(t1[0])
+	for i := range t1 {
+		if len(t1[i]) != s || len(t2[i]) != s {
+			return proof, ErrIncompatibleSize
+		}
+	}
+	d, err := newDomain(s)
+	if err != nil {
+		return proof, err
+	}
+	proof.Size = uint64(s)
+
+	// transcript to derive the challenges
+	fs, err := newTranscript(proof.Size, "alpha", "beta", "gamma", "eta")
+	if err != nil {
+		return proof, err
+	}
+
+	// commit the columns
+	nbColumns := len(t1)
+	columns := make([][]fr.Element, 0, 2*nbColumns+2)
+	digests := make([]kzg.Digest, 0, 2*nbColumns+2)
+	for _, c := range slices.Concat(t1, t2) {
+		cc := toCanonical(c, d)
+		digest, err := kzg.Commit(cc, pk)
+		if err != nil {
+			return proof, err
+		}
+		columns = append(columns, cc)
+		digests = append(digests, digest)
+	}
+	proof.T1 = digests[:nbColumns:nbColumns]
+	proof.T2 = digests[nbColumns : 2*nbColumns : 2*nbColumns]
+
+	// derive the compression and the permutation challenges
+	alpha, err := deriveRandomness(fs, "alpha", toPointers(digests)...)
+	if err != nil {
+		return proof, err
+	}
+	beta, err := deriveRandomness(fs, "beta")
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the accumulation polynomial of the compressed columns and commit it
+	f := compressColumns(t1, alpha)
+	g := compressColumns(t2, alpha)
+	z := make([]fr.Element, s)
+	den := make([]fr.Element, s)
+	z[0].SetOne()
+	den[0].SetOne()
+	var t fr.Element
+	for i := range s - 1 {
+		z[i+1].Mul(&z[i], t.Sub(&beta, &f[i]))
+		den[i+1].Mul(&den[i], t.Sub(&beta, &g[i]))
+	}
+	den = fr.BatchInvert(den)
+	for i := 1; i < s; i++ {
+		z[i].Mul(&z[i], &den[i])
+	}
+	cz := toCanonical(z, d)
+	if proof.Z, err = kzg.Commit(cz, pk); err != nil {
+		return proof, err
+	}
+
+	// derive the challenge used for the folding
+	gamma, err := deriveRandomness(fs, "gamma", &proof.Z)
+	if err != nil {
+		return proof, err
+	}
+
+	// numerator on the coset: z(ωX)(β-g) - z(β-f) + γ⋅n⋅L₀⋅(z-1)
+	lf := evaluateOnCoset(toCanonical(f, d), d)
+	lg := evaluateOnCoset(toCanonical(g, d), d)
+	lz := evaluateOnCoset(cz, d)
+	num := lagrangeOneOnCoset(d)
+	var one, a, b fr.Element
+	one.SetOne()
+	for i := range s {
+		a.Sub(&lz[i], &one)
+		num[i].Mul(&num[i], &a).Mul(&num[i], &gamma)
+		a.Sub(&beta, &lg[i]).Mul(&a, &lz[(i+1)%s])
+		b.Sub(&beta, &lf[i]).Mul(&b, &lz[i])
+		num[i].Add(&num[i], &a).Sub(&num[i], &b)
+	}
+
+	// get the quotient and commit it
+	cq := divideByVanishing(num, d)
+	if proof.Q, err = kzg.Commit(cq, pk); err != nil {
+		return proof, err
+	}
+
+	// derive the evaluation challenge
+	eta, err := deriveRandomness(fs, "eta", &proof.Q)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the opening proofs
+	hFunc := sha256.New()
+	proof.BatchedProof, err = kzg.BatchOpenSinglePoint(
+		append(columns, cz, cq),
+		append(digests, proof.Z, proof.Q),
+		eta,
+		hFunc,
+		pk,
+	)
+	if err != nil {
+		return proof, err
+	}
+
+	var shiftedEta fr.Element
+	shiftedEta.Mul(&eta, &d.Generator)
+	proof.ShiftedProof, err = kzg.Open(cz, shiftedEta, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	return proof, nil
+}
+
+// VerifyMultisetEquality verifies a multiset equality proof.
+func VerifyMultisetEquality(vk kzg.VerifyingKey, proof MultisetProof) error {
+
+	nbColumns := len(proof.T1)
+	if nbColumns == 0 || len(proof.T2) != nbColumns {
+		return ErrNumberColumns
+	}
+	if len(proof.BatchedProof.ClaimedValues) != 2*nbColumns+2 {
+		return ErrProofShape
+	}
+	if proof.Size < 2 || proof.Size&(proof.Size-1) != 0 {
+		return ErrSize
+	}
+	generator, err := fr.Generator(proof.Size)
+	if err != nil {
+		return err
+	}
+
+	// derive the challenges
+	digests := make([]kzg.Digest, 0, 2*nbColumns+2)
+	digests = append(digests, proof.T1...)
+	digests = append(digests, proof.T2...)
+	fs, err := newTranscript(proof.Size, "alpha", "beta", "gamma", "eta")
+	if err != nil {
+		return err
+	}
+	alpha, err := deriveRandomness(fs, "alpha", toPointers(digests)...)
+	if err != nil {
+		return err
+	}
+	beta, err := deriveRandomness(fs, "beta")
+	if err != nil {
+		return err
+	}
+	gamma, err := deriveRandomness(fs, "gamma", &proof.Z)
+	if err != nil {
+		return err
+	}
+	eta, err := deriveRandomness(fs, "eta", &proof.Q)
+	if err != nil {
+		return err
+	}
+
+	// check the relation
+	claimed := proof.BatchedProof.ClaimedValues
+	var f, g fr.Element
+	for j := nbColumns - 1; j >= 0; j-- {
+		f.Mul(&f, &alpha).Add(&f, &claimed[j])
+		g.Mul(&g, &alpha).Add(&g, &claimed[nbColumns+j])
+	}
+	z, q := claimed[2*nbColumns], claimed[2*nbColumns+1]
+	zh, l0 := evaluateVanishing(eta, proof.Size)
+	var lhs, rhs, a, b, one fr.Element
+	one.SetOne()
+	lhs.Sub(&z, &one).Mul(&lhs, &l0).Mul(&lhs, &gamma)
+	a.Sub(&beta, &g).Mul(&a, &proof.ShiftedProof.ClaimedValue)
+	b.Sub(&beta, &f).Mul(&b, &z)
+	lhs.Add(&lhs, &a).Sub(&lhs, &b)
+	rhs.Mul(&zh, &q)
+	if !lhs.Equal(&rhs) {
+		return ErrMultisetProof
+	}
+
+	// check the opening proofs
+	hFunc := sha256.New()
+	err = kzg.BatchVerifySinglePoint(
+		append(digests, proof.Z, proof.Q),
+		&proof.BatchedProof,
+		eta,
+		hFunc,
+		vk,
+	)
+	if err != nil {
+		return err
+	}
+
+	var shiftedEta fr.Element
+	shiftedEta.Mul(&eta, &generator)
+	return kzg.Verify(&proof.Z, &proof.ShiftedProof, shiftedEta, vk)
+}
+
+// compressColumns returns ∑ⱼ αʲ⋅columnsⱼ.
+func compressColumns(columns [][]fr.Element, alpha fr.Element) []fr.Element {
+	res := make([]fr.Element, len(columns[0]))
+	for j := len(columns) - 1; j >= 0; j-- {
+		for i := range res {
+			res[i].Mul(&res[i], &alpha).Add(&res[i], &columns[j][i])
+		}
+	}
+	return res
+}
+
+func toPointers(digests []kzg.Digest) []*kzg.Digest {
+	res := make([]*kzg.Digest, len(digests))
+	for i := range digests {
+		res[i] = &digests[i]
+	}
+	return res
+}
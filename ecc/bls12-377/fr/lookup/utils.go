@@ -0,0 +1,122 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package lookup
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// newDomain returns the fft domain of size n, which must be a power of 2.
+func newDomain(n int) (*fft.Domain, error) {
+	if n < 2 || n&(n-1) != 0 {
+		return nil, ErrSize
+	}
+	return fft.NewDomain(uint64(n)), nil
+}
+
+// toCanonical returns the coefficients, in regular layout, of the polynomial
+// whose evaluations on the domain are v.
+func toCanonical(v []fr.Element, d *fft.Domain) []fr.Element {
+	res := make([]fr.Element, len(v))
+	copy(res, v)
+	d.FFTInverse(res, fft.DIF)
+	utils.BitReverse(res)
+	return res
+}
+
+// evaluateOnCoset returns the evaluations, in regular layout, of the polynomial
+// p on the coset g⋅H of the domain H.
+func evaluateOnCoset(p []fr.Element, d *fft.Domain) []fr.Element {
+	res := make([]fr.Element, len(p))
+	copy(res, p)
+	d.FFT(res, fft.DIF, fft.OnCoset())
+	utils.BitReverse(res)
+	return res
+}
+
+// lagrangeOneOnCoset returns the evaluations of (Xⁿ-1)/(X-1) = n⋅L₀ on the
+// coset g⋅H of the domain H, in regular layout.
+func lagrangeOneOnCoset(d *fft.Domain) []fr.Element {
+	var gn, one, x fr.Element
+	one.SetOne()
+	gn.Exp(d.FrMultiplicativeGen, big.NewInt(int64(d.Cardinality))).Sub(&gn, &one)
+	res := make([]fr.Element, d.Cardinality)
+	x.Set(&d.FrMultiplicativeGen)
+	for i := range res {
+		res[i].Sub(&x, &one)
+		x.Mul(&x, &d.Generator)
+	}
+	res = fr.BatchInvert(res)
+	for i := range res {
+		res[i].Mul(&res[i], &gn)
+	}
+	return res
+}
+
+// divideByVanishing returns the canonical coefficients of num/(Xⁿ-1), num being
+// given by its evaluations on the coset g⋅H of the domain H, in regular layout.
+// num is modified.
+func divideByVanishing(num []fr.Element, d *fft.Domain) []fr.Element {
+	var t, one fr.Element
+	one.SetOne()
+	t.Exp(d.FrMultiplicativeGen, big.NewInt(int64(d.Cardinality))).Sub(&t, &one).Inverse(&t)
+	for i := range num {
+		num[i].Mul(&num[i], &t)
+	}
+	utils.BitReverse(num)
+	d.FFTInverse(num, fft.DIT, fft.OnCoset())
+	return num
+}
+
+// evaluateVanishing returns ηⁿ-1 and (ηⁿ-1)/(η-1) = n⋅L₀(η).
+func evaluateVanishing(eta fr.Element, n uint64) (zh, l0 fr.Element) {
+	var one, den fr.Element
+	one.SetOne()
+	zh.Exp(eta, new(big.Int).SetUint64(n)).Sub(&zh, &one)
+	den.Sub(&eta, &one)
+	l0.Div(&zh, &den)
+	return zh, l0
+}
+
+// newTranscript returns a Fiat-Shamir transcript whose first challenge is bound
+// to the size of the vectors.
+func newTranscript(size uint64, challenges ...string) (*fiatshamir.Transcript, error) {
+	fs := fiatshamir.NewTranscript(sha256.New(), challenges...)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], size)
+	if err := fs.Bind(challenges[0], buf[:]); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bls12377.G1Affine) (fr.Element, error) {
+
+	var buf [bls12377.SizeOfG1AffineUncompressed]byte
+	var r fr.Element
+
+	for _, p := range points {
+		buf = p.RawBytes()
+		if err := fs.Bind(challenge, buf[:]); err != nil {
+			return r, err
+		}
+	}
+
+	b, err := fs.ComputeChallenge(challenge)
+	if err != nil {
+		return r, err
+	}
+	r.SetBytes(b)
+	return r, nil
+}
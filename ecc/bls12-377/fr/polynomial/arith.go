@@ -0,0 +1,337 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+var (
+	ErrInterpolationSize   = errors.New("the numbers of points and of values differ")
+	ErrInterpolationPoints = errors.New("the interpolation points are not distinct")
+)
+
+const (
+	// below this number of coefficients, polynomials are multiplied naively
+	mulNaiveThreshold = 64
+	// below this number of quotient coefficients, polynomials are divided naively
+	divNaiveThreshold = 64
+	// below this number of points, polynomials are evaluated with Horner's rule
+	evalNaiveThreshold = 32
+)
+
+// Mul sets p to p1*p2 and returns it. Large polynomials are multiplied
+// with FFTs.
+// p can be p1 or p2.
+func (p *Polynomial) Mul(p1, p2 Polynomial) *Polynomial {
+	*p = mul(p1, p2)
+	return p
+}
+
+// DivRem returns the quotient and the remainder of the Euclidean division of a
+// by b, such that a = q⋅b + r with deg(r) < deg(b). The division uses Newton
+// iteration on the reversed polynomials when the quotient is large.
+// len(q) = deg(a)-deg(b)+1 (or 0) and len(r) = deg(b).
+// It panics if b is zero.
+func DivRem(a, b Polynomial) (q, r Polynomial) {
+	a, b = trim(a), trim(b)
+	if len(b) == 0 {
+		panic("polynomial: division by zero")
+	}
+	if len(a) < len(b) {
+		r = make(Polynomial, len(b)-1)
+		copy(r, a)
+		return nil, r
+	}
+
+	m := len(a) - len(b) + 1 // number of coefficients of q
+	if m <= divNaiveThreshold || len(b) <= divNaiveThreshold {
+		return divRemNaive(a, b)
+	}
+
+	// rev(q) = rev(a)⋅rev(b)⁻¹ mod Xᵐ
+	revB := reverse(b[max(0, len(b)-m):])
+	revA := reverse(a[len(a)-m:])
+	q = mul(revA, inverseSeries(revB, m))[:m]
+	q = reverse(q)
+
+	// r = a - q⋅b mod X^{deg(b)}
+	r = make(Polynomial, len(b)-1)
+	copy(r, a)
+	qb := mulLow(q, b, len(r))
+	for i := range r {
+		r[i].Sub(&r[i], &qb[i])
+	}
+	return q, r
+}
+
+// BuildVanishing returns the monic polynomial ∏ᵢ(X-pointsᵢ).
+func BuildVanishing(points []fr.Element) Polynomial {
+	tree := buildSubproductTree(points)
+	return tree[len(tree)-1][0]
+}
+
+// DivideByVanishing returns the quotient and the remainder of the division of
+// p by the vanishing polynomial ∏ᵢ(X-pointsᵢ) of the points.
+func DivideByVanishing(p Polynomial, points []fr.Element) (q, r Polynomial) {
+	return DivRem(p, BuildVanishing(points))
+}
+
+// EvaluateMultiPoint returns the evaluations of p at the points. For many points
+// the evaluations are computed by successive divisions down the subproduct tree
+// of the points, in O(M(n)⋅log(n)) operations where M(n) is the cost of a
+// multiplication.
+func EvaluateMultiPoint(p Polynomial, points []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(points))
+	if len(points) <= evalNaiveThreshold || len(p) <= evalNaiveThreshold {
+		evalNaive(p, points, res)
+		return res
+	}
+	tree := buildSubproductTree(points)
+	_, r := DivRem(p, tree[len(tree)-1][0])
+	evaluateDown(tree, len(tree)-1, 0, r, points, res)
+	return res
+}
+
+// Interpolate returns the polynomial f of degree less than len(points) such that
+// f(pointsᵢ) = valuesᵢ, using the subproduct tree of the points, in
+// O(M(n)⋅log(n)) operations where M(n) is the cost of a multiplication.
+// It returns an error if the numbers of points and values differ, or if the
+// points are not distinct.
+func Interpolate(points, values []fr.Element) (Polynomial, error) {
+	if len(points) != len(values) {
+		return nil, ErrInterpolationSize
+	}
+	if len(points) == 0 {
+		return Polynomial{}, nil
+	}
+	tree := buildSubproductTree(points)
+
+	// f = ∑ᵢ cᵢ⋅∏_{j≠i}(X-xⱼ) where cᵢ = yᵢ/M'(xᵢ) and M = ∏ⱼ(X-xⱼ)
+	root := tree[len(tree)-1][0]
+	derivative := make(Polynomial, len(root)-1)
+	var k fr.Element
+	for i := range derivative {
+		k.SetUint64(uint64(i + 1))
+		derivative[i].Mul(&root[i+1], &k)
+	}
+	c := EvaluateMultiPoint(derivative, points)
+	for i := range c {
+		if c[i].IsZero() {
+			return nil, ErrInterpolationPoints
+		}
+	}
+	c = fr.BatchInvert(c)
+	for i := range c {
+		c[i].Mul(&c[i], &values[i])
+	}
+
+	// linear combination, up the tree
+	level := make([]Polynomial, len(c))
+	for i := range c {
+		level[i] = Polynomial{c[i]}
+	}
+	for l := 1; l < len(tree); l++ {
+		next := make([]Polynomial, len(tree[l]))
+		parallel.Execute(len(next), func(start, end int) {
+			for j := start; j < end; j++ {
+				if 2*j+1 == len(level) {
+					next[j] = level[2*j]
+					continue
+				}
+				left := mul(level[2*j], tree[l-1][2*j+1])
+				right := mul(level[2*j+1], tree[l-1][2*j])
+				next[j].Add(left, right)
+			}
+		})
+		level = next
+	}
+	return trimTo(level[0], len(points)), nil
+}
+
+// buildSubproductTree returns the subproduct tree of the points: tree[0] holds
+// the polynomials X-pointsᵢ, and tree[l][j] = tree[l-1][2j]⋅tree[l-1][2j+1], or
+// tree[l-1][2j] if tree[l-1] has no element 2j+1. tree[l][j] is the vanishing
+// polynomial of the points j⋅2ˡ to min((j+1)⋅2ˡ, len(points)) (excluded).
+func buildSubproductTree(points []fr.Element) [][]Polynomial {
+	leaves := make([]Polynomial, len(points))
+	for i := range points {
+		leaves[i] = make(Polynomial, 2)
+		leaves[i][0].Neg(&points[i])
+		leaves[i][1].SetOne()
+	}
+	if len(points) == 0 {
+		leaves = []Polynomial{make(Polynomial, 1)}
+		leaves[0][0].SetOne()
+	}
+	tree := [][]Polynomial{leaves}
+	for len(tree[len(tree)-1]) > 1 {
+		prev := tree[len(tree)-1]
+		level := make([]Polynomial, (len(prev)+1)/2)
+		parallel.Execute(len(level), func(start, end int) {
+			for j := start; j < end; j++ {
+				if 2*j+1 == len(prev) {
+					level[j] = prev[2*j]
+					continue
+				}
+				level[j] = mul(prev[2*j], prev[2*j+1])
+			}
+		})
+		tree = append(tree, level)
+	}
+	return tree
+}
+
+// evaluateDown sets res to the evaluations at the points below the node (l, j)
+// of the tree of r, the remainder of the polynomial modulo tree[l][j].
+func evaluateDown(tree [][]Polynomial, l, j int, r Polynomial, points, res []fr.Element) {
+	start, end := j<<l, min((j+1)<<l, len(points))
+	if end-start <= evalNaiveThreshold {
+		evalNaive(r, points[start:end], res[start:end])
+		return
+	}
+	if 2*j+1 == len(tree[l-1]) {
+		evaluateDown(tree, l-1, 2*j, r, points, res)
+		return
+	}
+	_, r0 := DivRem(r, tree[l-1][2*j])
+	_, r1 := DivRem(r, tree[l-1][2*j+1])
+	evaluateDown(tree, l-1, 2*j, r0, points, res)
+	evaluateDown(tree, l-1, 2*j+1, r1, points, res)
+}
+
+// evalNaive sets res[i] to p(points[i]) using Horner's rule.
+func evalNaive(p Polynomial, points, res []fr.Element) {
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i].SetZero()
+			for k := len(p) - 1; k >= 0; k-- {
+				res[i].Mul(&res[i], &points[i]).Add(&res[i], &p[k])
+			}
+		}
+	}, max(1, len(points)/evalNaiveThreshold))
+}
+
+// inverseSeries returns g such that f⋅g = 1 mod Xⁿ, using Newton iteration
+// g ← g⋅(2-f⋅g). f[0] must not be zero.
+func inverseSeries(f Polynomial, n int) Polynomial {
+	g := make(Polynomial, 1, n)
+	g[0].Inverse(&f[0])
+	var two fr.Element
+	two.SetUint64(2)
+	for k := 1; k < n; {
+		k = min(2*k, n)
+		e := mulLow(f[:min(len(f), k)], g, k)
+		for i := range e {
+			e[i].Neg(&e[i])
+		}
+		e[0].Add(&e[0], &two)
+		g = mulLow(g, e, k)
+	}
+	return g
+}
+
+// divRemNaive is DivRem using schoolbook long division, a and b being trimmed.
+func divRemNaive(a, b Polynomial) (q, r Polynomial) {
+	r = make(Polynomial, len(a))
+	copy(r, a)
+	q = make(Polynomial, len(a)-len(b)+1)
+	var lInv, t fr.Element
+	lInv.Inverse(&b[len(b)-1])
+	for i := len(q) - 1; i >= 0; i-- {
+		q[i].Mul(&r[i+len(b)-1], &lInv)
+		for j := range len(b) - 1 {
+			t.Mul(&q[i], &b[j])
+			r[i+j].Sub(&r[i+j], &t)
+		}
+	}
+	return q, r[:len(b)-1]
+}
+
+// mulLow returns p1⋅p2 mod Xⁿ, with n coefficients.
+func mulLow(p1, p2 Polynomial, n int) Polynomial {
+	res := mul(p1[:min(len(p1), n)], p2[:min(len(p2), n)])
+	return trimTo(res, n)
+}
+
+// mul returns p1⋅p2 in a newly allocated slice.
+func mul(p1, p2 Polynomial) Polynomial {
+	if len(p1) == 0 || len(p2) == 0 {
+		return Polynomial{}
+	}
+	if min(len(p1), len(p2)) <= mulNaiveThreshold {
+		return mulNaive(p1, p2)
+	}
+	return mulFFT(p1, p2)
+}
+
+// mulNaive returns p1⋅p2 using schoolbook multiplication.
+func mulNaive(p1, p2 Polynomial) Polynomial {
+	if len(p1) < len(p2) {
+		p1, p2 = p2, p1
+	}
+	res := make(Polynomial, len(p1)+len(p2)-1)
+	var t fr.Element
+	for i := range p2 {
+		for j := range p1 {
+			t.Mul(&p1[j], &p2[i])
+			res[i+j].Add(&res[i+j], &t)
+		}
+	}
+	return res
+}
+
+// mulFFT returns p1⋅p2, computed by multiplying their evaluations on a domain
+// large enough.
+func mulFFT(p1, p2 Polynomial) Polynomial {
+	n := len(p1) + len(p2) - 1
+	size := uint64(1) << bits.Len64(uint64(n-1))
+	// the twiddles are not precomputed, not to keep a domain per size in memory
+	d := fft.NewDomain(size, fft.WithoutPrecompute())
+
+	a := make(fr.Vector, size)
+	b := make(fr.Vector, size)
+	copy(a, p1)
+	copy(b, p2)
+	d.FFT(a, fft.DIF)
+	d.FFT(b, fft.DIF)
+	a.Mul(a, b)
+	d.FFTInverse(a, fft.DIT)
+	return Polynomial(a[:n])
+}
+
+// trim returns p without its leading zero coefficients.
+func trim(p Polynomial) Polynomial {
+	n := len(p)
+	for n > 0 && p[n-1].IsZero() {
+		n--
+	}
+	return p[:n]
+}
+
+// trimTo returns the first n coefficients of p, padded with zeros if needed.
+func trimTo(p Polynomial, n int) Polynomial {
+	if len(p) >= n {
+		return p[:n]
+	}
+	res := make(Polynomial, n)
+	copy(res, p)
+	return res
+}
+
+// reverse returns the coefficients of p in reverse order.
+func reverse(p Polynomial) Polynomial {
+	res := make(Polynomial, len(p))
+	for i := range p {
+		res[len(p)-1-i] = p[i]
+	}
+	return res
+}
@@ -0,0 +1,118 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon2
+
+import (
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptDeterminism(t *testing.T) {
+	t.Parallel()
+
+	var x [5]fr.Element
+	for i := range x {
+		x[i].MustSetRandom()
+	}
+	run := func(t1 *Transcript) []fr.Element {
+		t1.AbsorbElements("x", x[:]...)
+		t1.AbsorbBytes("b", []byte("some bytes to absorb, longer than one element"))
+		a := t1.SqueezeElement("a")
+		b := t1.SqueezeElements("b", 3)
+		return append(b, a)
+	}
+
+	res := run(NewTranscript("test"))
+	require.Equal(t, res, run(NewTranscript("test")))
+	require.NotEqual(t, res, run(NewTranscript("other")))
+
+	// the challenges are distinct
+	for i := range res {
+		for j := range i {
+			require.False(t, res[i].Equal(&res[j]))
+		}
+	}
+
+	// clones are independent
+	t1 := NewTranscript("test")
+	t1.AbsorbElements("x", x[0])
+	t2 := t1.Clone()
+	a := t1.SqueezeElement("a")
+	require.Equal(t, a, t2.SqueezeElement("a"))
+	t1.AbsorbElements("x", x[1])
+	require.NotEqual(t, t1.SqueezeElement("a"), t2.SqueezeElement("a"))
+}
+
+func TestTranscriptDomainSeparation(t *testing.T) {
+	t.Parallel()
+
+	var x, y fr.Element
+	x.SetUint64(1)
+	y.SetUint64(2)
+	challenges := make(map[fr.Element]string)
+	for name, f := range map[string]func(*Transcript){
+		"elements":       func(t *Transcript) { t.AbsorbElements("l", x, y) },
+		"other label":    func(t *Transcript) { t.AbsorbElements("m", x, y) },
+		"split":          func(t *Transcript) { t.AbsorbElements("l", x); t.AbsorbElements("l", y) },
+		"bytes":          func(t *Transcript) { t.AbsorbBytes("l", []byte{1, 2}) },
+		"padded bytes":   func(t *Transcript) { t.AbsorbBytes("l", []byte{0, 1, 2}) },
+		"empty":          func(t *Transcript) { t.AbsorbElements("l") },
+		"empty bytes":    func(t *Transcript) { t.AbsorbBytes("l", nil) },
+		"label in bytes": func(t *Transcript) { t.AbsorbBytes("", []byte("l")) },
+		"squeeze":        func(t *Transcript) { t.SqueezeElement("l") },
+		"g1":             func(t *Transcript) { t.AbsorbG1("l", curve.G1Affine{}) },
+	} {
+		t1 := NewTranscript("test")
+		f(t1)
+		c := t1.SqueezeElement("c")
+		other, ok := challenges[c]
+		require.False(t, ok, "%s and %s give the same challenge", name, other)
+		challenges[c] = name
+	}
+}
+
+func TestTranscriptSqueezeIndices(t *testing.T) {
+	t.Parallel()
+
+	t1 := NewTranscript("test")
+	for _, nbBits := range []int{0, 1, 5, 16, fr.Bits - 1} {
+		if nbBits > 64 {
+			continue
+		}
+		indices := t1.SqueezeIndices("q", nbBits, 100)
+		require.Len(t, indices, 100)
+		distinct := make(map[uint64]struct{})
+		for _, i := range indices {
+			if nbBits < 64 {
+				require.Less(t, i, uint64(1)<<nbBits)
+			}
+			distinct[i] = struct{}{}
+		}
+		if nbBits >= 16 {
+			require.Greater(t, len(distinct), 90)
+		}
+	}
+
+	require.Panics(t, func() { t1.SqueezeIndices("q", fr.Bits, 1) })
+	require.Panics(t, func() { t1.SqueezeIndices("q", -1, 1) })
+}
+
+func BenchmarkTranscript(b *testing.B) {
+	var x [16]fr.Element
+	for i := range x {
+		x[i].MustSetRandom()
+	}
+	t1 := NewTranscript("bench")
+
+	b.ResetTimer()
+	for range b.N {
+		t1.AbsorbElements("x", x[:]...)
+		t1.SqueezeElement("c")
+	}
+}
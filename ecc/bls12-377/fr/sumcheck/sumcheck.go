@@ -0,0 +1,218 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	errNoVariables      = errors.New("sumcheck: claims must have at least one variable")
+	errWrongNbRounds    = errors.New("sumcheck: proof has the wrong number of rounds")
+	errWrongRoundDegree = errors.New("sumcheck: round polynomial has the wrong degree")
+)
+
+// Claims is the prover side of a multi-sumcheck statement, i.e. one of the form
+// ∑_{x∈{0,1}ⁿ} fⱼ(x) = cⱼ for 1 ≤ j ≤ m.
+//
+// The claims are first combined into g = ∑_{1≤j≤m} aʲ⁻¹fⱼ for a random a, and
+// the statement ∑_{x∈{0,1}ⁿ} g(x) = ∑_{1≤j≤m} aʲ⁻¹cⱼ is then reduced one variable
+// at a time. In round i, the partial sum polynomial
+//
+//	gᵢ(X) = ∑_{x∈{0,1}ⁿ⁻ⁱ} g(r₁, …, rᵢ₋₁, X, x)
+//
+// is sent as its evaluations gᵢ(1), …, gᵢ(d), where d is the degree of g in its
+// i-th variable. The verifier recovers gᵢ(0) from the current claim.
+type Claims interface {
+	// Combine combines the claims with the coefficient a and returns the
+	// evaluations of the first partial sum polynomial g₁.
+	Combine(a fr.Element) fr.Vector
+	// Next fixes the current variable to r and returns the evaluations of the
+	// next partial sum polynomial.
+	Next(r fr.Element) fr.Vector
+	// NbVars returns the number of variables n.
+	NbVars() int
+	// NbClaims returns the number of claims m.
+	NbClaims() int
+	// ProverFinalEval fixes the last variable to r[n-1] and returns a proof of
+	// the value of g(r₁, …, rₙ), for when the verifier cannot compute it alone.
+	ProverFinalEval(r []fr.Element) fr.Vector
+}
+
+// LazyClaims is the verifier side of a multi-sumcheck statement. It is "lazy"
+// in that it does not have access to the fⱼ.
+type LazyClaims interface {
+	// NbClaims returns the number of claims m.
+	NbClaims() int
+	// NbVars returns the number of variables n.
+	NbVars() int
+	// CombinedSum returns c = ∑_{1≤j≤m} aʲ⁻¹cⱼ.
+	CombinedSum(a fr.Element) fr.Element
+	// Degree returns the degree of g in its i-th variable (starting from 0).
+	Degree(i int) int
+	// VerifyFinalEval checks that g(r₁, …, rₙ) = purportedValue, where g is the
+	// combination of the claims with combinationCoeff, using the prover's proof.
+	VerifyFinalEval(r []fr.Element, combinationCoeff, purportedValue fr.Element, proof fr.Vector) error
+}
+
+// Proof of a multi-sumcheck statement.
+type Proof struct {
+	// PartialSumPolys[i] holds the evaluations gᵢ₊₁(1), …, gᵢ₊₁(d) of the
+	// partial sum polynomial of round i+1.
+	PartialSumPolys []fr.Vector
+	// FinalEvalProof is the proof of the value of g(r₁, …, rₙ), in the form
+	// expected by the LazyClaims.
+	FinalEvalProof fr.Vector
+}
+
+// Prove produces a proof of the claims, using the transcript settings to derive
+// the challenges.
+func Prove(claims Claims, transcriptSettings fiatshamir.Settings) (Proof, error) {
+	var proof Proof
+	nbVars := claims.NbVars()
+	if nbVars == 0 {
+		return proof, errNoVariables
+	}
+	remainingChallengeNames, err := setupTranscript(claims.NbClaims(), nbVars, &transcriptSettings)
+	if err != nil {
+		return proof, err
+	}
+	transcript := transcriptSettings.Transcript
+
+	var combinationCoeff fr.Element
+	if claims.NbClaims() >= 2 {
+		if combinationCoeff, err = next(transcript, nil, &remainingChallengeNames); err != nil {
+			return proof, err
+		}
+	}
+
+	proof.PartialSumPolys = make([]fr.Vector, nbVars)
+	proof.PartialSumPolys[0] = claims.Combine(combinationCoeff)
+	challenges := make([]fr.Element, nbVars)
+	for j := range nbVars {
+		if challenges[j], err = next(transcript, proof.PartialSumPolys[j], &remainingChallengeNames); err != nil {
+			return proof, err
+		}
+		if j+1 < nbVars {
+			proof.PartialSumPolys[j+1] = claims.Next(challenges[j])
+		}
+	}
+
+	proof.FinalEvalProof = claims.ProverFinalEval(challenges)
+
+	return proof, nil
+}
+
+// Verify checks a proof of the claims, using the transcript settings to derive
+// the challenges. The settings must match the ones used by the prover.
+func Verify(claims LazyClaims, proof Proof, transcriptSettings fiatshamir.Settings) error {
+	nbVars := claims.NbVars()
+	if nbVars == 0 {
+		return errNoVariables
+	}
+	if len(proof.PartialSumPolys) != nbVars {
+		return errWrongNbRounds
+	}
+	remainingChallengeNames, err := setupTranscript(claims.NbClaims(), nbVars, &transcriptSettings)
+	if err != nil {
+		return err
+	}
+	transcript := transcriptSettings.Transcript
+
+	var combinationCoeff fr.Element
+	if claims.NbClaims() >= 2 {
+		if combinationCoeff, err = next(transcript, nil, &remainingChallengeNames); err != nil {
+			return err
+		}
+	}
+
+	challenges := make([]fr.Element, nbVars)
+
+	// gJR is the claimed value of g(r₁, …, rⱼ, x), summed over x.
+	// Initially it is the claimed sum.
+	gJR := claims.CombinedSum(combinationCoeff)
+
+	for j := range nbVars {
+		partialSumPoly := proof.PartialSumPolys[j]
+		degree := claims.Degree(j)
+		if len(partialSumPoly) != degree {
+			return fmt.Errorf("%w: round %d: expected %d evaluations, got %d", errWrongRoundDegree, j, degree, len(partialSumPoly))
+		}
+
+		// gJ(0) = gJR - gJ(1)
+		gJ := make([]fr.Element, degree+1)
+		gJ[0].Sub(&gJR, &partialSumPoly[0])
+		copy(gJ[1:], partialSumPoly)
+
+		if challenges[j], err = next(transcript, partialSumPoly, &remainingChallengeNames); err != nil {
+			return err
+		}
+
+		gJR = interpolateOnRange(gJ, &challenges[j])
+	}
+
+	return claims.VerifyFinalEval(challenges, combinationCoeff, gJR, proof.FinalEvalProof)
+}
+
+// setupTranscript registers the challenges of the protocol in the transcript,
+// creating it if needed, and binds the base challenges to the first one.
+func setupTranscript(nbClaims, nbVars int, settings *fiatshamir.Settings) ([]string, error) {
+	nbChallenges := nbVars
+	if nbClaims >= 2 {
+		nbChallenges++
+	}
+	challengeNames := make([]string, nbChallenges)
+	if nbClaims >= 2 {
+		challengeNames[0] = settings.Prefix + "comb"
+	}
+	prefix := settings.Prefix + "pSP."
+	for i := range nbVars {
+		challengeNames[i+nbChallenges-nbVars] = prefix + strconv.Itoa(i)
+	}
+
+	if settings.Transcript == nil {
+		settings.Transcript = fiatshamir.NewTranscript(settings.Hash, challengeNames...)
+	} else {
+		for _, name := range challengeNames {
+			if err := settings.Transcript.NewChallenge(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range settings.BaseChallenges {
+		if err := settings.Transcript.Bind(challengeNames[0], settings.BaseChallenges[i]); err != nil {
+			return nil, err
+		}
+	}
+	return challengeNames, nil
+}
+
+// next binds the values to the next challenge in remainingChallengeNames,
+// computes it and removes it from the list.
+func next(transcript *fiatshamir.Transcript, bindings []fr.Element, remainingChallengeNames *[]string) (fr.Element, error) {
+	var res fr.Element
+	challengeName := (*remainingChallengeNames)[0]
+	for i := range bindings {
+		if err := transcript.Bind(challengeName, toBytes(&bindings[i])); err != nil {
+			return res, err
+		}
+	}
+	bytes, err := transcript.ComputeChallenge(challengeName)
+	if err != nil {
+		return res, err
+	}
+	setBytes(&res, bytes)
+
+	*remainingChallengeNames = (*remainingChallengeNames)[1:]
+
+	return res, nil
+}
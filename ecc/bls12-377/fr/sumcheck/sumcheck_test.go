@@ -0,0 +1,265 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/stretchr/testify/require"
+)
+
+// randomInputs returns nbClaims × nbInputs random tables of size 2ⁿ
+func randomInputs(nbClaims, nbInputs, nbVars int) [][]polynomial.MultiLin {
+	inputs := make([][]polynomial.MultiLin, nbClaims)
+	for j := range inputs {
+		inputs[j] = make([]polynomial.MultiLin, nbInputs)
+		for i := range inputs[j] {
+			inputs[j][i] = make(polynomial.MultiLin, 1<<nbVars)
+			fr.Vector(inputs[j][i]).MustSetRandom()
+		}
+	}
+	return inputs
+}
+
+// sums computes ∑_{x∈{0,1}ⁿ} g(Pⱼ,₁(x), …, Pⱼ,ₖ(x)) for each claim j
+func sums(gate Gate, inputs [][]polynomial.MultiLin) []fr.Element {
+	res := make([]fr.Element, len(inputs))
+	for j := range inputs {
+		tables := make([]fr.Vector, len(inputs[j]))
+		for i := range tables {
+			tables[i] = lift(inputs[j][i])
+		}
+		values := make([]fr.Element, len(tables))
+		for x := range tables[0] {
+			for i := range tables {
+				values[i] = tables[i][x]
+			}
+			v := gate.Evaluate(values...)
+			res[j].Add(&res[j], &v)
+		}
+	}
+	return res
+}
+
+// evaluationChecker returns a function checking the final evaluations claimed
+// by the prover against the actual inputs.
+func evaluationChecker(inputs [][]polynomial.MultiLin) func(r, evaluations []fr.Element) error {
+	return func(r, evaluations []fr.Element) error {
+		for j := range inputs {
+			for i := range inputs[j] {
+				table := lift(inputs[j][i])
+				for k := range r {
+					table = fold(table, &r[k])
+				}
+				if !table[0].Equal(&evaluations[j*len(inputs[j])+i]) {
+					return errors.New("wrong evaluation")
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func TestSumcheck(t *testing.T) {
+	t.Parallel()
+	for _, nbClaims := range []int{1, 3} {
+		for _, nbInputs := range []int{1, 2, 3} {
+			for _, nbVars := range []int{1, 2, 5} {
+				t.Run(fmt.Sprintf("claims=%d/inputs=%d/vars=%d", nbClaims, nbInputs, nbVars), func(t *testing.T) {
+					gate := ProductGate{NbInputs: nbInputs}
+					inputs := randomInputs(nbClaims, nbInputs, nbVars)
+
+					claims, err := NewClaims(gate, inputs)
+					require.NoError(t, err)
+					proof, err := Prove(claims, fiatshamir.WithHash(sha256.New(), []byte("base")))
+					require.NoError(t, err)
+
+					lazyClaims := NewLazyClaims(gate, sums(gate, inputs), nbVars, evaluationChecker(inputs))
+					require.NoError(t, Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New(), []byte("base"))))
+
+					// different base challenge
+					require.Error(t, Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New(), []byte("other"))))
+				})
+			}
+		}
+	}
+}
+
+func TestSumcheckWrongClaims(t *testing.T) {
+	t.Parallel()
+	const nbClaims, nbInputs, nbVars = 2, 2, 4
+	gate := ProductGate{NbInputs: nbInputs}
+	inputs := randomInputs(nbClaims, nbInputs, nbVars)
+
+	claims, err := NewClaims(gate, inputs)
+	require.NoError(t, err)
+	proof, err := Prove(claims, fiatshamir.WithHash(sha256.New()))
+	require.NoError(t, err)
+
+	claimedSums := sums(gate, inputs)
+	lazyClaims := NewLazyClaims(gate, claimedSums, nbVars, evaluationChecker(inputs))
+	require.NoError(t, Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())))
+
+	t.Run("wrong sum", func(t *testing.T) {
+		wrongSums := make([]fr.Element, nbClaims)
+		copy(wrongSums, claimedSums)
+		wrongSums[1].SetOne()
+		lazyClaims := NewLazyClaims(gate, wrongSums, nbVars, evaluationChecker(inputs))
+		require.Error(t, Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())))
+	})
+
+	t.Run("tampered round polynomial", func(t *testing.T) {
+		tampered := proof
+		tampered.PartialSumPolys = make([]fr.Vector, nbVars)
+		copy(tampered.PartialSumPolys, proof.PartialSumPolys)
+		tampered.PartialSumPolys[1] = make(fr.Vector, nbInputs)
+		copy(tampered.PartialSumPolys[1], proof.PartialSumPolys[1])
+		tampered.PartialSumPolys[1][0].SetOne()
+		require.Error(t, Verify(lazyClaims, tampered, fiatshamir.WithHash(sha256.New())))
+	})
+
+	t.Run("wrong evaluations", func(t *testing.T) {
+		// consistent with the last round, but not with the inputs
+		otherInputs := randomInputs(nbClaims, nbInputs, nbVars)
+		lazyClaims := NewLazyClaims(gate, claimedSums, nbVars, evaluationChecker(otherInputs))
+		require.Error(t, Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())))
+	})
+
+	t.Run("wrong degree", func(t *testing.T) {
+		lazyClaims := NewLazyClaims(ProductGate{NbInputs: nbInputs + 1}, claimedSums, nbVars, nil)
+		require.ErrorIs(t, Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())), errWrongRoundDegree)
+	})
+
+	t.Run("wrong number of rounds", func(t *testing.T) {
+		lazyClaims := NewLazyClaims(gate, claimedSums, nbVars+1, nil)
+		require.ErrorIs(t, Verify(lazyClaims, proof, fiatshamir.WithHash(sha256.New())), errWrongNbRounds)
+	})
+}
+
+func TestSumcheckSharedTranscript(t *testing.T) {
+	t.Parallel()
+	const nbVars = 3
+	gate := ProductGate{NbInputs: 2}
+	inputs := randomInputs(2, 2, nbVars)
+
+	// the outer protocol already has a challenge in the transcript
+	newTranscript := func() *fiatshamir.Transcript {
+		transcript := fiatshamir.NewTranscript(sha256.New(), "alpha")
+		require.NoError(t, transcript.Bind("alpha", []byte("commitment")))
+		_, err := transcript.ComputeChallenge("alpha")
+		require.NoError(t, err)
+		return transcript
+	}
+
+	claims, err := NewClaims(gate, inputs)
+	require.NoError(t, err)
+	proof, err := Prove(claims, fiatshamir.WithTranscript(newTranscript(), "sumcheck."))
+	require.NoError(t, err)
+
+	lazyClaims := NewLazyClaims(gate, sums(gate, inputs), nbVars, evaluationChecker(inputs))
+	require.NoError(t, Verify(lazyClaims, proof, fiatshamir.WithTranscript(newTranscript(), "sumcheck.")))
+	require.Error(t, Verify(lazyClaims, proof, fiatshamir.WithTranscript(newTranscript(), "other.")))
+}
+
+func TestNewClaimsErrors(t *testing.T) {
+	t.Parallel()
+	gate := ProductGate{NbInputs: 2}
+
+	_, err := NewClaims(gate, nil)
+	require.ErrorIs(t, err, errNoClaims)
+
+	_, err = NewClaims(gate, [][]polynomial.MultiLin{{}})
+	require.ErrorIs(t, err, errNoInputs)
+
+	_, err = NewClaims(gate, randomInputs(1, 2, 0))
+	require.ErrorIs(t, err, errNotPowerOfTwo)
+
+	inputs := randomInputs(2, 2, 3)
+	inputs[1] = inputs[1][:1]
+	_, err = NewClaims(gate, inputs)
+	require.ErrorIs(t, err, errInconsistentInputs)
+
+	inputs = randomInputs(2, 2, 3)
+	inputs[1][1] = inputs[1][1][:4]
+	_, err = NewClaims(gate, inputs)
+	require.ErrorIs(t, err, errInconsistentInputs)
+}
+
+func TestProofSerialization(t *testing.T) {
+	t.Parallel()
+	const nbVars = 4
+	gate := ProductGate{NbInputs: 3}
+	inputs := randomInputs(2, 3, nbVars)
+
+	claims, err := NewClaims(gate, inputs)
+	require.NoError(t, err)
+	proof, err := Prove(claims, fiatshamir.WithHash(sha256.New()))
+	require.NoError(t, err)
+
+	data, err := proof.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded Proof
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, len(proof.PartialSumPolys), len(decoded.PartialSumPolys))
+	for i := range proof.PartialSumPolys {
+		require.True(t, proof.PartialSumPolys[i].Equal(decoded.PartialSumPolys[i]))
+	}
+	require.True(t, proof.FinalEvalProof.Equal(decoded.FinalEvalProof))
+
+	lazyClaims := NewLazyClaims(gate, sums(gate, inputs), nbVars, evaluationChecker(inputs))
+	require.NoError(t, Verify(lazyClaims, decoded, fiatshamir.WithHash(sha256.New())))
+}
+
+func TestInterpolateOnRange(t *testing.T) {
+	t.Parallel()
+	for degree := range 6 {
+		// p(X) = ∑ coefficients[i] Xⁱ
+		coefficients := make([]fr.Element, degree+1)
+		for i := range coefficients {
+			coefficients[i].MustSetRandom()
+		}
+		eval := func(x *fr.Element) fr.Element {
+			var res fr.Element
+			for i := len(coefficients) - 1; i >= 0; i-- {
+				res.Mul(&res, x)
+				res.Add(&res, &coefficients[i])
+			}
+			return res
+		}
+
+		values := make([]fr.Element, degree+1)
+		for i := range values {
+			var x fr.Element
+			setInt64(&x, int64(i))
+			values[i] = eval(&x)
+		}
+
+		var r fr.Element
+		r.MustSetRandom()
+		expected := eval(&r)
+		actual := interpolateOnRange(values, &r)
+		require.True(t, expected.Equal(&actual), "degree %d", degree)
+	}
+}
+
+func BenchmarkProve(b *testing.B) {
+	const nbVars = 16
+	gate := ProductGate{NbInputs: 3}
+	inputs := randomInputs(1, 3, nbVars)
+
+	b.ResetTimer()
+	for range b.N {
+		claims, _ := NewClaims(gate, inputs)
+		_, _ = Prove(claims, fiatshamir.WithHash(sha256.New()))
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+)
+
+// interpolateOnRange returns p(r), where p is the polynomial of degree
+// len(values)-1 such that p(i) = values[i] for 0 ≤ i < len(values).
+func interpolateOnRange(values []fr.Element, r *fr.Element) fr.Element {
+	d := len(values) - 1
+
+	// prefix[i] = ∏_{j<i} (r-j) and suffix[i] = ∏_{j>i} (r-j)
+	prefix := make([]fr.Element, d+1)
+	suffix := make([]fr.Element, d+1)
+	var tmp fr.Element
+	prefix[0].SetOne()
+	suffix[d].SetOne()
+	for i := 1; i <= d; i++ {
+		setInt64(&tmp, int64(i-1))
+		tmp.Sub(r, &tmp)
+		prefix[i].Mul(&prefix[i-1], &tmp)
+
+		setInt64(&tmp, int64(d-i+1))
+		tmp.Sub(r, &tmp)
+		suffix[d-i].Mul(&suffix[d-i+1], &tmp)
+	}
+
+	// p(r) = ∑ᵢ values[i] ∏_{j≠i} (r-j)/(i-j)
+	// where ∏_{j≠i} (i-j) = (-1)ᵈ⁻ⁱ i! (d-i)!
+	factorials := make([]fr.Element, d+1)
+	factorials[0].SetOne()
+	for i := 1; i <= d; i++ {
+		factorials[i].SetInt64(int64(i))
+		factorials[i].Mul(&factorials[i], &factorials[i-1])
+	}
+
+	var res, term fr.Element
+	var denominator fr.Element
+	for i := range values {
+		denominator.Mul(&factorials[i], &factorials[d-i])
+		if (d-i)%2 == 1 {
+			denominator.Neg(&denominator)
+		}
+		denominator.Inverse(&denominator)
+
+		term.Mul(&prefix[i], &suffix[i])
+		term.Mul(&term, &values[i])
+		mulByElement(&term, &term, &denominator)
+		res.Add(&res, &term)
+	}
+	return res
+}
+
+// fold sets X₁ = r in the multilinear polynomial given by its evaluations on the
+// hypercube, i.e. k[X₁, X₂, ..., Xₙ] → k[X₂, ..., Xₙ], and returns the folded table.
+func fold(table fr.Vector, r *fr.Element) fr.Vector {
+	m := polynomial.MultiLin(table)
+	m.Fold(*r)
+	return fr.Vector(m)
+}
+
+// lift returns a copy of the evaluations of a multilinear polynomial.
+func lift(table polynomial.MultiLin) fr.Vector {
+	return fr.Vector(table.Clone())
+}
+
+// mulByElement sets z = x⋅y
+func mulByElement(z, x, y *fr.Element) {
+	z.Mul(x, y)
+}
+
+// setInt64 sets z to v
+func setInt64(z *fr.Element, v int64) {
+	z.SetInt64(v)
+}
+
+// toBytes returns the big-endian encoding of x
+func toBytes(x *fr.Element) []byte {
+	b := x.Bytes()
+	return b[:]
+}
+
+// setBytes sets z to b, interpreted as a big-endian integer reduced modulo q.
+func setBytes(z *fr.Element, b []byte) {
+	z.SetBytes(b)
+}
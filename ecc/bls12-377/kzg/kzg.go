@@ -8,15 +8,16 @@ package kzg
 import (
 	"errors"
 	"hash"
+	"io"
 	"math/big"
 	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
-	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 
 	"github.com/consensys/gnark-crypto/parallel"
+	"github.com/consensys/gnark-crypto/utils/unsafe"
 )
 
 var (
@@ -176,6 +177,39 @@ func Commit(p []fr.Element, pk ProvingKey, nbTasks ...int) (Digest, error) {
 	return res, nil
 }
 
+// CommitDump commits to a polynomial in canonical form, as Commit does, with
+// the ProvingKey of an SRS written by SRS.WriteDump, read from r. The points
+// of the ProvingKey are streamed by chunks of chunkSize points, so that the
+// SRS doesn't need to fit in memory (see bls12377.G1Jac.MultiExpReader).
+func CommitDump(r io.Reader, p []fr.Element, chunkSize int, nbTasks ...int) (Digest, error) {
+	// skip the VerifyingKey
+	var vk VerifyingKey
+	if _, err := vk.ReadFrom(r); err != nil {
+		return Digest{}, err
+	}
+	if err := unsafe.ReadMarker(r); err != nil {
+		return Digest{}, err
+	}
+	nbPoints, err := unsafe.ReadSliceLength(r)
+	if err != nil {
+		return Digest{}, err
+	}
+	if uint64(len(p)) > nbPoints {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	var res bls12377.G1Affine
+	if _, err := res.MultiExpReader(r, p, chunkSize, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
 // Open computes an opening proof of polynomial p at given point.
 // fft.Domain Cardinality must be larger than p.Degree()
 func Open(p []fr.Element, point fr.Element, pk ProvingKey) (OpeningProof, error) {
@@ -204,6 +238,113 @@ func Open(p []fr.Element, point fr.Element, pk ProvingKey) (OpeningProof, error)
 	return res, nil
 }
 
+// ProvingKeyLagrange used to create or open commitments of polynomials in
+// Lagrange form, that is given by their evaluations on the domain of the
+// n-th roots of unity, in natural order.
+type ProvingKeyLagrange struct {
+	G1 []bls12377.G1Affine // [[L₀(α)]G₁, [L₁(α)]G₁, ..., [Lₙ₋₁(α)]G₁]
+}
+
+// NewProvingKeyLagrange returns the ProvingKeyLagrange on the domain of size
+// n, computed from the first n points of pk with ToLagrangeG1. The size must
+// be a power of 2.
+func NewProvingKeyLagrange(pk ProvingKey, size uint64) (ProvingKeyLagrange, error) {
+	if size > uint64(len(pk.G1)) {
+		return ProvingKeyLagrange{}, ErrInvalidPolynomialSize
+	}
+	lagrange, err := ToLagrangeG1(pk.G1[:size])
+	if err != nil {
+		return ProvingKeyLagrange{}, err
+	}
+	return ProvingKeyLagrange{G1: lagrange}, nil
+}
+
+// CommitLagrange commits to a polynomial given by its evaluations on the
+// domain of pk, in natural order. The commitment is the same as the one
+// computed by Commit from the canonical form.
+func CommitLagrange(evals []fr.Element, pk ProvingKeyLagrange, nbTasks ...int) (Digest, error) {
+	if len(evals) == 0 || len(evals) != len(pk.G1) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+	return Commit(evals, ProvingKey(pk), nbTasks...)
+}
+
+// OpenLagrange computes an opening proof at point of the polynomial given by
+// its evaluations on the domain of pk, in natural order, without going back
+// to the canonical form. The point can be in the domain or not. The proof is
+// checked with Verify against the result of CommitLagrange.
+func OpenLagrange(evals []fr.Element, point fr.Element, pk ProvingKeyLagrange) (OpeningProof, error) {
+	n := len(evals)
+	if n == 0 || n != len(pk.G1) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+	generator, err := fr.Generator(uint64(n))
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	// ωⁱ and 1/(z-ωⁱ), zero if z = ωⁱ
+	omega := make([]fr.Element, n)
+	omega[0].SetOne()
+	for i := 1; i < n; i++ {
+		omega[i].Mul(&omega[i-1], &generator)
+	}
+	m := -1
+	inv := make([]fr.Element, n)
+	for i := range n {
+		inv[i].Sub(&point, &omega[i])
+		if inv[i].IsZero() {
+			m = i
+		}
+	}
+	inv = fr.BatchInvert(inv)
+
+	var res OpeningProof
+	q := make([]fr.Element, n)
+	var t fr.Element
+	if m < 0 {
+		// f(z) = (zⁿ-1)/n ∑ᵢ fᵢωⁱ/(z-ωⁱ)
+		for i := range n {
+			t.Mul(&evals[i], &omega[i]).Mul(&t, &inv[i])
+			res.ClaimedValue.Add(&res.ClaimedValue, &t)
+		}
+		var zn, one, nInv fr.Element
+		one.SetOne()
+		zn.Exp(point, big.NewInt(int64(n))).Sub(&zn, &one)
+		nInv.SetUint64(uint64(n)).Inverse(&nInv)
+		res.ClaimedValue.Mul(&res.ClaimedValue, &zn).Mul(&res.ClaimedValue, &nInv)
+
+		// qᵢ = (fᵢ-f(z))/(ωⁱ-z)
+		for i := range n {
+			q[i].Sub(&res.ClaimedValue, &evals[i]).Mul(&q[i], &inv[i])
+		}
+	} else {
+		// z = ωᵐ, f(z) = fₘ and qᵢ = (fᵢ-fₘ)/(ωⁱ-z) for i ≠ m, while by
+		// l'Hôpital's rule qₘ = ∑_{i≠m} (fᵢ-fₘ)ωⁱ/(z(z-ωⁱ)).
+		res.ClaimedValue.Set(&evals[m])
+		for i := range n {
+			if i == m {
+				continue
+			}
+			q[i].Sub(&evals[i], &res.ClaimedValue).Mul(&q[i], &inv[i])
+			t.Mul(&q[i], &omega[i])
+			q[m].Add(&q[m], &t)
+			q[i].Neg(&q[i])
+		}
+		t.Inverse(&point)
+		q[m].Mul(&q[m], &t)
+	}
+
+	// commit to H
+	hCommit, err := CommitLagrange(q, pk)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	res.H.Set(&hCommit)
+
+	return res, nil
+}
+
 // Verify verifies a KZG opening proof at a single point
 func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, vk VerifyingKey) error {
 
@@ -253,6 +394,17 @@ func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, vk Verify
 // * polynomials is the list of polynomials to open, they are supposed to be of the same size.
 // * dataTranscript extra data that might be needed to derive the challenge used for folding
 func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, pk ProvingKey, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	t := newHashTranscript(hf, []string{"gamma"}, dataTranscript...)
+	res, err := BatchOpenSinglePointWithTranscript(polynomials, digests, point, t, pk)
+	if t.err != nil {
+		return BatchOpeningProof{}, t.err
+	}
+	return res, err
+}
+
+// BatchOpenSinglePointWithTranscript is BatchOpenSinglePoint, where the challenge
+// used for folding is squeezed from the transcript t.
+func BatchOpenSinglePointWithTranscript(polynomials [][]fr.Element, digests []Digest, point fr.Element, t Transcript, pk ProvingKey) (BatchOpeningProof, error) {
 
 	// check for invalid sizes
 	nbDigests := len(digests)
@@ -288,10 +440,7 @@ func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr
 	wg.Wait()
 
 	// derive the challenge γ, binded to the point and the commitments
-	gamma, err := deriveGamma(point, digests, res.ClaimedValues, hf, dataTranscript...)
-	if err != nil {
-		return BatchOpeningProof{}, err
-	}
+	gamma := deriveGamma(point, digests, res.ClaimedValues, t)
 
 	// ∑ᵢγⁱf(a)
 	var foldedEvaluations fr.Element
@@ -332,6 +481,7 @@ func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr
 	h := dividePolyByXminusA(foldedPolynomials, foldedEvaluations, point)
 	foldedPolynomials = nil // same memory as h
 
+	var err error
 	res.H, err = Commit(h, pk)
 	if err != nil {
 		return BatchOpeningProof{}, err
@@ -348,6 +498,17 @@ func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr
 // * transcript extra data needed to derive the challenge used for folding.
 // * returns the folded version of batchOpeningProof, Digest, the folded version of digests
 func FoldProof(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, hf hash.Hash, dataTranscript ...[]byte) (OpeningProof, Digest, error) {
+	t := newHashTranscript(hf, []string{"gamma"}, dataTranscript...)
+	res, foldedDigests, err := FoldProofWithTranscript(digests, batchOpeningProof, point, t)
+	if t.err != nil {
+		return OpeningProof{}, Digest{}, t.err
+	}
+	return res, foldedDigests, err
+}
+
+// FoldProofWithTranscript is FoldProof, where the challenge used for folding is
+// squeezed from the transcript t.
+func FoldProofWithTranscript(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, t Transcript) (OpeningProof, Digest, error) {
 
 	nbDigests := len(digests)
 
@@ -357,10 +518,7 @@ func FoldProof(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.
 	}
 
 	// derive the challenge γ, binded to the point and the commitments
-	gamma, err := deriveGamma(point, digests, batchOpeningProof.ClaimedValues, hf, dataTranscript...)
-	if err != nil {
-		return OpeningProof{}, Digest{}, ErrInvalidNbDigests
-	}
+	gamma := deriveGamma(point, digests, batchOpeningProof.ClaimedValues, t)
 
 	// fold the claimed values and digests
 	// gammai = [1,γ,γ²,..,γⁿ⁻¹]
@@ -392,6 +550,17 @@ func FoldProof(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.
 // * batchOpeningProof proof of correct opening on the digests
 // * dataTranscript extra data that might be needed to derive the challenge used for the folding
 func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) error {
+	t := newHashTranscript(hf, []string{"gamma"}, dataTranscript...)
+	err := BatchVerifySinglePointWithTranscript(digests, batchOpeningProof, point, t, vk)
+	if t.err != nil {
+		return t.err
+	}
+	return err
+}
+
+// BatchVerifySinglePointWithTranscript is BatchVerifySinglePoint, where the
+// challenge used for folding is squeezed from the transcript t.
+func BatchVerifySinglePointWithTranscript(digests []Digest, batchOpeningProof *BatchOpeningProof, point fr.Element, t Transcript, vk VerifyingKey) error {
 
 	for i := range len(digests) {
 		if !digests[i].IsInSubGroup() {
@@ -403,7 +572,7 @@ func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningPro
 	}
 
 	// fold the proof
-	foldedProof, foldedDigest, err := FoldProof(digests, batchOpeningProof, point, hf, dataTranscript...)
+	foldedProof, foldedDigest, err := FoldProofWithTranscript(digests, batchOpeningProof, point, t)
 	if err != nil {
 		return err
 	}
@@ -556,38 +725,12 @@ func fold(di []Digest, fai []fr.Element, ci []fr.Element) (Digest, fr.Element, e
 }
 
 // deriveGamma derives a challenge using Fiat Shamir to fold proofs.
-func deriveGamma(point fr.Element, digests []Digest, claimedValues []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
-
+func deriveGamma(point fr.Element, digests []Digest, claimedValues []fr.Element, t Transcript) fr.Element {
 	// derive the challenge gamma, binded to the point and the commitments
-	fs := fiatshamir.NewTranscript(hf, "gamma")
-	if err := fs.Bind("gamma", point.Marshal()); err != nil {
-		return fr.Element{}, err
-	}
-	for i := range digests {
-		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
-			return fr.Element{}, err
-		}
-	}
-	for i := range claimedValues {
-		if err := fs.Bind("gamma", claimedValues[i].Marshal()); err != nil {
-			return fr.Element{}, err
-		}
-	}
-
-	for i := range len(dataTranscript) {
-		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
-			return fr.Element{}, err
-		}
-	}
-
-	gammaByte, err := fs.ComputeChallenge("gamma")
-	if err != nil {
-		return fr.Element{}, err
-	}
-	var gamma fr.Element
-	gamma.SetBytes(gammaByte)
-
-	return gamma, nil
+	t.AbsorbElements("gamma", point)
+	t.AbsorbG1("gamma", digests...)
+	t.AbsorbElements("gamma", claimedValues...)
+	return t.SqueezeElement("gamma")
 }
 
 // dividePolyByXminusA computes (f-f(a))/(x-a), in canonical basis, in regular form
@@ -20,6 +20,7 @@ import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/poseidon2"
 	"github.com/consensys/gnark-crypto/utils"
 
 	"github.com/consensys/gnark-crypto/utils/testutils"
@@ -119,6 +120,43 @@ func TestToLagrangeG1(t *testing.T) {
 	}
 }
 
+func TestFFTG1(t *testing.T) {
+	assert := require.New(t)
+
+	const size = 16
+
+	// the transform of [pᵢ]G₁ is [FFT(p)ᵢ]G₁
+	p := make([]fr.Element, size)
+	a := make([]curve.G1Jac, size)
+	_, _, g1Gen, _ := curve.Generators()
+	var s big.Int
+	for i := range p {
+		p[i].MustSetRandom()
+		a[i].FromAffine(&g1Gen)
+		a[i].ScalarMultiplication(&a[i], p[i].BigInt(&s))
+	}
+	expected := slices.Clone(a)
+
+	assert.NoError(FFTG1(a, false))
+	d := fft.NewDomain(uint64(size))
+	d.FFT(p, fft.DIF)
+	utils.BitReverse(p)
+	for i := range p {
+		var e curve.G1Jac
+		e.FromAffine(&g1Gen)
+		e.ScalarMultiplication(&e, p[i].BigInt(&s))
+		assert.True(e.Equal(&a[i]), "error fft %d", i)
+	}
+
+	// the inverse transform gives back the input
+	assert.NoError(FFTG1(a, true))
+	for i := range a {
+		assert.True(expected[i].Equal(&a[i]), "error inverse fft %d", i)
+	}
+
+	assert.Error(FFTG1(a[:3], false))
+}
+
 func TestCommitLagrange(t *testing.T) {
 	// sample a sparse polynomial (here in Lagrange form)
 	size := 64
@@ -156,6 +194,94 @@ func TestCommitLagrange(t *testing.T) {
 	t.Run("mpcsetup", test(mpcGetSrs(t)))
 }
 
+func TestProvingKeyLagrange(t *testing.T) {
+	const size = 64
+	evals := make([]fr.Element, size)
+	for i := range evals {
+		evals[i].MustSetRandom()
+	}
+	coeffs := slices.Clone(evals)
+	d := fft.NewDomain(size)
+	d.FFTInverse(coeffs, fft.DIF)
+	utils.BitReverse(coeffs)
+
+	test := func(srs *SRS) func(*testing.T) {
+		return func(t *testing.T) {
+			assert := require.New(t)
+
+			// the key is the Lagrange form of the first points of the SRS
+			pkLagrange, err := NewProvingKeyLagrange(srs.Pk, size)
+			assert.NoError(err)
+			lagrange, err := ToLagrangeG1(srs.Pk.G1[:size])
+			assert.NoError(err)
+			assert.Equal(lagrange, pkLagrange.G1)
+
+			// CommitLagrange matches the commitment of the canonical form
+			digestLagrange, err := CommitLagrange(evals, pkLagrange)
+			assert.NoError(err)
+			digestCanonical, err := Commit(coeffs, srs.Pk)
+			assert.NoError(err)
+			assert.True(digestCanonical.Equal(&digestLagrange), "error CommitLagrange")
+
+			_, err = NewProvingKeyLagrange(srs.Pk, uint64(len(srs.Pk.G1)+1))
+			assert.ErrorIs(err, ErrInvalidPolynomialSize)
+			_, err = CommitLagrange(nil, pkLagrange)
+			assert.ErrorIs(err, ErrInvalidPolynomialSize)
+		}
+	}
+	t.Run("unsafe", test(testSrs))
+	t.Run("mpcsetup", test(mpcGetSrs(t)))
+}
+
+func TestOpenLagrange(t *testing.T) {
+	const size = 64
+	evals := make([]fr.Element, size)
+	for i := range evals {
+		evals[i].MustSetRandom()
+	}
+	d := fft.NewDomain(size)
+	coeffs := slices.Clone(evals)
+	d.FFTInverse(coeffs, fft.DIF)
+	utils.BitReverse(coeffs)
+
+	test := func(srs *SRS) func(*testing.T) {
+		return func(t *testing.T) {
+			assert := require.New(t)
+
+			pkLagrange, err := NewProvingKeyLagrange(srs.Pk, size)
+			assert.NoError(err)
+			digest, err := CommitLagrange(evals, pkLagrange)
+			assert.NoError(err)
+
+			var outside fr.Element
+			outside.MustSetRandom()
+			var inside, last fr.Element
+			inside.Exp(d.Generator, big.NewInt(5))
+			last.Exp(d.Generator, big.NewInt(size-1))
+			for _, point := range []fr.Element{outside, fr.One(), inside, last} {
+				proof, err := OpenLagrange(evals, point, pkLagrange)
+				assert.NoError(err)
+
+				// same proof as from the canonical form
+				expected, err := Open(coeffs, point, srs.Pk)
+				assert.NoError(err)
+				assert.True(proof.ClaimedValue.Equal(&expected.ClaimedValue), "wrong claimed value")
+				assert.True(proof.H.Equal(&expected.H), "wrong quotient")
+
+				assert.NoError(Verify(&digest, &proof, point, srs.Vk))
+			}
+
+			// wrong sizes
+			_, err = OpenLagrange(evals[:size-1], outside, pkLagrange)
+			assert.ErrorIs(err, ErrInvalidPolynomialSize)
+			_, err = CommitLagrange(evals[:size-1], pkLagrange)
+			assert.ErrorIs(err, ErrInvalidPolynomialSize)
+		}
+	}
+	t.Run("unsafe", test(testSrs))
+	t.Run("mpcsetup", test(mpcGetSrs(t)))
+}
+
 func TestDividePolyByXminusA(t *testing.T) {
 
 	const pSize = 230
@@ -427,6 +553,36 @@ func TestBatchVerifySinglePoint(t *testing.T) {
 	t.Run("mpcsetup", test(mpcGetSrs(t)))
 }
 
+func TestBatchVerifySinglePointWithTranscript(t *testing.T) {
+	t.Parallel()
+
+	size := 40
+	f := make([][]fr.Element, 10)
+	digests := make([]Digest, len(f))
+	for i := range f {
+		f[i] = randomPolynomial(size)
+		var err error
+		digests[i], err = Commit(f[i], testSrs.Pk)
+		require.NoError(t, err)
+	}
+	var point fr.Element
+	point.MustSetRandom()
+
+	proof, err := BatchOpenSinglePointWithTranscript(f, digests, point, poseidon2.NewTranscript("test"), testSrs.Pk)
+	require.NoError(t, err)
+	for i := range f {
+		expectedClaim := eval(f[i], point)
+		require.True(t, expectedClaim.Equal(&proof.ClaimedValues[i]))
+	}
+	require.NoError(t, BatchVerifySinglePointWithTranscript(digests, &proof, point, poseidon2.NewTranscript("test"), testSrs.Vk))
+
+	// the challenge depends on the state of the transcript
+	require.Error(t, BatchVerifySinglePointWithTranscript(digests, &proof, point, poseidon2.NewTranscript("other"), testSrs.Vk))
+
+	proof.ClaimedValues[0].Double(&proof.ClaimedValues[0])
+	require.Error(t, BatchVerifySinglePointWithTranscript(digests, &proof, point, poseidon2.NewTranscript("test"), testSrs.Vk))
+}
+
 func TestBatchVerifyMultiPoints(t *testing.T) {
 
 	// create polynomials
@@ -539,6 +695,27 @@ func TestUnsafeToBytesTruncating(t *testing.T) {
 	assert.Equal(srs.Pk.G1[:1<<8], newSRSPartial.Pk.G1)
 }
 
+func TestCommitDump(t *testing.T) {
+	assert := require.New(t)
+
+	var buf bytes.Buffer
+	assert.NoError(testSrs.WriteDump(&buf))
+
+	f := randomPolynomial(srsSize)
+	expected, err := Commit(f, testSrs.Pk)
+	assert.NoError(err)
+
+	for _, chunkSize := range []int{32, 100, 1 << 10} {
+		digest, err := CommitDump(bytes.NewReader(buf.Bytes()), f, chunkSize)
+		assert.NoError(err)
+		assert.True(expected.Equal(&digest), "wrong commitment with chunk size %d", chunkSize)
+	}
+
+	// polynomial larger than the SRS
+	_, err = CommitDump(bytes.NewReader(buf.Bytes()), randomPolynomial(len(testSrs.Pk.G1)+1), 32)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
 const benchSize = 1 << 16
 
 func BenchmarkSRSGen(b *testing.B) {
@@ -26,15 +26,6 @@ func ToLagrangeG1(coeffs []curve.G1Affine) ([]curve.G1Affine, error) {
 	if bits.OnesCount64(uint64(len(coeffs))) != 1 {
 		return nil, fmt.Errorf("len(coeffs) must be a power of 2")
 	}
-	size := len(coeffs)
-
-	numCPU := uint64(runtime.NumCPU())
-	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU)) << 1
-
-	twiddlesInv, err := computeTwiddlesInv(size)
-	if err != nil {
-		return nil, err
-	}
 
 	// batch convert to Jacobian
 	jCoeffs := make([]curve.G1Jac, len(coeffs))
@@ -42,11 +33,43 @@ func ToLagrangeG1(coeffs []curve.G1Affine) ([]curve.G1Affine, error) {
 		jCoeffs[i].FromAffine(&coeffs[i])
 	}
 
-	difFFTG1(jCoeffs, twiddlesInv, 0, maxSplits, nil)
+	if err := FFTG1(jCoeffs, true); err != nil {
+		return nil, err
+	}
+
+	// batch convert to affine
+	return curve.BatchJacobianToAffineG1(jCoeffs), nil
+}
+
+// FFTG1 computes in place the discrete Fourier transform of a in G₁,
+// aᵢ ← ∑ⱼ ωⁱʲaⱼ where ω = fr.Generator(len(a)), or if inverse is set the
+// inverse transform aᵢ ← 1/n∑ⱼ ω⁻ⁱʲaⱼ. The input and the output are in
+// natural order. Size of a must be a power of 2.
+func FFTG1(a []curve.G1Jac, inverse bool) error {
+	if bits.OnesCount64(uint64(len(a))) != 1 {
+		return fmt.Errorf("len(a) must be a power of 2")
+	}
+	size := len(a)
+	if size == 1 {
+		return nil
+	}
+
+	numCPU := uint64(runtime.NumCPU())
+	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU)) << 1
+
+	twiddles, err := computeTwiddles(size, inverse)
+	if err != nil {
+		return err
+	}
+
+	difFFTG1(a, twiddles, 0, maxSplits, nil)
 
 	// TODO @gbotrel generify the cobra bitreverse function, benchmark it and use it everywhere
-	bitReverse(jCoeffs)
+	bitReverse(a)
 
+	if !inverse {
+		return nil
+	}
 	var invBigint big.Int
 	var frCardinality fr.Element
 	frCardinality.SetUint64(uint64(size))
@@ -55,22 +78,24 @@ func ToLagrangeG1(coeffs []curve.G1Affine) ([]curve.G1Affine, error) {
 
 	parallel.Execute(size, func(start, end int) {
 		for i := start; i < end; i++ {
-			jCoeffs[i].ScalarMultiplication(&jCoeffs[i], &invBigint)
+			a[i].ScalarMultiplication(&a[i], &invBigint)
 		}
 	})
-
-	// batch convert to affine
-	return curve.BatchJacobianToAffineG1(jCoeffs), nil
+	return nil
 }
 
-func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
+// computeTwiddles returns the powers of the generator of order cardinality,
+// or of its inverse
+func computeTwiddles(cardinality int, inverse bool) ([]*big.Int, error) {
 	generator, err := fr.Generator(uint64(cardinality))
 	if err != nil {
 		return nil, err
 	}
 
 	// inverse the generator
-	generator.Inverse(&generator)
+	if inverse {
+		generator.Inverse(&generator)
+	}
 
 	// nb fft stages
 	nbStages := uint64(bits.TrailingZeros64(uint64(cardinality)))
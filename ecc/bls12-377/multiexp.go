@@ -7,12 +7,14 @@ package bls12377
 
 import (
 	"errors"
+	"io"
 	"math"
 	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/parallel"
+	"github.com/consensys/gnark-crypto/utils/unsafe"
 )
 
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
@@ -71,29 +73,24 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
-	// here, we compute the best C for nbPoints
-	// we split recursively until nbChunks(c) >= nbTasks,
-	bestC := func(nbPoints int) uint64 {
-		// implemented msmC methods (the c we use must be in this slice)
-		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
-		var C uint64
-		// approximate cost (in group operations)
-		// cost = bits/c * (nbPoints + 2^{c})
-		// this needs to be verified empirically.
-		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
-		min := math.MaxFloat64
-		for _, c := range implementedCs {
-			cc := (fr.Bits + 1) * (nbPoints + (1 << c))
-			cost := float64(cc) / float64(c)
-			if cost < min {
-				min = cost
-				C = c
-			}
-		}
-		return C
+	// small scalars are decomposed in fewer windows (see MultiExpSmall), and
+	// the zero scalars of sparse vectors are skipped.
+	small, nbBits, nbZeros := scanScalars(scalars, config.NbTasks)
+	if small != nil {
+		return p.multiExpSmall(points, small, nbBits, nbZeros, config), nil
 	}
+	if isSparse(nbPoints, nbZeros) {
+		points, scalars = removeZeros(points, scalars, func(s *fr.Element) bool { return s.IsZero() })
+	}
+	return p.multiExp(points, scalars, config), nil
+}
 
-	C := bestC(nbPoints)
+func (p *G1Jac) multiExp(points []G1Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G1Jac {
+	nbPoints := len(points)
+
+	// here, we compute the best C for nbPoints
+	// we split recursively until nbChunks(c) >= nbTasks,
+	C := bestCG1(nbPoints)
 	nbChunks := int(computeNbChunks(C))
 
 	// should we recursively split the msm in half? (see below)
@@ -121,7 +118,7 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 
 	costPreSplit := costFunction(nbChunks, config.NbTasks, costPerTask(C, nbPoints))
 
-	cPostSplit := bestC(nbPoints / 2)
+	cPostSplit := bestCG1(nbPoints / 2)
 	nbChunksPostSplit := int(computeNbChunks(cPostSplit))
 	costPostSplit := costFunction(nbChunksPostSplit*2, config.NbTasks, costPerTask(cPostSplit, nbPoints/2))
 
@@ -131,22 +128,49 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		var _p G1Jac
 		chDone := make(chan struct{}, 1)
 		go func() {
-			_p.MultiExp(points[:nbPoints/2], scalars[:nbPoints/2], config)
+			_p.multiExp(points[:nbPoints/2], scalars[:nbPoints/2], config)
 			close(chDone)
 		}()
-		p.MultiExp(points[nbPoints/2:], scalars[nbPoints/2:], config)
+		p.multiExp(points[nbPoints/2:], scalars[nbPoints/2:], config)
 		<-chDone
 		p.AddAssign(&_p)
-		return p, nil
+		return p
 	}
 
 	// if we don't split, we use the best C we found
-	_innerMsmG1(p, C, points, scalars, config)
+	return _innerMsmG1(p, C, points, scalars, config)
+}
 
-	return p, nil
+// implementedCsG1 are the implemented msmC methods (the c we use must be in this slice)
+var implementedCsG1 = []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+// bestCG1 returns the window size minimizing the cost of a multiExp of nbPoints
+func bestCG1(nbPoints int) uint64 {
+	var C uint64
+	// approximate cost (in group operations)
+	// cost = bits/c * (nbPoints + 2^{c})
+	// this needs to be verified empirically.
+	// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
+	min := math.MaxFloat64
+	for _, c := range implementedCsG1 {
+		cc := (fr.Bits + 1) * (nbPoints + (1 << c))
+		cost := float64(cc) / float64(c)
+		if cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
 }
 
 func _innerMsmG1(p *G1Jac, c uint64, points []G1Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G1Jac {
+	chChunks := _innerMsmWindowsG1(c, points, scalars, config)
+	return msmReduceChunkG1Affine(p, int(c), chChunks)
+}
+
+// _innerMsmWindowsG1 spawns the processing of the c-bit windows of the scalars,
+// and returns the channels in which the weighted bucket sums of the windows are sent.
+func _innerMsmWindowsG1(c uint64, points []G1Affine, scalars []fr.Element, config ecc.MultiExpConfig) []chan g1JacExtended {
 	// partition the scalars
 	digits, chunkStats := partitionScalars(scalars, c, config.NbTasks)
 
@@ -171,9 +195,6 @@ func _innerMsmG1(p *G1Jac, c uint64, points []G1Affine, scalars []fr.Element, co
 		for range config.NbTasks {
 			sem <- struct{}{}
 		}
-		defer func() {
-			close(sem)
-		}()
 	}
 
 	// the last chunk may be processed with a different method than the rest, as it could be smaller.
@@ -206,7 +227,7 @@ func _innerMsmG1(p *G1Jac, c uint64, points []G1Affine, scalars []fr.Element, co
 		go processChunk(uint64(j), chChunks[j], c, points, digits[j*n:(j+1)*n], sem)
 	}
 
-	return msmReduceChunkG1Affine(p, int(c), chChunks[:])
+	return chChunks
 }
 
 // getChunkProcessorG1 decides, depending on c window size and statistics for the chunk
@@ -313,6 +334,101 @@ func msmReduceChunkG1Affine(p *G1Jac, c int, chChunks []chan g1JacExtended) *G1J
 	return p.unsafeFromJacExtended(&_p)
 }
 
+// MultiExpReader computes the multi-exponentiation ∑ᵢ [scalarsᵢ]pointsᵢ where
+// the len(scalars) points are read from r in their raw memory representation,
+// as written by unsafe.WriteSlice after the length of the slice (see
+// SRS.WriteDump in the kzg package). It is meant for sets of points which
+// don't fit in memory: a memory-mapped file is read through an io.Reader too.
+//
+// The points are read and processed by chunks of chunkSize points, the next
+// chunk being read while the current one is processed, so that at most
+// 2·chunkSize points are held in memory. The weighted bucket sums of each
+// c-bit window are accumulated across the chunks and reduced once at the end.
+// The result is the same as the one of MultiExp.
+func (p *G1Affine) MultiExpReader(r io.Reader, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G1Affine, error) {
+	var _p G1Jac
+	if _, err := _p.MultiExpReader(r, scalars, chunkSize, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpReader computes the multi-exponentiation ∑ᵢ [scalarsᵢ]pointsᵢ where
+// the len(scalars) points are read from r in their raw memory representation,
+// as written by unsafe.WriteSlice after the length of the slice (see
+// SRS.WriteDump in the kzg package). It is meant for sets of points which
+// don't fit in memory: a memory-mapped file is read through an io.Reader too.
+//
+// The points are read and processed by chunks of chunkSize points, the next
+// chunk being read while the current one is processed, so that at most
+// 2·chunkSize points are held in memory. The weighted bucket sums of each
+// c-bit window are accumulated across the chunks and reduced once at the end.
+// The result is the same as the one of MultiExp.
+func (p *G1Jac) MultiExpReader(r io.Reader, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G1Jac, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("invalid chunk size")
+	}
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU() * 2
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+	nbPoints := len(scalars)
+	chunkSize = min(chunkSize, nbPoints)
+
+	// the window size is the one of a multiExp of chunkSize points, as the
+	// buckets are reduced for each chunk.
+	c := bestCG1(chunkSize)
+	windows := make([]g1JacExtended, computeNbChunks(c))
+	for j := range windows {
+		windows[j].SetInfinity()
+	}
+
+	var buffers [2][]G1Affine
+	buffers[0] = make([]G1Affine, chunkSize)
+	if nbPoints > chunkSize {
+		buffers[1] = make([]G1Affine, chunkSize)
+	}
+	if _, err := unsafe.ReadSliceElements(r, buffers[0]); err != nil {
+		return nil, err
+	}
+
+	for start, k := 0, 0; start < nbPoints; start, k = start+chunkSize, k+1 {
+		end := min(start+chunkSize, nbPoints)
+		points := buffers[k%2][:end-start]
+
+		// read the next chunk while processing this one
+		chErr := make(chan error, 1)
+		if end < nbPoints {
+			next := buffers[(k+1)%2][:min(chunkSize, nbPoints-end)]
+			go func() {
+				_, err := unsafe.ReadSliceElements(r, next)
+				chErr <- err
+			}()
+		} else {
+			chErr <- nil
+		}
+
+		chChunks := _innerMsmWindowsG1(c, points, scalars[start:end], config)
+		for j := range windows {
+			total := <-chChunks[j]
+			windows[j].add(&total)
+		}
+		if err := <-chErr; err != nil {
+			return nil, err
+		}
+	}
+
+	chWindows := make([]chan g1JacExtended, len(windows))
+	for j := range windows {
+		chWindows[j] = make(chan g1JacExtended, 1)
+		chWindows[j] <- windows[j]
+	}
+	return msmReduceChunkG1Affine(p, int(c), chWindows), nil
+}
+
 // Fold computes the multi-exponentiation \sum_{i=0}^{len(points)-1} points[i] *
 // combinationCoeff^i and stores the result in p. It returns error in case
 // configuration is invalid.
@@ -394,29 +510,24 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
-	// here, we compute the best C for nbPoints
-	// we split recursively until nbChunks(c) >= nbTasks,
-	bestC := func(nbPoints int) uint64 {
-		// implemented msmC methods (the c we use must be in this slice)
-		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
-		var C uint64
-		// approximate cost (in group operations)
-		// cost = bits/c * (nbPoints + 2^{c})
-		// this needs to be verified empirically.
-		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
-		min := math.MaxFloat64
-		for _, c := range implementedCs {
-			cc := (fr.Bits + 1) * (nbPoints + (1 << c))
-			cost := float64(cc) / float64(c)
-			if cost < min {
-				min = cost
-				C = c
-			}
-		}
-		return C
+	// small scalars are decomposed in fewer windows (see MultiExpSmall), and
+	// the zero scalars of sparse vectors are skipped.
+	small, nbBits, nbZeros := scanScalars(scalars, config.NbTasks)
+	if small != nil {
+		return p.multiExpSmall(points, small, nbBits, nbZeros, config), nil
+	}
+	if isSparse(nbPoints, nbZeros) {
+		points, scalars = removeZeros(points, scalars, func(s *fr.Element) bool { return s.IsZero() })
 	}
+	return p.multiExp(points, scalars, config), nil
+}
+
+func (p *G2Jac) multiExp(points []G2Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G2Jac {
+	nbPoints := len(points)
 
-	C := bestC(nbPoints)
+	// here, we compute the best C for nbPoints
+	// we split recursively until nbChunks(c) >= nbTasks,
+	C := bestCG2(nbPoints)
 	nbChunks := int(computeNbChunks(C))
 
 	// should we recursively split the msm in half? (see below)
@@ -444,7 +555,7 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 
 	costPreSplit := costFunction(nbChunks, config.NbTasks, costPerTask(C, nbPoints))
 
-	cPostSplit := bestC(nbPoints / 2)
+	cPostSplit := bestCG2(nbPoints / 2)
 	nbChunksPostSplit := int(computeNbChunks(cPostSplit))
 	costPostSplit := costFunction(nbChunksPostSplit*2, config.NbTasks, costPerTask(cPostSplit, nbPoints/2))
 
@@ -454,22 +565,49 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		var _p G2Jac
 		chDone := make(chan struct{}, 1)
 		go func() {
-			_p.MultiExp(points[:nbPoints/2], scalars[:nbPoints/2], config)
+			_p.multiExp(points[:nbPoints/2], scalars[:nbPoints/2], config)
 			close(chDone)
 		}()
-		p.MultiExp(points[nbPoints/2:], scalars[nbPoints/2:], config)
+		p.multiExp(points[nbPoints/2:], scalars[nbPoints/2:], config)
 		<-chDone
 		p.AddAssign(&_p)
-		return p, nil
+		return p
 	}
 
 	// if we don't split, we use the best C we found
-	_innerMsmG2(p, C, points, scalars, config)
+	return _innerMsmG2(p, C, points, scalars, config)
+}
 
-	return p, nil
+// implementedCsG2 are the implemented msmC methods (the c we use must be in this slice)
+var implementedCsG2 = []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+// bestCG2 returns the window size minimizing the cost of a multiExp of nbPoints
+func bestCG2(nbPoints int) uint64 {
+	var C uint64
+	// approximate cost (in group operations)
+	// cost = bits/c * (nbPoints + 2^{c})
+	// this needs to be verified empirically.
+	// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
+	min := math.MaxFloat64
+	for _, c := range implementedCsG2 {
+		cc := (fr.Bits + 1) * (nbPoints + (1 << c))
+		cost := float64(cc) / float64(c)
+		if cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
 }
 
 func _innerMsmG2(p *G2Jac, c uint64, points []G2Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G2Jac {
+	chChunks := _innerMsmWindowsG2(c, points, scalars, config)
+	return msmReduceChunkG2Affine(p, int(c), chChunks)
+}
+
+// _innerMsmWindowsG2 spawns the processing of the c-bit windows of the scalars,
+// and returns the channels in which the weighted bucket sums of the windows are sent.
+func _innerMsmWindowsG2(c uint64, points []G2Affine, scalars []fr.Element, config ecc.MultiExpConfig) []chan g2JacExtended {
 	// partition the scalars
 	digits, chunkStats := partitionScalars(scalars, c, config.NbTasks)
 
@@ -494,9 +632,6 @@ func _innerMsmG2(p *G2Jac, c uint64, points []G2Affine, scalars []fr.Element, co
 		for range config.NbTasks {
 			sem <- struct{}{}
 		}
-		defer func() {
-			close(sem)
-		}()
 	}
 
 	// the last chunk may be processed with a different method than the rest, as it could be smaller.
@@ -529,7 +664,7 @@ func _innerMsmG2(p *G2Jac, c uint64, points []G2Affine, scalars []fr.Element, co
 		go processChunk(uint64(j), chChunks[j], c, points, digits[j*n:(j+1)*n], sem)
 	}
 
-	return msmReduceChunkG2Affine(p, int(c), chChunks[:])
+	return chChunks
 }
 
 // getChunkProcessorG2 decides, depending on c window size and statistics for the chunk
@@ -636,6 +771,101 @@ func msmReduceChunkG2Affine(p *G2Jac, c int, chChunks []chan g2JacExtended) *G2J
 	return p.unsafeFromJacExtended(&_p)
 }
 
+// MultiExpReader computes the multi-exponentiation ∑ᵢ [scalarsᵢ]pointsᵢ where
+// the len(scalars) points are read from r in their raw memory representation,
+// as written by unsafe.WriteSlice after the length of the slice (see
+// SRS.WriteDump in the kzg package). It is meant for sets of points which
+// don't fit in memory: a memory-mapped file is read through an io.Reader too.
+//
+// The points are read and processed by chunks of chunkSize points, the next
+// chunk being read while the current one is processed, so that at most
+// 2·chunkSize points are held in memory. The weighted bucket sums of each
+// c-bit window are accumulated across the chunks and reduced once at the end.
+// The result is the same as the one of MultiExp.
+func (p *G2Affine) MultiExpReader(r io.Reader, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G2Affine, error) {
+	var _p G2Jac
+	if _, err := _p.MultiExpReader(r, scalars, chunkSize, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpReader computes the multi-exponentiation ∑ᵢ [scalarsᵢ]pointsᵢ where
+// the len(scalars) points are read from r in their raw memory representation,
+// as written by unsafe.WriteSlice after the length of the slice (see
+// SRS.WriteDump in the kzg package). It is meant for sets of points which
+// don't fit in memory: a memory-mapped file is read through an io.Reader too.
+//
+// The points are read and processed by chunks of chunkSize points, the next
+// chunk being read while the current one is processed, so that at most
+// 2·chunkSize points are held in memory. The weighted bucket sums of each
+// c-bit window are accumulated across the chunks and reduced once at the end.
+// The result is the same as the one of MultiExp.
+func (p *G2Jac) MultiExpReader(r io.Reader, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G2Jac, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("invalid chunk size")
+	}
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU() * 2
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+	nbPoints := len(scalars)
+	chunkSize = min(chunkSize, nbPoints)
+
+	// the window size is the one of a multiExp of chunkSize points, as the
+	// buckets are reduced for each chunk.
+	c := bestCG2(chunkSize)
+	windows := make([]g2JacExtended, computeNbChunks(c))
+	for j := range windows {
+		windows[j].SetInfinity()
+	}
+
+	var buffers [2][]G2Affine
+	buffers[0] = make([]G2Affine, chunkSize)
+	if nbPoints > chunkSize {
+		buffers[1] = make([]G2Affine, chunkSize)
+	}
+	if _, err := unsafe.ReadSliceElements(r, buffers[0]); err != nil {
+		return nil, err
+	}
+
+	for start, k := 0, 0; start < nbPoints; start, k = start+chunkSize, k+1 {
+		end := min(start+chunkSize, nbPoints)
+		points := buffers[k%2][:end-start]
+
+		// read the next chunk while processing this one
+		chErr := make(chan error, 1)
+		if end < nbPoints {
+			next := buffers[(k+1)%2][:min(chunkSize, nbPoints-end)]
+			go func() {
+				_, err := unsafe.ReadSliceElements(r, next)
+				chErr <- err
+			}()
+		} else {
+			chErr <- nil
+		}
+
+		chChunks := _innerMsmWindowsG2(c, points, scalars[start:end], config)
+		for j := range windows {
+			total := <-chChunks[j]
+			windows[j].add(&total)
+		}
+		if err := <-chErr; err != nil {
+			return nil, err
+		}
+	}
+
+	chWindows := make([]chan g2JacExtended, len(windows))
+	for j := range windows {
+		chWindows[j] = make(chan g2JacExtended, 1)
+		chWindows[j] <- windows[j]
+	}
+	return msmReduceChunkG2Affine(p, int(c), chWindows), nil
+}
+
 // Fold computes the multi-exponentiation \sum_{i=0}^{len(points)-1} points[i] *
 // combinationCoeff^i and stores the result in p. It returns error in case
 // configuration is invalid.
@@ -799,11 +1029,17 @@ func partitionScalars(scalars []fr.Element, c uint64, nbTasks int) ([]uint16, []
 
 	}, nbTasks)
 
+	return digits, computeChunkStats(digits, len(scalars), c, int(nbChunks), nbTasks)
+}
+
+// computeChunkStats computes the statistics of the nbChunks chunks of digits,
+// as returned by partitionScalars for nbScalars scalars.
+func computeChunkStats(digits []uint16, nbScalars int, c uint64, nbChunks, nbTasks int) []chunkStat {
 	// aggregate  chunk stats
 	chunkStats := make([]chunkStat, nbChunks)
 	if c <= 9 {
 		// no need to compute stats for small window sizes
-		return digits, chunkStats
+		return chunkStats
 	}
 	parallel.Execute(len(chunkStats), func(start, end int) {
 		// for each chunk compute the statistics
@@ -812,7 +1048,7 @@ func partitionScalars(scalars []fr.Element, c uint64, nbTasks int) ([]uint16, []
 			var b bitSetC16
 
 			// digits for the chunk
-			chunkDigits := digits[chunkID*len(scalars) : (chunkID+1)*len(scalars)]
+			chunkDigits := digits[chunkID*nbScalars : (chunkID+1)*nbScalars]
 
 			totalOps := 0
 			nz := 0 // non zero buckets count
@@ -849,5 +1085,5 @@ func partitionScalars(scalars []fr.Element, c uint64, nbTasks int) ([]uint16, []
 		}
 	}
 
-	return digits, chunkStats
+	return chunkStats
 }
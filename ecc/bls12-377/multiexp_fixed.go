@@ -0,0 +1,554 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"errors"
+	"io"
+	"math"
+	"runtime"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+// FixedBaseMSMOption sets options of a fixed-base multi-exponentiation. See
+// the descriptions of functions returning instances of this type for
+// particular options.
+type FixedBaseMSMOption func(*fixedBaseMSMConfig)
+
+type fixedBaseMSMConfig struct {
+	c        uint64
+	nbTables int
+}
+
+// WithWindowSize sets the size c of the windows in which the scalars are
+// decomposed. It must be one of the window sizes implemented by MultiExp.
+// Default is the one minimizing the cost of a multi-exponentiation with the
+// tables, which is larger than the one of MultiExp as the buckets are reduced
+// once per set of windows sharing a table instead of once per window.
+func WithWindowSize(c uint64) FixedBaseMSMOption {
+	return func(opt *fixedBaseMSMConfig) {
+		opt.c = c
+	}
+}
+
+// WithNbTables sets the number of multiples of each point which are
+// precomputed, trading memory for speed. With one table, only the points are
+// stored and a multi-exponentiation costs as much as MultiExp. With as many
+// tables as windows in a scalar, that is ⌈fr.Bits/c⌉, the windows are
+// processed in a single pass, without doublings. Default is the latter.
+func WithNbTables(nbTables int) FixedBaseMSMOption {
+	return func(opt *fixedBaseMSMConfig) {
+		opt.nbTables = nbTables
+	}
+}
+
+// fixedBaseMSMLayout returns the number s of windows sharing a table, and
+// the number of tables, for the given window size and requested number of
+// tables (0 for the default).
+func fixedBaseMSMLayout(c uint64, nbTables int) (stride, tables int) {
+	nbWindows := int(computeNbChunks(c))
+	if nbTables <= 0 || nbTables > nbWindows {
+		nbTables = nbWindows
+	}
+	stride = (nbWindows + nbTables - 1) / nbTables
+	return stride, (nbWindows + stride - 1) / stride
+}
+
+// FixedBaseMSMG1 computes multi-exponentiations with a fixed set of points,
+// typically the ProvingKey of a polynomial commitment scheme, from precomputed
+// tables.
+//
+// The scalars are decomposed in signed c-bit windows as in MultiExp. The
+// window j of the scalars has weight 2^{c·j}; for each point Pᵢ and each table
+// t, the tables hold 2^{c·s·t}·Pᵢ where s is the number of windows sharing a
+// table. The windows j = s·t + k of all the scalars, for t varying, are then
+// accumulated in a single set of buckets, and the s weighted bucket sums are
+// combined with c·(s-1) doublings.
+type FixedBaseMSMG1 struct {
+	c        uint64
+	stride   int
+	nbPoints int
+	nbTables int
+	// tables[t·nbPoints+i] = 2^{c·stride·t}·pointsᵢ
+	tables []G1Affine
+}
+
+// NewFixedBaseMSMG1 precomputes the tables for the multi-exponentiations with
+// points. With the default options, the tables hold ⌈fr.Bits/c⌉ times as many
+// points as the input.
+func NewFixedBaseMSMG1(points []G1Affine, opts ...FixedBaseMSMOption) (*FixedBaseMSMG1, error) {
+	var opt fixedBaseMSMConfig
+	for _, option := range opts {
+		option(&opt)
+	}
+	if opt.c == 0 {
+		// approximate cost (in group operations)
+		// cost = nbWindows * nbPoints + stride * 2^{c}
+		minCost := math.MaxInt
+		for _, c := range implementedCsG1 {
+			stride, _ := fixedBaseMSMLayout(c, opt.nbTables)
+			if cost := int(computeNbChunks(c))*len(points) + stride*(1<<c); cost < minCost {
+				minCost = cost
+				opt.c = c
+			}
+		}
+	} else if !slices.Contains(implementedCsG1, opt.c) {
+		return nil, errors.New("invalid window size")
+	}
+	stride, nbTables := fixedBaseMSMLayout(opt.c, opt.nbTables)
+
+	n := len(points)
+	m := &FixedBaseMSMG1{
+		c:        opt.c,
+		stride:   stride,
+		nbPoints: n,
+		nbTables: nbTables,
+		tables:   make([]G1Affine, nbTables*n),
+	}
+	copy(m.tables, points)
+
+	// tables[t] = 2^{c·stride}·tables[t-1]
+	nbDoublings := int(opt.c) * stride
+	jac := make([]G1Jac, n)
+	for t := 1; t < nbTables; t++ {
+		previous, table := m.table(t-1), m.table(t)
+		parallel.Execute(n, func(start, end int) {
+			for i := start; i < end; i++ {
+				jac[i].FromAffine(&previous[i])
+				for range nbDoublings {
+					jac[i].DoubleAssign()
+				}
+			}
+		})
+		copy(table, BatchJacobianToAffineG1(jac))
+	}
+
+	return m, nil
+}
+
+// NbPoints returns the number of points of the multi-exponentiation.
+func (m *FixedBaseMSMG1) NbPoints() int {
+	return m.nbPoints
+}
+
+// MultiExp returns ∑ᵢ [scalarsᵢ]pointsᵢ. There can be fewer scalars than
+// points, the missing scalars being zero.
+func (m *FixedBaseMSMG1) MultiExp(scalars []fr.Element, config ecc.MultiExpConfig) (G1Affine, error) {
+	var res G1Jac
+	if err := m.multiExp(&res, scalars, config); err != nil {
+		return G1Affine{}, err
+	}
+	var p G1Affine
+	p.FromJacobian(&res)
+	return p, nil
+}
+
+// MultiExpBatch returns the multi-exponentiations ∑ᵢ [scalars[k]ᵢ]pointsᵢ for
+// all k.
+func (m *FixedBaseMSMG1) MultiExpBatch(scalars [][]fr.Element, config ecc.MultiExpConfig) ([]G1Affine, error) {
+	res := make([]G1Jac, len(scalars))
+	for k := range scalars {
+		if err := m.multiExp(&res[k], scalars[k], config); err != nil {
+			return nil, err
+		}
+	}
+	return BatchJacobianToAffineG1(res), nil
+}
+
+func (m *FixedBaseMSMG1) multiExp(p *G1Jac, scalars []fr.Element, config ecc.MultiExpConfig) error {
+	if len(scalars) > m.nbPoints {
+		return errors.New("more scalars than points")
+	}
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	// the digits of the windows of the scalars must match the tables
+	n := m.nbPoints
+	if len(scalars) < n {
+		scalars = append(slices.Clone(scalars), make([]fr.Element, n-len(scalars))...)
+	}
+	digits, chunkStats := partitionScalars(scalars, m.c, config.NbTasks)
+	nbWindows := int(computeNbChunks(m.c))
+	last := nbWindows - 1
+
+	// the weighted bucket sums of the s sets of windows, from the highest
+	var acc g1JacExtended
+	acc.SetInfinity()
+	for k := m.stride - 1; k >= 0; k-- {
+		for range m.c {
+			acc.double(&acc)
+		}
+
+		// the windows k, k+s, k+2s... use the consecutive tables, and are
+		// processed as a single chunk of the msm, but the last window whose
+		// digits may be larger.
+		var stat chunkStat
+		var windowDigits []uint16
+		nbTables := 0
+		for j := k; j < last; j += m.stride {
+			if m.stride > 1 {
+				windowDigits = append(windowDigits, digits[j*n:(j+1)*n]...)
+			}
+			stat.nbBucketFilled = max(stat.nbBucketFilled, chunkStats[j].nbBucketFilled)
+			nbTables++
+		}
+		if m.stride == 1 {
+			windowDigits = digits[:last*n]
+		}
+		if nbTables > 0 {
+			total := m.processWindows(getChunkProcessorG1(m.c, stat), m.tables[:nbTables*n], windowDigits, config.NbTasks)
+			acc.add(&total)
+		}
+		if last%m.stride == k {
+			total := m.processWindows(getChunkProcessorG1(lastC(m.c), chunkStats[last]), m.table(last/m.stride), digits[last*n:], config.NbTasks)
+			acc.add(&total)
+		}
+	}
+
+	p.unsafeFromJacExtended(&acc)
+	return nil
+}
+
+// processWindows returns the weighted bucket sum of the digits with the
+// points, splitting the work in nbTasks parts.
+func (m *FixedBaseMSMG1) processWindows(processChunk func(chunkID uint64, chRes chan<- g1JacExtended, c uint64, points []G1Affine, digits []uint16, sem chan struct{}), points []G1Affine, digits []uint16, nbTasks int) g1JacExtended {
+	nbTasks = max(1, min(nbTasks, len(digits)/(1<<m.c)))
+	chRes := make(chan g1JacExtended, nbTasks)
+	for i := range nbTasks {
+		start, end := i*len(digits)/nbTasks, (i+1)*len(digits)/nbTasks
+		go processChunk(0, chRes, m.c, points[start:end], digits[start:end], nil)
+	}
+	total := <-chRes
+	for range nbTasks - 1 {
+		t := <-chRes
+		total.add(&t)
+	}
+	return total
+}
+
+// table returns the t-th table
+func (m *FixedBaseMSMG1) table(t int) []G1Affine {
+	return m.tables[t*m.nbPoints : (t+1)*m.nbPoints]
+}
+
+// WriteTo writes the binary encoding of the tables, with compressed points.
+func (m *FixedBaseMSMG1) WriteTo(w io.Writer) (int64, error) {
+	return m.writeTo(NewEncoder(w))
+}
+
+// WriteRawTo writes the binary encoding of the tables, without point
+// compression. The encoding is larger but faster to read.
+func (m *FixedBaseMSMG1) WriteRawTo(w io.Writer) (int64, error) {
+	return m.writeTo(NewEncoder(w, RawEncoding()))
+}
+
+func (m *FixedBaseMSMG1) writeTo(enc *Encoder) (int64, error) {
+	if err := enc.Encode([]uint64{m.c, uint64(m.stride), uint64(m.nbTables)}); err != nil {
+		return enc.BytesWritten(), err
+	}
+	for t := range m.nbTables {
+		if err := enc.Encode(m.table(t)); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes the tables written by WriteTo or WriteRawTo.
+func (m *FixedBaseMSMG1) ReadFrom(r io.Reader) (int64, error) {
+	return m.readFrom(NewDecoder(r))
+}
+
+// UnsafeReadFrom decodes the tables written by WriteTo or WriteRawTo,
+// without checking that the points are in the correct subgroup.
+func (m *FixedBaseMSMG1) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return m.readFrom(NewDecoder(r, NoSubgroupChecks()))
+}
+
+func (m *FixedBaseMSMG1) readFrom(dec *Decoder) (int64, error) {
+	var header []uint64
+	if err := dec.Decode(&header); err != nil {
+		return dec.BytesRead(), err
+	}
+	if len(header) != 3 || !slices.Contains(implementedCsG1, header[0]) {
+		return dec.BytesRead(), errors.New("invalid fixed-base msm encoding")
+	}
+	stride, nbTables := fixedBaseMSMLayout(header[0], int(header[2]))
+	if uint64(stride) != header[1] || uint64(nbTables) != header[2] {
+		return dec.BytesRead(), errors.New("invalid fixed-base msm encoding")
+	}
+	var tables, table []G1Affine
+	for t := range nbTables {
+		if err := dec.Decode(&table); err != nil {
+			return dec.BytesRead(), err
+		}
+		if t == 0 {
+			tables = make([]G1Affine, 0, nbTables*len(table))
+		} else if len(table) != len(tables)/t {
+			return dec.BytesRead(), errors.New("invalid fixed-base msm encoding")
+		}
+		tables = append(tables, table...)
+	}
+	m.c, m.stride, m.nbTables, m.nbPoints, m.tables = header[0], stride, nbTables, len(tables)/nbTables, tables
+	return dec.BytesRead(), nil
+}
+
+// FixedBaseMSMG2 computes multi-exponentiations with a fixed set of points,
+// typically the ProvingKey of a polynomial commitment scheme, from precomputed
+// tables.
+//
+// The scalars are decomposed in signed c-bit windows as in MultiExp. The
+// window j of the scalars has weight 2^{c·j}; for each point Pᵢ and each table
+// t, the tables hold 2^{c·s·t}·Pᵢ where s is the number of windows sharing a
+// table. The windows j = s·t + k of all the scalars, for t varying, are then
+// accumulated in a single set of buckets, and the s weighted bucket sums are
+// combined with c·(s-1) doublings.
+type FixedBaseMSMG2 struct {
+	c        uint64
+	stride   int
+	nbPoints int
+	nbTables int
+	// tables[t·nbPoints+i] = 2^{c·stride·t}·pointsᵢ
+	tables []G2Affine
+}
+
+// NewFixedBaseMSMG2 precomputes the tables for the multi-exponentiations with
+// points. With the default options, the tables hold ⌈fr.Bits/c⌉ times as many
+// points as the input.
+func NewFixedBaseMSMG2(points []G2Affine, opts ...FixedBaseMSMOption) (*FixedBaseMSMG2, error) {
+	var opt fixedBaseMSMConfig
+	for _, option := range opts {
+		option(&opt)
+	}
+	if opt.c == 0 {
+		// approximate cost (in group operations)
+		// cost = nbWindows * nbPoints + stride * 2^{c}
+		minCost := math.MaxInt
+		for _, c := range implementedCsG2 {
+			stride, _ := fixedBaseMSMLayout(c, opt.nbTables)
+			if cost := int(computeNbChunks(c))*len(points) + stride*(1<<c); cost < minCost {
+				minCost = cost
+				opt.c = c
+			}
+		}
+	} else if !slices.Contains(implementedCsG2, opt.c) {
+		return nil, errors.New("invalid window size")
+	}
+	stride, nbTables := fixedBaseMSMLayout(opt.c, opt.nbTables)
+
+	n := len(points)
+	m := &FixedBaseMSMG2{
+		c:        opt.c,
+		stride:   stride,
+		nbPoints: n,
+		nbTables: nbTables,
+		tables:   make([]G2Affine, nbTables*n),
+	}
+	copy(m.tables, points)
+
+	// tables[t] = 2^{c·stride}·tables[t-1]
+	nbDoublings := int(opt.c) * stride
+	jac := make([]G2Jac, n)
+	for t := 1; t < nbTables; t++ {
+		previous, table := m.table(t-1), m.table(t)
+		parallel.Execute(n, func(start, end int) {
+			for i := start; i < end; i++ {
+				jac[i].FromAffine(&previous[i])
+				for range nbDoublings {
+					jac[i].DoubleAssign()
+				}
+			}
+		})
+		parallel.Execute(n, func(start, end int) {
+			for i := start; i < end; i++ {
+				table[i].FromJacobian(&jac[i])
+			}
+		})
+	}
+
+	return m, nil
+}
+
+// NbPoints returns the number of points of the multi-exponentiation.
+func (m *FixedBaseMSMG2) NbPoints() int {
+	return m.nbPoints
+}
+
+// MultiExp returns ∑ᵢ [scalarsᵢ]pointsᵢ. There can be fewer scalars than
+// points, the missing scalars being zero.
+func (m *FixedBaseMSMG2) MultiExp(scalars []fr.Element, config ecc.MultiExpConfig) (G2Affine, error) {
+	var res G2Jac
+	if err := m.multiExp(&res, scalars, config); err != nil {
+		return G2Affine{}, err
+	}
+	var p G2Affine
+	p.FromJacobian(&res)
+	return p, nil
+}
+
+// MultiExpBatch returns the multi-exponentiations ∑ᵢ [scalars[k]ᵢ]pointsᵢ for
+// all k.
+func (m *FixedBaseMSMG2) MultiExpBatch(scalars [][]fr.Element, config ecc.MultiExpConfig) ([]G2Affine, error) {
+	res := make([]G2Jac, len(scalars))
+	for k := range scalars {
+		if err := m.multiExp(&res[k], scalars[k], config); err != nil {
+			return nil, err
+		}
+	}
+	points := make([]G2Affine, len(res))
+	for k := range res {
+		points[k].FromJacobian(&res[k])
+	}
+	return points, nil
+}
+
+func (m *FixedBaseMSMG2) multiExp(p *G2Jac, scalars []fr.Element, config ecc.MultiExpConfig) error {
+	if len(scalars) > m.nbPoints {
+		return errors.New("more scalars than points")
+	}
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	// the digits of the windows of the scalars must match the tables
+	n := m.nbPoints
+	if len(scalars) < n {
+		scalars = append(slices.Clone(scalars), make([]fr.Element, n-len(scalars))...)
+	}
+	digits, chunkStats := partitionScalars(scalars, m.c, config.NbTasks)
+	nbWindows := int(computeNbChunks(m.c))
+	last := nbWindows - 1
+
+	// the weighted bucket sums of the s sets of windows, from the highest
+	var acc g2JacExtended
+	acc.SetInfinity()
+	for k := m.stride - 1; k >= 0; k-- {
+		for range m.c {
+			acc.double(&acc)
+		}
+
+		// the windows k, k+s, k+2s... use the consecutive tables, and are
+		// processed as a single chunk of the msm, but the last window whose
+		// digits may be larger.
+		var stat chunkStat
+		var windowDigits []uint16
+		nbTables := 0
+		for j := k; j < last; j += m.stride {
+			if m.stride > 1 {
+				windowDigits = append(windowDigits, digits[j*n:(j+1)*n]...)
+			}
+			stat.nbBucketFilled = max(stat.nbBucketFilled, chunkStats[j].nbBucketFilled)
+			nbTables++
+		}
+		if m.stride == 1 {
+			windowDigits = digits[:last*n]
+		}
+		if nbTables > 0 {
+			total := m.processWindows(getChunkProcessorG2(m.c, stat), m.tables[:nbTables*n], windowDigits, config.NbTasks)
+			acc.add(&total)
+		}
+		if last%m.stride == k {
+			total := m.processWindows(getChunkProcessorG2(lastC(m.c), chunkStats[last]), m.table(last/m.stride), digits[last*n:], config.NbTasks)
+			acc.add(&total)
+		}
+	}
+
+	p.unsafeFromJacExtended(&acc)
+	return nil
+}
+
+// processWindows returns the weighted bucket sum of the digits with the
+// points, splitting the work in nbTasks parts.
+func (m *FixedBaseMSMG2) processWindows(processChunk func(chunkID uint64, chRes chan<- g2JacExtended, c uint64, points []G2Affine, digits []uint16, sem chan struct{}), points []G2Affine, digits []uint16, nbTasks int) g2JacExtended {
+	nbTasks = max(1, min(nbTasks, len(digits)/(1<<m.c)))
+	chRes := make(chan g2JacExtended, nbTasks)
+	for i := range nbTasks {
+		start, end := i*len(digits)/nbTasks, (i+1)*len(digits)/nbTasks
+		go processChunk(0, chRes, m.c, points[start:end], digits[start:end], nil)
+	}
+	total := <-chRes
+	for range nbTasks - 1 {
+		t := <-chRes
+		total.add(&t)
+	}
+	return total
+}
+
+// table returns the t-th table
+func (m *FixedBaseMSMG2) table(t int) []G2Affine {
+	return m.tables[t*m.nbPoints : (t+1)*m.nbPoints]
+}
+
+// WriteTo writes the binary encoding of the tables, with compressed points.
+func (m *FixedBaseMSMG2) WriteTo(w io.Writer) (int64, error) {
+	return m.writeTo(NewEncoder(w))
+}
+
+// WriteRawTo writes the binary encoding of the tables, without point
+// compression. The encoding is larger but faster to read.
+func (m *FixedBaseMSMG2) WriteRawTo(w io.Writer) (int64, error) {
+	return m.writeTo(NewEncoder(w, RawEncoding()))
+}
+
+func (m *FixedBaseMSMG2) writeTo(enc *Encoder) (int64, error) {
+	if err := enc.Encode([]uint64{m.c, uint64(m.stride), uint64(m.nbTables)}); err != nil {
+		return enc.BytesWritten(), err
+	}
+	for t := range m.nbTables {
+		if err := enc.Encode(m.table(t)); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes the tables written by WriteTo or WriteRawTo.
+func (m *FixedBaseMSMG2) ReadFrom(r io.Reader) (int64, error) {
+	return m.readFrom(NewDecoder(r))
+}
+
+// UnsafeReadFrom decodes the tables written by WriteTo or WriteRawTo,
+// without checking that the points are in the correct subgroup.
+func (m *FixedBaseMSMG2) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return m.readFrom(NewDecoder(r, NoSubgroupChecks()))
+}
+
+func (m *FixedBaseMSMG2) readFrom(dec *Decoder) (int64, error) {
+	var header []uint64
+	if err := dec.Decode(&header); err != nil {
+		return dec.BytesRead(), err
+	}
+	if len(header) != 3 || !slices.Contains(implementedCsG2, header[0]) {
+		return dec.BytesRead(), errors.New("invalid fixed-base msm encoding")
+	}
+	stride, nbTables := fixedBaseMSMLayout(header[0], int(header[2]))
+	if uint64(stride) != header[1] || uint64(nbTables) != header[2] {
+		return dec.BytesRead(), errors.New("invalid fixed-base msm encoding")
+	}
+	var tables, table []G2Affine
+	for t := range nbTables {
+		if err := dec.Decode(&table); err != nil {
+			return dec.BytesRead(), err
+		}
+		if t == 0 {
+			tables = make([]G2Affine, 0, nbTables*len(table))
+		} else if len(table) != len(tables)/t {
+			return dec.BytesRead(), errors.New("invalid fixed-base msm encoding")
+		}
+		tables = append(tables, table...)
+	}
+	m.c, m.stride, m.nbTables, m.nbPoints, m.tables = header[0], stride, nbTables, len(tables)/nbTables, tables
+	return dec.BytesRead(), nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedBaseMSMG1(t *testing.T) {
+	assert := require.New(t)
+
+	const nbSamples = 100
+	points := make([]G1Affine, nbSamples)
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := range points {
+		points[i].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+	points[7].SetInfinity()
+
+	scalars := make([][]fr.Element, 3)
+	for k := range scalars {
+		scalars[k] = make([]fr.Element, nbSamples-k*30)
+		fillBenchScalars(scalars[k])
+	}
+	scalars[0][3].SetZero()
+	scalars[0][4].SetOne()
+
+	expected := make([]G1Affine, len(scalars))
+	for k := range scalars {
+		_, err := expected[k].MultiExp(points[:len(scalars[k])], scalars[k], ecc.MultiExpConfig{})
+		assert.NoError(err)
+	}
+
+	check := func(m *FixedBaseMSMG1) {
+		for k := range scalars {
+			got, err := m.MultiExp(scalars[k], ecc.MultiExpConfig{})
+			assert.NoError(err)
+			assert.True(got.Equal(&expected[k]), "wrong multi-exponentiation")
+		}
+		got, err := m.MultiExpBatch(scalars, ecc.MultiExpConfig{NbTasks: 3})
+		assert.NoError(err)
+		for k := range scalars {
+			assert.True(got[k].Equal(&expected[k]), "wrong batch multi-exponentiation")
+		}
+	}
+
+	for _, opts := range [][]FixedBaseMSMOption{
+		nil,
+		{WithNbTables(1)},
+		{WithNbTables(3)},
+		{WithWindowSize(5), WithNbTables(7)},
+	} {
+		m, err := NewFixedBaseMSMG1(points, opts...)
+		assert.NoError(err)
+		assert.Equal(nbSamples, m.NbPoints())
+		check(m)
+
+		// serialization
+		var buf bytes.Buffer
+		_, err = m.WriteRawTo(&buf)
+		assert.NoError(err)
+		var read FixedBaseMSMG1
+		_, err = read.UnsafeReadFrom(&buf)
+		assert.NoError(err)
+		assert.Equal(m, &read)
+	}
+
+	m, err := NewFixedBaseMSMG1(points, WithNbTables(2))
+	assert.NoError(err)
+	var buf bytes.Buffer
+	_, err = m.WriteTo(&buf)
+	assert.NoError(err)
+	var read FixedBaseMSMG1
+	_, err = read.ReadFrom(&buf)
+	assert.NoError(err)
+	check(&read)
+
+	// errors
+	_, err = NewFixedBaseMSMG1(points, WithWindowSize(3))
+	assert.Error(err)
+	_, err = m.MultiExp(make([]fr.Element, nbSamples+1), ecc.MultiExpConfig{})
+	assert.Error(err)
+}
+
+func TestFixedBaseMSMG2(t *testing.T) {
+	assert := require.New(t)
+
+	const nbSamples = 100
+	points := make([]G2Affine, nbSamples)
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := range points {
+		points[i].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+	points[7].SetInfinity()
+
+	scalars := make([][]fr.Element, 3)
+	for k := range scalars {
+		scalars[k] = make([]fr.Element, nbSamples-k*30)
+		fillBenchScalars(scalars[k])
+	}
+	scalars[0][3].SetZero()
+	scalars[0][4].SetOne()
+
+	expected := make([]G2Affine, len(scalars))
+	for k := range scalars {
+		_, err := expected[k].MultiExp(points[:len(scalars[k])], scalars[k], ecc.MultiExpConfig{})
+		assert.NoError(err)
+	}
+
+	check := func(m *FixedBaseMSMG2) {
+		for k := range scalars {
+			got, err := m.MultiExp(scalars[k], ecc.MultiExpConfig{})
+			assert.NoError(err)
+			assert.True(got.Equal(&expected[k]), "wrong multi-exponentiation")
+		}
+		got, err := m.MultiExpBatch(scalars, ecc.MultiExpConfig{NbTasks: 3})
+		assert.NoError(err)
+		for k := range scalars {
+			assert.True(got[k].Equal(&expected[k]), "wrong batch multi-exponentiation")
+		}
+	}
+
+	for _, opts := range [][]FixedBaseMSMOption{
+		nil,
+		{WithNbTables(1)},
+		{WithNbTables(3)},
+		{WithWindowSize(5), WithNbTables(7)},
+	} {
+		m, err := NewFixedBaseMSMG2(points, opts...)
+		assert.NoError(err)
+		assert.Equal(nbSamples, m.NbPoints())
+		check(m)
+
+		// serialization
+		var buf bytes.Buffer
+		_, err = m.WriteRawTo(&buf)
+		assert.NoError(err)
+		var read FixedBaseMSMG2
+		_, err = read.UnsafeReadFrom(&buf)
+		assert.NoError(err)
+		assert.Equal(m, &read)
+	}
+
+	m, err := NewFixedBaseMSMG2(points, WithNbTables(2))
+	assert.NoError(err)
+	var buf bytes.Buffer
+	_, err = m.WriteTo(&buf)
+	assert.NoError(err)
+	var read FixedBaseMSMG2
+	_, err = read.ReadFrom(&buf)
+	assert.NoError(err)
+	check(&read)
+
+	// errors
+	_, err = NewFixedBaseMSMG2(points, WithWindowSize(3))
+	assert.Error(err)
+	_, err = m.MultiExp(make([]fr.Element, nbSamples+1), ecc.MultiExpConfig{})
+	assert.Error(err)
+}
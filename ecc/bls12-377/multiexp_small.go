@@ -0,0 +1,331 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+// scanScalars returns the scalars as uint64 if they all fit on 64 bits, or
+// nil, along with the maximal bit length of the scalars in the first case,
+// and the number of zero scalars.
+//
+// The scalars are first checked without allocating, and the check stops at the
+// first wide scalar: the uint64 are only extracted if all scalars are small.
+func scanScalars(scalars []fr.Element, nbTasks int) (small []uint64, nbBits, nbZeros int) {
+	var isWide atomic.Bool
+	var zeros atomic.Int64
+	parallel.Execute(len(scalars), func(start, end int) {
+		localZeros := 0
+		for i := start; i < end; i++ {
+			if scalars[i].IsZero() {
+				localZeros++
+			} else if !isWide.Load() && !scalars[i].IsUint64() {
+				isWide.Store(true)
+			}
+		}
+		zeros.Add(int64(localZeros))
+	}, nbTasks)
+	nbZeros = int(zeros.Load())
+	if isWide.Load() {
+		return nil, 0, nbZeros
+	}
+
+	small = make([]uint64, len(scalars))
+	var lock sync.Mutex
+	parallel.Execute(len(scalars), func(start, end int) {
+		localBits := 0
+		for i := start; i < end; i++ {
+			small[i] = scalars[i].Uint64()
+			localBits = max(localBits, bits.Len64(small[i]))
+		}
+		lock.Lock()
+		nbBits = max(nbBits, localBits)
+		lock.Unlock()
+	}, nbTasks)
+	return small, nbBits, nbZeros
+}
+
+// isSparse returns true if it is worth removing the zero scalars before a
+// multiExp.
+func isSparse(nbScalars, nbZeros int) bool {
+	return 4*nbZeros > nbScalars
+}
+
+// removeZeros returns the points and scalars for which the scalar is not zero.
+func removeZeros[P, S any](points []P, scalars []S, isZero func(*S) bool) ([]P, []S) {
+	var (
+		p []P
+		s []S
+	)
+	for i := range scalars {
+		if !isZero(&scalars[i]) {
+			p = append(p, points[i])
+			s = append(s, scalars[i])
+		}
+	}
+	return p, s
+}
+
+// partitionSmallScalars is partitionScalars for scalars on 64 bits, decomposed
+// in nbChunks c-bit windows. nbChunks·c must be larger than the bit length of
+// the scalars, so that the last window accommodates a carry.
+func partitionSmallScalars(scalars []uint64, c uint64, nbChunks int, nbTasks int) ([]uint16, []chunkStat) {
+	digits := make([]uint16, len(scalars)*nbChunks)
+
+	mask := uint64((1 << c) - 1) // low c bits are 1
+	max := int(1<<(c-1)) - 1     // max value (inclusive) we want for our digits
+
+	parallel.Execute(len(scalars), func(start, end int) {
+		for i := start; i < end; i++ {
+			var carry int
+			for chunk := range nbChunks {
+				// a shift of 64 bits or more gives 0
+				digit := carry + int((scalars[i]>>(uint64(chunk)*c))&mask)
+				carry = 0
+
+				// if the digit is larger than 2^{c-1}, then, we borrow 2^c from the next window and subtract
+				// 2^{c} to the current digit, making it negative.
+				if digit > max && chunk != nbChunks-1 {
+					digit -= (1 << c)
+					carry = 1
+				}
+
+				// if digit is zero, no impact on result
+				if digit == 0 {
+					continue
+				}
+
+				var bits uint16
+				if digit > 0 {
+					bits = uint16(digit) << 1
+				} else {
+					bits = (uint16(-digit-1) << 1) + 1
+				}
+				digits[chunk*len(scalars)+i] = bits
+			}
+		}
+	}, nbTasks)
+
+	return digits, computeChunkStats(digits, len(scalars), c, nbChunks, nbTasks)
+}
+
+// MultiExpSmall computes ∑ᵢ [scalarsᵢ]pointsᵢ for scalars on 64 bits.
+//
+// This call return an error if len(scalars) != len(points) or if provided config is invalid.
+func (p *G1Affine) MultiExpSmall(points []G1Affine, scalars []uint64, config ecc.MultiExpConfig) (*G1Affine, error) {
+	var _p G1Jac
+	if _, err := _p.MultiExpSmall(points, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpSmall computes ∑ᵢ [scalarsᵢ]pointsᵢ for scalars on 64 bits. The
+// scalars are decomposed in as few windows as the largest of them needs, so
+// that for instance a vector of bits is summed in a single window, and the
+// zero scalars are skipped. MultiExp uses the same algorithm when its scalars
+// are small.
+//
+// This call return an error if len(scalars) != len(points) or if provided config is invalid.
+func (p *G1Jac) MultiExpSmall(points []G1Affine, scalars []uint64, config ecc.MultiExpConfig) (*G1Jac, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU() * 2
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	nbBits, nbZeros := 0, 0
+	for _, s := range scalars {
+		if s == 0 {
+			nbZeros++
+		}
+		nbBits = max(nbBits, bits.Len64(s))
+	}
+	return p.multiExpSmall(points, scalars, nbBits, nbZeros, config), nil
+}
+
+func (p *G1Jac) multiExpSmall(points []G1Affine, scalars []uint64, nbBits, nbZeros int, config ecc.MultiExpConfig) *G1Jac {
+	if isSparse(len(scalars), nbZeros) {
+		points, scalars = removeZeros(points, scalars, func(s *uint64) bool { return *s == 0 })
+	}
+	n := len(points)
+	if nbBits == 0 || n == 0 {
+		var infinity g1JacExtended
+		infinity.SetInfinity()
+		return p.unsafeFromJacExtended(&infinity)
+	}
+
+	// the windows are the chunks of the msm; the last one has room for a carry
+	c := bestCSmallG1(n, nbBits)
+	nbChunks := (nbBits + int(c)) / int(c)
+	digits, chunkStats := partitionSmallScalars(scalars, c, nbChunks, config.NbTasks)
+
+	// with few chunks, we split the points so that all the tasks are busy
+	nbSplits := max(1, min(config.NbTasks/nbChunks, n>>c))
+
+	chChunks := make([]chan g1JacExtended, nbChunks)
+	for j := range chChunks {
+		chChunks[j] = make(chan g1JacExtended, 1)
+		processChunk := getChunkProcessorG1(c, chunkStats[j])
+		chunkDigits := digits[j*n : (j+1)*n]
+		if nbSplits == 1 {
+			go processChunk(uint64(j), chChunks[j], c, points, chunkDigits, nil)
+			continue
+		}
+		chSplit := make(chan g1JacExtended, nbSplits)
+		for k := range nbSplits {
+			start, end := k*n/nbSplits, (k+1)*n/nbSplits
+			go processChunk(uint64(j), chSplit, c, points[start:end], chunkDigits[start:end], nil)
+		}
+		go func(chunkID int) {
+			total := <-chSplit
+			for range nbSplits - 1 {
+				s := <-chSplit
+				total.add(&s)
+			}
+			chChunks[chunkID] <- total
+		}(j)
+	}
+
+	return msmReduceChunkG1Affine(p, int(c), chChunks)
+}
+
+// bestCSmallG1 returns the window size minimizing the cost of a multiExp of
+// nbPoints scalars of nbBits bits.
+func bestCSmallG1(nbPoints, nbBits int) uint64 {
+	var C uint64
+	min := math.MaxInt
+	for _, c := range implementedCsG1 {
+		// digits are encoded on 16 bits, and the last window may hold 2^{c-1}
+		if c >= 16 {
+			continue
+		}
+		nbChunks := (nbBits + int(c)) / int(c)
+		if cost := nbChunks * (nbPoints + (1 << c)); cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
+}
+
+// MultiExpSmall computes ∑ᵢ [scalarsᵢ]pointsᵢ for scalars on 64 bits.
+//
+// This call return an error if len(scalars) != len(points) or if provided config is invalid.
+func (p *G2Affine) MultiExpSmall(points []G2Affine, scalars []uint64, config ecc.MultiExpConfig) (*G2Affine, error) {
+	var _p G2Jac
+	if _, err := _p.MultiExpSmall(points, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpSmall computes ∑ᵢ [scalarsᵢ]pointsᵢ for scalars on 64 bits. The
+// scalars are decomposed in as few windows as the largest of them needs, so
+// that for instance a vector of bits is summed in a single window, and the
+// zero scalars are skipped. MultiExp uses the same algorithm when its scalars
+// are small.
+//
+// This call return an error if len(scalars) != len(points) or if provided config is invalid.
+func (p *G2Jac) MultiExpSmall(points []G2Affine, scalars []uint64, config ecc.MultiExpConfig) (*G2Jac, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU() * 2
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	nbBits, nbZeros := 0, 0
+	for _, s := range scalars {
+		if s == 0 {
+			nbZeros++
+		}
+		nbBits = max(nbBits, bits.Len64(s))
+	}
+	return p.multiExpSmall(points, scalars, nbBits, nbZeros, config), nil
+}
+
+func (p *G2Jac) multiExpSmall(points []G2Affine, scalars []uint64, nbBits, nbZeros int, config ecc.MultiExpConfig) *G2Jac {
+	if isSparse(len(scalars), nbZeros) {
+		points, scalars = removeZeros(points, scalars, func(s *uint64) bool { return *s == 0 })
+	}
+	n := len(points)
+	if nbBits == 0 || n == 0 {
+		var infinity g2JacExtended
+		infinity.SetInfinity()
+		return p.unsafeFromJacExtended(&infinity)
+	}
+
+	// the windows are the chunks of the msm; the last one has room for a carry
+	c := bestCSmallG2(n, nbBits)
+	nbChunks := (nbBits + int(c)) / int(c)
+	digits, chunkStats := partitionSmallScalars(scalars, c, nbChunks, config.NbTasks)
+
+	// with few chunks, we split the points so that all the tasks are busy
+	nbSplits := max(1, min(config.NbTasks/nbChunks, n>>c))
+
+	chChunks := make([]chan g2JacExtended, nbChunks)
+	for j := range chChunks {
+		chChunks[j] = make(chan g2JacExtended, 1)
+		processChunk := getChunkProcessorG2(c, chunkStats[j])
+		chunkDigits := digits[j*n : (j+1)*n]
+		if nbSplits == 1 {
+			go processChunk(uint64(j), chChunks[j], c, points, chunkDigits, nil)
+			continue
+		}
+		chSplit := make(chan g2JacExtended, nbSplits)
+		for k := range nbSplits {
+			start, end := k*n/nbSplits, (k+1)*n/nbSplits
+			go processChunk(uint64(j), chSplit, c, points[start:end], chunkDigits[start:end], nil)
+		}
+		go func(chunkID int) {
+			total := <-chSplit
+			for range nbSplits - 1 {
+				s := <-chSplit
+				total.add(&s)
+			}
+			chChunks[chunkID] <- total
+		}(j)
+	}
+
+	return msmReduceChunkG2Affine(p, int(c), chChunks)
+}
+
+// bestCSmallG2 returns the window size minimizing the cost of a multiExp of
+// nbPoints scalars of nbBits bits.
+func bestCSmallG2(nbPoints, nbBits int) uint64 {
+	var C uint64
+	min := math.MaxInt
+	for _, c := range implementedCsG2 {
+		// digits are encoded on 16 bits, and the last window may hold 2^{c-1}
+		if c >= 16 {
+			continue
+		}
+		nbChunks := (nbBits + int(c)) / int(c)
+		if cost := nbChunks * (nbPoints + (1 << c)); cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
+}
@@ -0,0 +1,153 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+func TestMultiExpSmallG1(t *testing.T) {
+	const nbSamples = 1 << 10
+	var samplePoints [nbSamples]G1Affine
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+	samplePoints[rand.N(nbSamples)].SetInfinity() //#nosec G404 weak rng is fine here
+
+	// the reference is the generic algorithm on full-width scalars
+	config := ecc.MultiExpConfig{NbTasks: runtime.NumCPU()}
+	reference := func(scalars []fr.Element) G1Affine {
+		var r G1Jac
+		_innerMsmG1(&r, bestCG1(nbSamples), samplePoints[:], scalars, config)
+		var res G1Affine
+		res.FromJacobian(&r)
+		return res
+	}
+
+	vectors := map[string]func(int) uint64{
+		"zeros":  func(int) uint64 { return 0 },
+		"bits":   func(int) uint64 { return rand.Uint64N(2) },       //#nosec G404 weak rng is fine here
+		"u32":    func(int) uint64 { return uint64(rand.Uint32()) }, //#nosec G404 weak rng is fine here
+		"u64":    func(int) uint64 { return rand.Uint64() },         //#nosec G404 weak rng is fine here
+		"max":    func(int) uint64 { return ^uint64(0) },
+		"sparse": func(i int) uint64 { return uint64(i%10/9) * rand.Uint64() }, //#nosec G404 weak rng is fine here
+		"one":    func(i int) uint64 { return uint64(i / (nbSamples - 1)) },
+	}
+	for name, sample := range vectors {
+		small := make([]uint64, nbSamples)
+		scalars := make([]fr.Element, nbSamples)
+		for i := range small {
+			small[i] = sample(i)
+			scalars[i].SetUint64(small[i])
+		}
+		expected := reference(scalars)
+
+		var got G1Affine
+		if _, err := got.MultiExpSmall(samplePoints[:], small, config); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&expected) {
+			t.Fatalf("MultiExpSmall failed on %s scalars", name)
+		}
+		if _, err := got.MultiExp(samplePoints[:], scalars, config); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&expected) {
+			t.Fatalf("MultiExp failed on %s scalars", name)
+		}
+	}
+
+	// sparse full-width scalars
+	scalars := make([]fr.Element, nbSamples)
+	for i := 0; i < nbSamples; i += 7 {
+		scalars[i].MustSetRandom()
+	}
+	expected := reference(scalars)
+	var got G1Affine
+	if _, err := got.MultiExp(samplePoints[:], scalars, config); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&expected) {
+		t.Fatal("MultiExp failed on sparse scalars")
+	}
+}
+
+func TestMultiExpSmallG2(t *testing.T) {
+	const nbSamples = 1 << 10
+	var samplePoints [nbSamples]G2Affine
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+	samplePoints[rand.N(nbSamples)].SetInfinity() //#nosec G404 weak rng is fine here
+
+	// the reference is the generic algorithm on full-width scalars
+	config := ecc.MultiExpConfig{NbTasks: runtime.NumCPU()}
+	reference := func(scalars []fr.Element) G2Affine {
+		var r G2Jac
+		_innerMsmG2(&r, bestCG2(nbSamples), samplePoints[:], scalars, config)
+		var res G2Affine
+		res.FromJacobian(&r)
+		return res
+	}
+
+	vectors := map[string]func(int) uint64{
+		"zeros":  func(int) uint64 { return 0 },
+		"bits":   func(int) uint64 { return rand.Uint64N(2) },       //#nosec G404 weak rng is fine here
+		"u32":    func(int) uint64 { return uint64(rand.Uint32()) }, //#nosec G404 weak rng is fine here
+		"u64":    func(int) uint64 { return rand.Uint64() },         //#nosec G404 weak rng is fine here
+		"max":    func(int) uint64 { return ^uint64(0) },
+		"sparse": func(i int) uint64 { return uint64(i%10/9) * rand.Uint64() }, //#nosec G404 weak rng is fine here
+		"one":    func(i int) uint64 { return uint64(i / (nbSamples - 1)) },
+	}
+	for name, sample := range vectors {
+		small := make([]uint64, nbSamples)
+		scalars := make([]fr.Element, nbSamples)
+		for i := range small {
+			small[i] = sample(i)
+			scalars[i].SetUint64(small[i])
+		}
+		expected := reference(scalars)
+
+		var got G2Affine
+		if _, err := got.MultiExpSmall(samplePoints[:], small, config); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&expected) {
+			t.Fatalf("MultiExpSmall failed on %s scalars", name)
+		}
+		if _, err := got.MultiExp(samplePoints[:], scalars, config); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&expected) {
+			t.Fatalf("MultiExp failed on %s scalars", name)
+		}
+	}
+
+	// sparse full-width scalars
+	scalars := make([]fr.Element, nbSamples)
+	for i := 0; i < nbSamples; i += 7 {
+		scalars[i].MustSetRandom()
+	}
+	expected := reference(scalars)
+	var got G2Affine
+	if _, err := got.MultiExp(samplePoints[:], scalars, config); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(&expected) {
+		t.Fatal("MultiExp failed on sparse scalars")
+	}
+}
@@ -6,6 +6,7 @@
 package bls12377
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"math/bits"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/utils/unsafe"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
 )
@@ -271,6 +273,48 @@ func TestCrossMultiExpG1(t *testing.T) {
 
 }
 
+func TestMultiExpReaderG1(t *testing.T) {
+	const nbSamples = 1 << 10
+	var samplePoints [nbSamples]G1Affine
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+	samplePoints[rand.N(nbSamples)].SetInfinity() //#nosec G404 weak rng is fine here
+
+	var sampleScalars [nbSamples]fr.Element
+	fillBenchScalars(sampleScalars[:])
+
+	var buf bytes.Buffer
+	if err := unsafe.WriteSlice(&buf, samplePoints[:]); err != nil {
+		t.Fatal(err)
+	}
+	dump := buf.Bytes()[8:] // skip the length of the slice
+
+	var expected G1Affine
+	if _, err := expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunkSize := range []int{1, 100, nbSamples, 3 * nbSamples} {
+		var got G1Affine
+		if _, err := got.MultiExpReader(bytes.NewReader(dump), sampleScalars[:], chunkSize, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(&got) {
+			t.Fatalf("msm from reader failed with chunk size %d", chunkSize)
+		}
+	}
+
+	// not enough points
+	var got G1Affine
+	if _, err := got.MultiExpReader(bytes.NewReader(dump[:len(dump)-1]), sampleScalars[:], 100, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("msm from a truncated reader should have failed")
+	}
+}
+
 // _innerMsmG1Reference always do ext jacobian with c == 16
 func _innerMsmG1Reference(p *G1Jac, points []G1Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G1Jac {
 	// partition the scalars
@@ -681,6 +725,48 @@ func TestCrossMultiExpG2(t *testing.T) {
 
 }
 
+func TestMultiExpReaderG2(t *testing.T) {
+	const nbSamples = 1 << 10
+	var samplePoints [nbSamples]G2Affine
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+	samplePoints[rand.N(nbSamples)].SetInfinity() //#nosec G404 weak rng is fine here
+
+	var sampleScalars [nbSamples]fr.Element
+	fillBenchScalars(sampleScalars[:])
+
+	var buf bytes.Buffer
+	if err := unsafe.WriteSlice(&buf, samplePoints[:]); err != nil {
+		t.Fatal(err)
+	}
+	dump := buf.Bytes()[8:] // skip the length of the slice
+
+	var expected G2Affine
+	if _, err := expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunkSize := range []int{1, 100, nbSamples, 3 * nbSamples} {
+		var got G2Affine
+		if _, err := got.MultiExpReader(bytes.NewReader(dump), sampleScalars[:], chunkSize, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(&got) {
+			t.Fatalf("msm from reader failed with chunk size %d", chunkSize)
+		}
+	}
+
+	// not enough points
+	var got G2Affine
+	if _, err := got.MultiExpReader(bytes.NewReader(dump[:len(dump)-1]), sampleScalars[:], 100, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("msm from a truncated reader should have failed")
+	}
+}
+
 // _innerMsmG2Reference always do ext jacobian with c == 16
 func _innerMsmG2Reference(p *G2Jac, points []G2Affine, scalars []fr.Element, config ecc.MultiExpConfig) *G2Jac {
 	// partition the scalars
@@ -0,0 +1,21 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package pst provides a multilinear KZG commitment scheme (Papamanthou–Shi–Tamassia),
+// cf https://eprint.iacr.org/2011/587.pdf
+//
+// A multilinear polynomial f in n variables is given by its evaluations over the
+// boolean hypercube {0,1}ⁿ, as a polynomial.MultiLin. The commitment is [f(τ)]₁ where
+// τ = (τ₁, …, τₙ) is the secret of the SRS. An opening at z ∈ 𝔽ⁿ consists of the
+// commitments to the n quotients qᵢ such that
+//
+//	f(X) - f(z) = ∑ᵢ (Xᵢ - zᵢ) qᵢ(Xᵢ₊₁, …, Xₙ)
+//
+// and is checked with a single pairing product. Several openings, possibly at
+// different points, are verified together with one pairing check.
+//
+// Polynomials with m < n variables are committed as polynomials in the last m
+// variables, so that an SRS can be used for any number of variables up to n.
+package pst
@@ -0,0 +1,271 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/mpcsetup"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+// MpcSetup is the state of a multiparty ceremony for the SRS. Every contribution
+// updates all the secrets τ₁, …, τₙ.
+//
+// The ceremony works in the monomial basis [∏_{i∈S} τᵢ]₁, S ⊆ {1, …, n}, on
+// which a contribution acts multiplicatively; Seal converts it to the Lagrange basis.
+type MpcSetup struct {
+	monomials []curve.G1Affine // [∏_{i∈S} τᵢ]₁, where S is the set of ones in the index, X₁ being the most significant bit
+	tau       []curve.G2Affine // [τᵢ]₂
+	proofs    []mpcsetup.UpdateProof
+	challenge []byte
+}
+
+func InitializeSetup(nbVars int) MpcSetup {
+	var res MpcSetup
+	_, _, g1, g2 := curve.Generators()
+
+	res.monomials = make([]curve.G1Affine, 1<<nbVars)
+	for i := range res.monomials {
+		res.monomials[i] = g1
+	}
+	res.tau = make([]curve.G2Affine, nbVars)
+	for i := range res.tau {
+		res.tau[i] = g2
+	}
+	res.proofs = make([]mpcsetup.UpdateProof, nbVars)
+	res.challenge = make([]byte, sha256.Size) // so that the initial state can be serialized
+
+	return res
+}
+
+// WriteTo implements io.WriterTo
+func (s *MpcSetup) WriteTo(w io.Writer) (int64, error) {
+	if err := binary.Write(w, binary.BigEndian, uint64(len(s.tau))); err != nil {
+		return -1, err // binary.Write doesn't return the number written in case of failure
+	}
+	n := int64(8)
+	for i := range s.proofs {
+		m, err := s.proofs[i].WriteTo(w)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	enc := curve.NewEncoder(w)
+	for i := range len(s.monomials) - 1 {
+		if err := enc.Encode(&s.monomials[i+1]); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+	for i := range s.tau {
+		if err := enc.Encode(&s.tau[i]); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+	err := enc.Encode(s.challenge)
+	return n + enc.BytesWritten(), err
+}
+
+// ReadFrom implements io.ReaderFrom
+func (s *MpcSetup) ReadFrom(r io.Reader) (int64, error) {
+	var nbVars uint64
+	if err := binary.Read(r, binary.BigEndian, &nbVars); err != nil {
+		return -1, err
+	}
+	if nbVars >= 64 {
+		return 8, errors.New("number of variables too large")
+	}
+	n := int64(8)
+	s.proofs = make([]mpcsetup.UpdateProof, nbVars)
+	for i := range s.proofs {
+		m, err := s.proofs[i].ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	_, _, g1, _ := curve.Generators()
+	dec := curve.NewDecoder(r)
+	s.monomials = make([]curve.G1Affine, 1<<nbVars)
+	s.monomials[0] = g1
+	for i := range len(s.monomials) - 1 {
+		if err := dec.Decode(&s.monomials[i+1]); err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+	s.tau = make([]curve.G2Affine, nbVars)
+	for i := range s.tau {
+		if err := dec.Decode(&s.tau[i]); err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+	if len(s.challenge) != 32 {
+		s.challenge = make([]byte, 32)
+	}
+	err := dec.Decode(&s.challenge)
+	return n + dec.BytesRead(), err
+}
+
+func (s *MpcSetup) hash() []byte {
+	hsh := sha256.New()
+	if _, err := s.WriteTo(hsh); err != nil {
+		panic(err)
+	}
+	return hsh.Sum(nil)
+}
+
+// Contribute updates all the secrets with fresh randomness, and proves
+// knowledge of the contributions.
+func (s *MpcSetup) Contribute() {
+	s.challenge = s.hash()
+	contributions := make([]fr.Element, len(s.tau))
+
+	for i := range s.tau {
+		s.proofs[i] = mpcsetup.UpdateValues(&contributions[i], append([]byte("PST Setup"), s.challenge...), byte(i), &s.tau[i])
+	}
+	updateMonomials(s.monomials, contributions)
+}
+
+// Verify checks that next is a valid contribution on top of s.
+func (s *MpcSetup) Verify(next *MpcSetup) error {
+	challenge := s.hash()
+	if len(next.challenge) != 0 && !bytes.Equal(next.challenge, challenge) {
+		return errors.New("the challenge does not match the previous contribution's hash")
+	}
+	next.challenge = challenge
+
+	if len(s.tau) != len(next.tau) || len(s.monomials) != len(next.monomials) || len(next.proofs) != len(next.tau) {
+		return errors.New("different numbers of variables")
+	}
+
+	_, _, g1, g2 := curve.Generators()
+	if !next.monomials[0].Equal(&g1) {
+		return errors.New("[1]₁ representation changed")
+	}
+
+	for i := range next.tau {
+		if !next.tau[i].IsInSubGroup() {
+			return fmt.Errorf("[τ%d]₂ representation not in subgroup", i+1)
+		}
+	}
+
+	if !curve.IsInSubGroupBatchG1(next.monomials) {
+		return errors.New("some [∏τᵢ]₁ representation not in subgroup")
+	}
+
+	for i := range next.tau {
+		if err := next.proofs[i].Verify(append([]byte("PST Setup"), challenge...), byte(i), mpcsetup.ValueUpdate{
+			Previous: s.tau[i],
+			Next:     next.tau[i],
+		}); err != nil {
+			return fmt.Errorf("contribution to τ%d: %w", i+1, err)
+		}
+	}
+
+	// for each variable Xᵢ, check that [∏_{j∈S∪{i}} τⱼ]₁ = τᵢ[∏_{j∈S} τⱼ]₁ for all S ∌ i,
+	// on a random linear combination.
+	mid := len(next.monomials) / 2
+	without := make([]curve.G1Affine, mid)
+	with := make([]curve.G1Affine, mid)
+	r := make(fr.Vector, mid)
+	for i := range next.tau {
+		bit := len(next.monomials) >> (i + 1)
+		k := 0
+		for j := range next.monomials {
+			if j&bit == 0 {
+				without[k] = next.monomials[j]
+				with[k] = next.monomials[j|bit]
+				k++
+			}
+		}
+
+		r.MustSetRandom()
+		var a, b curve.G1Affine
+		if _, err := a.MultiExp(without, r, ecc.MultiExpConfig{}); err != nil {
+			return err
+		}
+		if _, err := b.MultiExp(with, r, ecc.MultiExpConfig{}); err != nil {
+			return err
+		}
+		if err := mpcsetup.SameRatioMany([]curve.G1Affine{a, b}, []curve.G2Affine{g2, next.tau[i]}); err != nil {
+			return fmt.Errorf("[∏τᵢ]₁ inconsistent with [τ%d]₂: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Seal applies a final contribution derived from the beacon challenge, and
+// returns the resulting SRS.
+func (s *MpcSetup) Seal(beaconChallenge []byte) SRS {
+	contributions := mpcsetup.BeaconContributions(s.hash(), []byte("PST Setup"), beaconChallenge, len(s.tau))
+	var I big.Int
+	for i := range s.tau {
+		contributions[i].BigInt(&I)
+		s.tau[i].ScalarMultiplication(&s.tau[i], &I)
+	}
+	updateMonomials(s.monomials, contributions)
+
+	// change of basis: for each variable, (1, τᵢ) ↦ (1-τᵢ, τᵢ)
+	lagrange := make([]curve.G1Jac, len(s.monomials))
+	for j := range lagrange {
+		lagrange[j].FromAffine(&s.monomials[j])
+	}
+	for i := range s.tau {
+		bit := len(lagrange) >> (i + 1)
+		parallel.Execute(len(lagrange), func(start, end int) {
+			for j := start; j < end; j++ {
+				if j&bit == 0 {
+					lagrange[j].SubAssign(&lagrange[j|bit])
+				}
+			}
+		})
+	}
+
+	_, _, g1, g2 := curve.Generators()
+	var srs SRS
+	srs.Pk.Lagrange = lagrangeLevels(curve.BatchJacobianToAffineG1(lagrange))
+	srs.Vk.G1 = g1
+	srs.Vk.G2 = g2
+	srs.Vk.Tau = make([]curve.G2Affine, len(s.tau))
+	copy(srs.Vk.Tau, s.tau)
+
+	return srs
+}
+
+// updateMonomials multiplies A[j] by ∏ᵢ xᵢ, over the variables Xᵢ set in j.
+func updateMonomials(A []curve.G1Affine, x []fr.Element) {
+	scalars := make([]fr.Element, len(A))
+	for j := range scalars {
+		scalars[j].SetOne()
+	}
+	for i := range x {
+		bit := len(A) >> (i + 1)
+		for j := range scalars {
+			if j&bit != 0 {
+				scalars[j].Mul(&scalars[j], &x[i])
+			}
+		}
+	}
+
+	parallel.Execute(len(A), func(start, end int) {
+		var I big.Int
+		for j := start; j < end; j++ {
+			scalars[j].BigInt(&I)
+			A[j].ScalarMultiplication(&A[j], &I)
+		}
+	})
+}
@@ -0,0 +1,308 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+var (
+	ErrInvalidNbDigests        = errors.New("number of digests is not the same as the number of proofs or points")
+	ErrZeroNbDigests           = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize   = errors.New("invalid polynomial size (not a power of two, or larger than SRS)")
+	ErrInvalidPointSize        = errors.New("number of coordinates of the point does not match the number of variables")
+	ErrVerifyOpeningProof      = errors.New("can't verify opening proof")
+	ErrMinSRSSize              = errors.New("minimum number of variables is 1")
+	ErrCommitmentNotInSubgroup = errors.New("commitment is not in the correct subgroup")
+	ErrQuotientNotInSubgroup   = errors.New("proof quotient is not in the correct subgroup")
+)
+
+// Digest commitment of a multilinear polynomial.
+type Digest = curve.G1Affine
+
+// ProvingKey used to create or open commitments
+type ProvingKey struct {
+	// Lagrange[k] contains the commitments [eq(τₖ₊₁, …, τₙ; b)]₁ to the Lagrange
+	// polynomials in the last n-k variables, for all b ∈ {0,1}ⁿ⁻ᵏ, in the same
+	// order as polynomial.MultiLin. Lagrange[0] is used to commit to polynomials
+	// in n variables, the next ones to commit to smaller polynomials and to the
+	// quotients of the openings.
+	Lagrange [][]curve.G1Affine
+}
+
+// VerifyingKey used to verify opening proofs
+type VerifyingKey struct {
+	G1  curve.G1Affine
+	G2  curve.G2Affine
+	Tau []curve.G2Affine // [τ₁]₂, …, [τₙ]₂
+}
+
+// SRS must be computed through MPC and comprises the ProvingKey and the VerifyingKey
+type SRS struct {
+	Pk ProvingKey
+	Vk VerifyingKey
+}
+
+// OpeningProof proves that a committed multilinear polynomial f evaluates to
+// ClaimedValue at a point z.
+type OpeningProof struct {
+	// Quotients are the commitments [qᵢ(τ)]₁ such that
+	// f(X) - f(z) = ∑ᵢ (Xᵢ - zᵢ) qᵢ(Xᵢ₊₁, …, Xₙ)
+	Quotients []curve.G1Affine
+
+	// ClaimedValue purported value f(z)
+	ClaimedValue fr.Element
+}
+
+// NewSRS returns a new SRS for multilinear polynomials in nbVars variables,
+// using τᵢ = τⁱ as secrets.
+//
+// In production, a SRS generated through MPC should be used.
+//
+// implements io.ReaderFrom and io.WriterTo
+func NewSRS(nbVars int, bTau *big.Int) (*SRS, error) {
+	if nbVars < 1 {
+		return nil, ErrMinSRSSize
+	}
+
+	tau := make([]fr.Element, nbVars)
+	tau[0].SetBigInt(bTau)
+	for i := 1; i < nbVars; i++ {
+		tau[i].Mul(&tau[i-1], &tau[0])
+	}
+
+	// eq(τ; b) for all b ∈ {0,1}ⁿ
+	eq := make(polynomial.MultiLin, 1<<nbVars)
+	eq[0].SetOne()
+	eq.Eq(tau)
+
+	_, _, g1, g2 := curve.Generators()
+
+	var srs SRS
+	srs.Pk.Lagrange = lagrangeLevels(curve.BatchScalarMultiplicationG1(&g1, eq))
+	srs.Vk.G1 = g1
+	srs.Vk.G2 = g2
+	srs.Vk.Tau = curve.BatchScalarMultiplicationG2(&g2, tau)
+
+	return &srs, nil
+}
+
+// lagrangeLevels returns the Lagrange tables of the ProvingKey from the first one.
+// Since eq(τ₁; 0) + eq(τ₁; 1) = 1, the table in the last n-k-1 variables is
+// obtained by adding the two halves of the table in the last n-k variables.
+func lagrangeLevels(lagrange []curve.G1Affine) [][]curve.G1Affine {
+	nbVars := bits.TrailingZeros(uint(len(lagrange)))
+	res := make([][]curve.G1Affine, nbVars+1)
+	res[0] = lagrange
+	for k := range nbVars {
+		mid := len(res[k]) / 2
+		sums := make([]curve.G1Jac, mid)
+		parallel.Execute(mid, func(start, end int) {
+			for j := start; j < end; j++ {
+				sums[j].FromAffine(&res[k][j])
+				sums[j].AddMixed(&res[k][mid+j])
+			}
+		})
+		res[k+1] = curve.BatchJacobianToAffineG1(sums)
+	}
+	return res
+}
+
+// NbVars returns the maximum number of variables of a committed polynomial.
+func (pk *ProvingKey) NbVars() int {
+	return len(pk.Lagrange) - 1
+}
+
+// offset returns the index of the Lagrange table used to commit to a polynomial
+// given by size evaluations.
+func (pk *ProvingKey) offset(size int) (int, error) {
+	if size == 0 || size&(size-1) != 0 || len(pk.Lagrange) == 0 || size > len(pk.Lagrange[0]) {
+		return 0, ErrInvalidPolynomialSize
+	}
+	return pk.NbVars() - bits.TrailingZeros(uint(size)), nil
+}
+
+// Commit commits to a multilinear polynomial given by its evaluations over the
+// hypercube, using a multi exponentiation with the SRS. A polynomial in m < n
+// variables is committed as a polynomial in the last m variables.
+func Commit(p polynomial.MultiLin, pk ProvingKey, nbTasks ...int) (Digest, error) {
+	offset, err := pk.offset(len(p))
+	if err != nil {
+		return Digest{}, err
+	}
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+
+	var res Digest
+	if _, err := res.MultiExp(pk.Lagrange[offset], p, config); err != nil {
+		return Digest{}, err
+	}
+	return res, nil
+}
+
+// Open computes an opening proof of p at the given point.
+// The number of coordinates of point must match the number of variables of p.
+func Open(p polynomial.MultiLin, point []fr.Element, pk ProvingKey) (OpeningProof, error) {
+	offset, err := pk.offset(len(p))
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	if len(point) != p.NumVars() {
+		return OpeningProof{}, ErrInvalidPointSize
+	}
+
+	// fix the variables one at a time; with f = f(0, X₂, …) + X₁(f(1, X₂, …) - f(0, X₂, …)),
+	// the quotient is q₁ = f(1, X₂, …) - f(0, X₂, …) and the remainder f(z₁, X₂, …).
+	table := p.Clone()
+	quotients := make([]polynomial.MultiLin, len(point))
+	for i := range point {
+		mid := len(table) / 2
+		q := make(polynomial.MultiLin, mid)
+		parallel.Execute(mid, func(start, end int) {
+			var t fr.Element
+			for j := start; j < end; j++ {
+				q[j].Sub(&table[mid+j], &table[j])
+				t.Mul(&q[j], &point[i])
+				table[j].Add(&table[j], &t)
+			}
+		})
+		table = table[:mid]
+		quotients[i] = q
+	}
+
+	res := OpeningProof{
+		Quotients:    make([]curve.G1Affine, len(point)),
+		ClaimedValue: table[0],
+	}
+	config := ecc.MultiExpConfig{}
+	for i := range quotients {
+		if _, err := res.Quotients[i].MultiExp(pk.Lagrange[offset+i+1], quotients[i], config); err != nil {
+			return OpeningProof{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// Verify verifies a PST opening proof at a single point
+func Verify(commitment *Digest, proof *OpeningProof, point []fr.Element, vk VerifyingKey) error {
+	return BatchVerify([]Digest{*commitment}, []OpeningProof{*proof}, [][]fr.Element{point}, vk)
+}
+
+// BatchVerify verifies a list of opening proofs, possibly at different points
+// and for polynomials in different numbers of variables, with a single pairing check.
+//
+// * digests list of committed polynomials
+// * proofs list of opening proofs, one for each digest
+// * points the list of points at which the openings are done
+func BatchVerify(digests []Digest, proofs []OpeningProof, points [][]fr.Element, vk VerifyingKey) error {
+
+	// check consistency nb proofs vs nb digests
+	if len(digests) != len(proofs) || len(digests) != len(points) {
+		return ErrInvalidNbDigests
+	}
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+
+	nbQuotients := 0
+	for i := range proofs {
+		if len(proofs[i].Quotients) != len(points[i]) || len(points[i]) > len(vk.Tau) {
+			return ErrInvalidPointSize
+		}
+		if !digests[i].IsInSubGroup() {
+			return ErrCommitmentNotInSubgroup
+		}
+		for j := range proofs[i].Quotients {
+			if !proofs[i].Quotients[j].IsInSubGroup() {
+				return ErrQuotientNotInSubgroup
+			}
+		}
+		nbQuotients += len(points[i])
+	}
+
+	// sample random numbers λⱼ for folding, λ₀ = 1
+	randomNumbers := make([]fr.Element, len(digests))
+	randomNumbers[0].SetOne()
+	for j := 1; j < len(randomNumbers); j++ {
+		if _, err := randomNumbers[j].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	// e([f(τ) - f(z)]₁, G₂) = ∏ᵢ e([qᵢ(τ)]₁, [τᵢ - zᵢ]₂) is rewritten as
+	// e([f(τ) - f(z)]₁ + ∑ᵢ zᵢ[qᵢ(τ)]₁, G₂) ∏ᵢ e(-[qᵢ(τ)]₁, [τᵢ]₂) = 1,
+	// and the equations of all the proofs are folded using the λⱼ:
+	// left ≔ ∑ⱼ λⱼ ([fⱼ(τ)]₁ - fⱼ(zⱼ)G₁ + ∑ᵢ zⱼᵢ[qⱼᵢ(τ)]₁)
+	// folded[k] ≔ ∑ⱼ λⱼ[qⱼₖ(τ)]₁ where the quotients are indexed by the variable of the SRS
+	bases := make([]curve.G1Affine, 0, len(digests)+nbQuotients+1)
+	scalars := make([]fr.Element, 0, cap(bases))
+	folded := make([]curve.G1Jac, len(vk.Tau))
+	used := make([]bool, len(vk.Tau))
+	var foldedEval, tmp fr.Element
+	var tmpBig big.Int
+	var tmpJac curve.G1Jac
+	for j := range digests {
+		bases = append(bases, digests[j])
+		scalars = append(scalars, randomNumbers[j])
+		tmp.Mul(&randomNumbers[j], &proofs[j].ClaimedValue)
+		foldedEval.Add(&foldedEval, &tmp)
+
+		offset := len(vk.Tau) - len(points[j])
+		randomNumbers[j].BigInt(&tmpBig)
+		for i := range points[j] {
+			bases = append(bases, proofs[j].Quotients[i])
+			tmp.Mul(&randomNumbers[j], &points[j][i])
+			scalars = append(scalars, tmp)
+
+			tmpJac.FromAffine(&proofs[j].Quotients[i])
+			tmpJac.ScalarMultiplication(&tmpJac, &tmpBig)
+			folded[offset+i].AddAssign(&tmpJac)
+			used[offset+i] = true
+		}
+	}
+	foldedEval.Neg(&foldedEval)
+	bases = append(bases, vk.G1)
+	scalars = append(scalars, foldedEval)
+
+	var left curve.G1Affine
+	if _, err := left.MultiExp(bases, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+
+	P := []curve.G1Affine{left}
+	Q := []curve.G2Affine{vk.G2}
+	for k := range folded {
+		if !used[k] {
+			continue
+		}
+		folded[k].Neg(&folded[k])
+		var p curve.G1Affine
+		p.FromJacobian(&folded[k])
+		P = append(P, p)
+		Q = append(Q, vk.Tau[k])
+	}
+
+	check, err := curve.PairingCheck(P, Q)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
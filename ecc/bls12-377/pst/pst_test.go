@@ -0,0 +1,273 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/stretchr/testify/require"
+)
+
+// Test SRS re-used across tests of the PST scheme
+var (
+	testSrs *SRS
+	bTau    *big.Int
+)
+
+const testNbVars = 6
+
+func init() {
+	bTau = new(big.Int).SetInt64(42)
+	testSrs, _ = NewSRS(testNbVars, bTau)
+}
+
+func randomMultiLin(nbVars int) polynomial.MultiLin {
+	p := make(polynomial.MultiLin, 1<<nbVars)
+	fr.Vector(p).MustSetRandom()
+	return p
+}
+
+func randomPoint(nbVars int) []fr.Element {
+	point := make([]fr.Element, nbVars)
+	fr.Vector(point).MustSetRandom()
+	return point
+}
+
+func TestCommitMatchesEvaluation(t *testing.T) {
+	t.Parallel()
+
+	var tau fr.Element
+	tau.SetBigInt(bTau)
+	taus := make([]fr.Element, testNbVars)
+	taus[0] = tau
+	for i := 1; i < testNbVars; i++ {
+		taus[i].Mul(&taus[i-1], &tau)
+	}
+
+	_, _, g1, _ := curve.Generators()
+	for nbVars := 0; nbVars <= testNbVars; nbVars++ {
+		p := randomMultiLin(nbVars)
+		digest, err := Commit(p, testSrs.Pk)
+		require.NoError(t, err)
+
+		// a polynomial in m variables is committed in the last m variables
+		v := p.Evaluate(taus[testNbVars-nbVars:], nil)
+		var vBig big.Int
+		v.BigInt(&vBig)
+		var expected curve.G1Affine
+		expected.ScalarMultiplication(&g1, &vBig)
+		require.True(t, expected.Equal(&digest), "nbVars=%d", nbVars)
+	}
+}
+
+func TestOpenVerify(t *testing.T) {
+	t.Parallel()
+
+	for nbVars := 0; nbVars <= testNbVars; nbVars++ {
+		p := randomMultiLin(nbVars)
+		point := randomPoint(nbVars)
+
+		digest, err := Commit(p, testSrs.Pk)
+		require.NoError(t, err)
+		proof, err := Open(p, point, testSrs.Pk)
+		require.NoError(t, err)
+
+		expected := p.Evaluate(point, nil)
+		require.True(t, expected.Equal(&proof.ClaimedValue))
+		require.Len(t, proof.Quotients, nbVars)
+		require.NoError(t, Verify(&digest, &proof, point, testSrs.Vk), "nbVars=%d", nbVars)
+
+		// wrong value
+		wrongProof := proof
+		wrongProof.ClaimedValue.SetOne()
+		require.ErrorIs(t, Verify(&digest, &wrongProof, point, testSrs.Vk), ErrVerifyOpeningProof)
+
+		if nbVars == 0 {
+			continue
+		}
+
+		// wrong point
+		wrongPoint := randomPoint(nbVars)
+		require.ErrorIs(t, Verify(&digest, &proof, wrongPoint, testSrs.Vk), ErrVerifyOpeningProof)
+
+		// tampered quotient
+		wrongProof = proof
+		wrongProof.Quotients = make([]curve.G1Affine, nbVars)
+		copy(wrongProof.Quotients, proof.Quotients)
+		wrongProof.Quotients[nbVars-1].Add(&wrongProof.Quotients[nbVars-1], &testSrs.Vk.G1)
+		require.ErrorIs(t, Verify(&digest, &wrongProof, point, testSrs.Vk), ErrVerifyOpeningProof)
+
+		// wrong number of coordinates
+		require.ErrorIs(t, Verify(&digest, &proof, point[1:], testSrs.Vk), ErrInvalidPointSize)
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	t.Parallel()
+
+	nbVars := []int{testNbVars, 3, testNbVars, 1}
+	digests := make([]Digest, len(nbVars))
+	proofs := make([]OpeningProof, len(nbVars))
+	points := make([][]fr.Element, len(nbVars))
+	for i := range nbVars {
+		p := randomMultiLin(nbVars[i])
+		points[i] = randomPoint(nbVars[i])
+		var err error
+		digests[i], err = Commit(p, testSrs.Pk)
+		require.NoError(t, err)
+		proofs[i], err = Open(p, points[i], testSrs.Pk)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, BatchVerify(digests, proofs, points, testSrs.Vk))
+
+	// one wrong claimed value
+	proofs[2].ClaimedValue.SetOne()
+	require.ErrorIs(t, BatchVerify(digests, proofs, points, testSrs.Vk), ErrVerifyOpeningProof)
+
+	require.ErrorIs(t, BatchVerify(digests[1:], proofs, points, testSrs.Vk), ErrInvalidNbDigests)
+	require.ErrorIs(t, BatchVerify(nil, nil, nil, testSrs.Vk), ErrZeroNbDigests)
+}
+
+func TestInvalidSizes(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSRS(0, bTau)
+	require.ErrorIs(t, err, ErrMinSRSSize)
+
+	_, err = Commit(make(polynomial.MultiLin, 3), testSrs.Pk)
+	require.ErrorIs(t, err, ErrInvalidPolynomialSize)
+
+	_, err = Commit(randomMultiLin(testNbVars+1), testSrs.Pk)
+	require.ErrorIs(t, err, ErrInvalidPolynomialSize)
+
+	_, err = Open(randomMultiLin(3), randomPoint(2), testSrs.Pk)
+	require.ErrorIs(t, err, ErrInvalidPointSize)
+}
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	n, err := testSrs.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	var srs SRS
+	m, err := srs.ReadFrom(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, n, m)
+	require.Equal(t, testSrs, &srs)
+
+	buf.Reset()
+	_, err = testSrs.WriteRawTo(&buf)
+	require.NoError(t, err)
+	srs = SRS{}
+	_, err = srs.UnsafeReadFrom(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, testSrs, &srs)
+
+	p := randomMultiLin(4)
+	proof, err := Open(p, randomPoint(4), testSrs.Pk)
+	require.NoError(t, err)
+	buf.Reset()
+	n, err = proof.WriteTo(&buf)
+	require.NoError(t, err)
+	var decoded OpeningProof
+	m, err = decoded.ReadFrom(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, n, m)
+	require.Equal(t, proof, decoded)
+}
+
+func TestMpcSetup(t *testing.T) {
+	t.Parallel()
+
+	const nbVars, nbContributions = 4, 3
+	phases := make([]MpcSetup, nbContributions+1)
+	phases[0] = InitializeSetup(nbVars)
+
+	var buf bytes.Buffer
+	for i := 1; i < len(phases); i++ {
+		// each participant reads the previous state
+		buf.Reset()
+		_, err := phases[i-1].WriteTo(&buf)
+		require.NoError(t, err)
+		_, err = phases[i].ReadFrom(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		phases[i].Contribute()
+	}
+
+	for i := 1; i < len(phases); i++ {
+		require.NoError(t, phases[i-1].Verify(&phases[i]))
+	}
+
+	srs := phases[nbContributions].Seal([]byte("beacon"))
+	for _, n := range []int{nbVars, 2} {
+		p := randomMultiLin(n)
+		point := randomPoint(n)
+		digest, err := Commit(p, srs.Pk)
+		require.NoError(t, err)
+		proof, err := Open(p, point, srs.Pk)
+		require.NoError(t, err)
+		require.NoError(t, Verify(&digest, &proof, point, srs.Vk))
+	}
+
+	// a contribution inconsistent with the claimed [τᵢ]₂
+	buf.Reset()
+	_, err := phases[nbContributions].WriteTo(&buf)
+	require.NoError(t, err)
+	var next MpcSetup
+	_, err = next.ReadFrom(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	next.Contribute()
+	next.monomials[3] = next.monomials[2]
+	require.Error(t, phases[nbContributions].Verify(&next))
+}
+
+func BenchmarkCommit(b *testing.B) {
+	const nbVars = 16
+	srs, err := NewSRS(nbVars, bTau)
+	require.NoError(b, err)
+	p := randomMultiLin(nbVars)
+
+	b.ResetTimer()
+	for range b.N {
+		_, _ = Commit(p, srs.Pk)
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	const nbVars = 16
+	srs, err := NewSRS(nbVars, bTau)
+	require.NoError(b, err)
+	p := randomMultiLin(nbVars)
+	point := randomPoint(nbVars)
+
+	b.ResetTimer()
+	for range b.N {
+		_, _ = Open(p, point, srs.Pk)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	p := randomMultiLin(testNbVars)
+	point := randomPoint(testNbVars)
+	digest, err := Commit(p, testSrs.Pk)
+	require.NoError(b, err)
+	proof, err := Open(p, point, testSrs.Pk)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for range b.N {
+		_ = Verify(&digest, &proof, point, testSrs.Vk)
+	}
+}
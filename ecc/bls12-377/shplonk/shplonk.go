@@ -14,7 +14,6 @@ import (
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
-	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 )
 
 var (
@@ -43,6 +42,16 @@ type OpeningProof struct {
 
 // BatchOpen opens the list of polynomials on points, where the i-th polynomials is opend at points[i].
 func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.Element, hf hash.Hash, pk kzg.ProvingKey, dataTranscript ...[]byte) (OpeningProof, error) {
+	t := newHashTranscript(hf, []string{"gamma", "z"}, dataTranscript...)
+	res, err := BatchOpenWithTranscript(polynomials, digests, points, t, pk)
+	if t.err != nil {
+		return OpeningProof{}, t.err
+	}
+	return res, err
+}
+
+// BatchOpenWithTranscript is BatchOpen, where the challenges are squeezed from the transcript t.
+func BatchOpenWithTranscript(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.Element, t kzg.Transcript, pk kzg.ProvingKey) (OpeningProof, error) {
 
 	var res OpeningProof
 
@@ -54,14 +63,8 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 		return res, ErrInvalidNumberOfDigests
 	}
 
-	// transcript
-	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
-
 	// derive γ
-	gamma, err := deriveChallenge("gamma", points, digests, fs, dataTranscript...)
-	if err != nil {
-		return res, err
-	}
+	gamma := deriveGamma(points, digests, t)
 
 	// compute the size of the linear combination
 	maxSizePolys := len(polynomials[0])
@@ -119,16 +122,14 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 
 	zt := buildVanishingPoly(flatten(points))
 	w := div(f, zt) // cf https://eprint.iacr.org/2020/081.pdf page 11 for notation page 11 for notation
+	var err error
 	res.W, err = kzg.Commit(w, pk)
 	if err != nil {
 		return res, err
 	}
 
 	// derive z
-	z, err := deriveChallenge("z", nil, []kzg.Digest{res.W}, fs)
-	if err != nil {
-		return res, err
-	}
+	z := deriveZ(res.W, t)
 
 	// compute L = ∑ᵢγⁱZ_{T\Sᵢ}(z)(fᵢ-rᵢ(z))-Z_{T}(z)W
 	accGamma.SetOne()
@@ -178,6 +179,16 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 // dataTranscript is some extra data that might be needed for Fiat Shamir, and is appended at the end
 // of the original transcript.
 func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element, hf hash.Hash, vk kzg.VerifyingKey, dataTranscript ...[]byte) error {
+	t := newHashTranscript(hf, []string{"gamma", "z"}, dataTranscript...)
+	err := BatchVerifyWithTranscript(proof, digests, points, t, vk)
+	if t.err != nil {
+		return t.err
+	}
+	return err
+}
+
+// BatchVerifyWithTranscript is BatchVerify, where the challenges are squeezed from the transcript t.
+func BatchVerifyWithTranscript(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element, t kzg.Transcript, vk kzg.VerifyingKey) error {
 
 	if len(digests) != len(proof.ClaimedValues) {
 		return ErrInvalidNumberOfPoints
@@ -193,21 +204,12 @@ func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element
 		return ErrNotInSubgroup
 	}
 
-	// transcript
-	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
-
 	// derive γ
-	gamma, err := deriveChallenge("gamma", points, digests, fs, dataTranscript...)
-	if err != nil {
-		return err
-	}
+	gamma := deriveGamma(points, digests, t)
 
 	// derive z
 	// TODO seems ok that z depend only on W, need to check that carefully
-	z, err := deriveChallenge("z", nil, []kzg.Digest{proof.W}, fs)
-	if err != nil {
-		return err
-	}
+	z := deriveZ(proof.W, t)
 
 	// check that e(F + zW', [1]_{2})=e(W',[x]_{2})
 	// where F = ∑ᵢγⁱZ_{T\xᵢ}[Com]_{i}-[∑ᵢγⁱZ_{T\xᵢ}(z)fᵢ(z)]_{1}-Z_{T}(z)[W]
@@ -233,7 +235,7 @@ func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element
 	// ∑ᵢγⁱZ_{T\xᵢ}[Com]_{i}
 	config := ecc.MultiExpConfig{}
 	var sumGammaiZtMinusSiComi kzg.Digest
-	_, err = sumGammaiZtMinusSiComi.MultiExp(digests, gammaiZTminusSiz, config)
+	_, err := sumGammaiZtMinusSiComi.MultiExp(digests, gammaiZTminusSiz, config)
 	if err != nil {
 		return err
 	}
@@ -281,38 +283,19 @@ func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element
 	return nil
 }
 
-// deriveChallenge derives a challenge using Fiat Shamir to polynomials.
-// The arguments are added to the transcript in the order in which they are given.
-func deriveChallenge(name string, points [][]fr.Element, digests []kzg.Digest, t *fiatshamir.Transcript, dataTranscript ...[]byte) (fr.Element, error) {
-
-	// derive the challenge gamma, binded to the point and the commitments
+// deriveGamma derives the challenge γ, binded to the points and the commitments.
+func deriveGamma(points [][]fr.Element, digests []kzg.Digest, t kzg.Transcript) fr.Element {
 	for i := range points {
-		for j := range points[i] {
-			if err := t.Bind(name, points[i][j].Marshal()); err != nil {
-				return fr.Element{}, err
-			}
-		}
-	}
-	for i := range digests {
-		if err := t.Bind(name, digests[i].Marshal()); err != nil {
-			return fr.Element{}, err
-		}
+		t.AbsorbElements("gamma", points[i]...)
 	}
+	t.AbsorbG1("gamma", digests...)
+	return t.SqueezeElement("gamma")
+}
 
-	for i := range len(dataTranscript) {
-		if err := t.Bind(name, dataTranscript[i]); err != nil {
-			return fr.Element{}, err
-		}
-	}
-
-	challengeByte, err := t.ComputeChallenge(name)
-	if err != nil {
-		return fr.Element{}, err
-	}
-	var challenge fr.Element
-	challenge.SetBytes(challengeByte)
-
-	return challenge, nil
+// deriveZ derives the challenge z, binded to W.
+func deriveZ(w kzg.Digest, t kzg.Transcript) fr.Element {
+	t.AbsorbG1("z", w)
+	return t.SqueezeElement("z")
 }
 
 // ------------------------------
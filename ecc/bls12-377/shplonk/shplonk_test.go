@@ -15,6 +15,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/poseidon2"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
 	"github.com/consensys/gnark-crypto/utils/testutils"
 	"github.com/stretchr/testify/require"
@@ -105,6 +106,39 @@ func TestOpening(t *testing.T) {
 
 }
 
+func TestOpeningWithTranscript(t *testing.T) {
+
+	assert := require.New(t)
+
+	nbPolys := 3
+	polys := make([][]fr.Element, nbPolys)
+	digests := make([]kzg.Digest, nbPolys)
+	points := make([][]fr.Element, nbPolys)
+	for i := range nbPolys {
+		polys[i] = make([]fr.Element, 5+i)
+		fr.Vector(polys[i]).MustSetRandom()
+		digests[i], _ = kzg.Commit(polys[i], testSrs.Pk)
+		points[i] = make([]fr.Element, i+1)
+		fr.Vector(points[i]).MustSetRandom()
+	}
+
+	// correct proof
+	openingProof, err := BatchOpenWithTranscript(polys, digests, points, poseidon2.NewTranscript("test"), testSrs.Pk)
+	assert.NoError(err)
+	err = BatchVerifyWithTranscript(openingProof, digests, points, poseidon2.NewTranscript("test"), testSrs.Vk)
+	assert.NoError(err)
+
+	// other transcript
+	err = BatchVerifyWithTranscript(openingProof, digests, points, poseidon2.NewTranscript("other"), testSrs.Vk)
+	assert.Error(err)
+
+	// tampered proof
+	openingProof.ClaimedValues[0][0].MustSetRandom()
+	err = BatchVerifyWithTranscript(openingProof, digests, points, poseidon2.NewTranscript("test"), testSrs.Vk)
+	assert.Error(err)
+
+}
+
 func TestBuildZtMinusSi(t *testing.T) {
 
 	nbSi := 10
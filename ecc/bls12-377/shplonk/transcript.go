@@ -0,0 +1,62 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package shplonk
+
+import (
+	"hash"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// hashTranscript implements kzg.Transcript with a fiatshamir.Transcript, where the
+// labels are the names of the challenges. The extra data is bound to the first
+// challenge, after the absorbed values. The first error is kept in err.
+type hashTranscript struct {
+	fs   *fiatshamir.Transcript
+	data [][]byte
+	err  error
+}
+
+func newHashTranscript(hf hash.Hash, challenges []string, data ...[]byte) *hashTranscript {
+	return &hashTranscript{fs: fiatshamir.NewTranscript(hf, challenges...), data: data}
+}
+
+func (t *hashTranscript) AbsorbElements(label string, x ...fr.Element) {
+	for i := range x {
+		t.bind(label, x[i].Marshal())
+	}
+}
+
+func (t *hashTranscript) AbsorbG1(label string, points ...bls12377.G1Affine) {
+	for i := range points {
+		t.bind(label, points[i].Marshal())
+	}
+}
+
+func (t *hashTranscript) SqueezeElement(label string) fr.Element {
+	for i := range t.data {
+		t.bind(label, t.data[i])
+	}
+	t.data = nil
+	var res fr.Element
+	b, err := t.fs.ComputeChallenge(label)
+	if err != nil {
+		if t.err == nil {
+			t.err = err
+		}
+		return res
+	}
+	res.SetBytes(b)
+	return res
+}
+
+func (t *hashTranscript) bind(label string, b []byte) {
+	if err := t.fs.Bind(label, b); err != nil && t.err == nil {
+		t.err = err
+	}
+}
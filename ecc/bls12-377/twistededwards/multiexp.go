@@ -0,0 +1,170 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+// MultiExp computes ∑ᵢ [scalarsᵢ]pointsᵢ and sets p to the result in affine coordinates.
+//
+// See [PointExtended.MultiExp].
+func (p *PointAffine) MultiExp(points []PointAffine, scalars []big.Int, config ecc.MultiExpConfig) (*PointAffine, error) {
+	var _p PointExtended
+	if _, err := _p.MultiExp(points, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromExtended(&_p)
+	return p, nil
+}
+
+// MultiExp computes ∑ᵢ [scalarsᵢ]pointsᵢ with the bucket method, using signed
+// digits so that a window of c bits needs 2ᶜ⁻¹ buckets. Negative scalars are
+// supported. The windows, and the points when there are fewer windows than
+// tasks, are processed in parallel by up to config.NbTasks goroutines.
+//
+// This call return an error if len(scalars) != len(points) or if provided config is invalid.
+func (p *PointExtended) MultiExp(points []PointAffine, scalars []big.Int, config ecc.MultiExpConfig) (*PointExtended, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU()
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	nbBits := 0
+	for i := range scalars {
+		nbBits = max(nbBits, scalars[i].BitLen())
+	}
+	n := len(points)
+	if nbBits == 0 || n == 0 {
+		p.setInfinity()
+		return p, nil
+	}
+
+	c := bestC(n, nbBits)
+	nbChunks := nbBits/c + 1 // the last window has room for the carry
+	digits := partitionScalars(scalars, c, nbChunks, config.NbTasks)
+
+	// with few windows, we split the points so that all the tasks are busy
+	nbSplits := max(1, min(config.NbTasks/nbChunks, n>>c))
+	sums := make([]PointExtended, nbChunks*nbSplits)
+	parallel.Execute(len(sums), func(start, end int) {
+		buckets := make([]PointExtended, 1<<(c-1))
+		for job := start; job < end; job++ {
+			chunk, split := job/nbSplits, job%nbSplits
+			from, to := split*n/nbSplits, (split+1)*n/nbSplits
+			sums[job].msmWindow(buckets, points[from:to], digits[chunk*n+from:chunk*n+to])
+		}
+	}, config.NbTasks)
+
+	// ∑ⱼ 2ʲᶜ⋅sumⱼ
+	var res PointExtended
+	res.setInfinity()
+	for chunk := nbChunks - 1; chunk >= 0; chunk-- {
+		for range c {
+			res.Double(&res)
+		}
+		for split := range nbSplits {
+			res.Add(&res, &sums[chunk*nbSplits+split])
+		}
+	}
+	p.Set(&res)
+	return p, nil
+}
+
+// msmWindow sets p to ∑ᵢ digitsᵢ⋅pointsᵢ, where the digits are in [-2ᶜ⁻¹, 2ᶜ⁻¹]
+// and len(buckets) = 2ᶜ⁻¹.
+func (p *PointExtended) msmWindow(buckets []PointExtended, points []PointAffine, digits []int32) *PointExtended {
+	for i := range buckets {
+		buckets[i].setInfinity()
+	}
+	var neg PointAffine
+	for i, d := range digits {
+		if d > 0 {
+			buckets[d-1].MixedAdd(&buckets[d-1], &points[i])
+		} else if d < 0 {
+			neg.Neg(&points[i])
+			buckets[-d-1].MixedAdd(&buckets[-d-1], &neg)
+		}
+	}
+
+	// ∑ₖ k⋅bucketₖ₋₁ = ∑ₖ ∑_{j≥k} bucketⱼ₋₁
+	var runningSum PointExtended
+	runningSum.setInfinity()
+	p.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		runningSum.Add(&runningSum, &buckets[k])
+		p.Add(p, &runningSum)
+	}
+	return p
+}
+
+// partitionScalars decomposes the scalars in nbChunks signed c-bit digits in
+// [-2ᶜ⁻¹, 2ᶜ⁻¹], stored window by window: digits[j*len(scalars)+i] is the j-th
+// digit of scalars[i].
+func partitionScalars(scalars []big.Int, c, nbChunks, nbTasks int) []int32 {
+	n := len(scalars)
+	digits := make([]int32, n*nbChunks)
+	half := 1 << (c - 1)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			// Bits returns the absolute value of the scalar
+			words := scalars[i].Bits()
+			neg := scalars[i].Sign() < 0
+			carry := 0
+			for chunk := range nbChunks {
+				digit := carry + window(words, chunk*c, c)
+				carry = 0
+				// if the digit is larger than 2ᶜ⁻¹, we borrow 2ᶜ from the next window
+				if digit > half {
+					digit -= 1 << c
+					carry = 1
+				}
+				if neg {
+					digit = -digit
+				}
+				digits[chunk*n+i] = int32(digit)
+			}
+		}
+	}, nbTasks)
+	return digits
+}
+
+// window returns the c bits of words starting at bit index start.
+func window(words []big.Word, start, c int) int {
+	i, shift := start/bits.UintSize, start%bits.UintSize
+	if i >= len(words) {
+		return 0
+	}
+	w := uint(words[i]) >> shift
+	if shift+c > bits.UintSize && i+1 < len(words) {
+		w |= uint(words[i+1]) << (bits.UintSize - shift)
+	}
+	return int(w & (1<<c - 1))
+}
+
+// bestC returns the window size minimizing the cost of a multi-exponentiation
+// of nbPoints scalars of nbBits bits.
+func bestC(nbPoints, nbBits int) int {
+	C, min := 2, -1
+	for c := 2; c <= 16; c++ {
+		// a mixed addition per point and two additions per bucket, per window
+		cost := (nbBits/c + 1) * (nbPoints + (1 << c))
+		if min < 0 || cost < min {
+			C, min = c, cost
+		}
+	}
+	return C
+}
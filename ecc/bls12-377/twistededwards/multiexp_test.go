@@ -0,0 +1,104 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestMultiExp(t *testing.T) {
+	t.Parallel()
+
+	params := GetEdwardsCurve()
+
+	const nbSamples = 73
+	points := make([]PointAffine, nbSamples)
+	scalars := make([]big.Int, nbSamples)
+	var bound big.Int
+	bound.Lsh(&params.Order, 1)
+	points[0].Set(&params.Base)
+	for i := range points {
+		if i > 0 {
+			points[i].Add(&points[i-1], &params.Base)
+		}
+		s, err := rand.Int(rand.Reader, &bound)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scalars[i].Sub(s, &params.Order)
+	}
+	points[3].setInfinity()
+	scalars[4].SetInt64(0)
+	scalars[5].SetInt64(-1)
+	scalars[6].Set(&params.Order)
+
+	var expected, tmp PointAffine
+	expected.setInfinity()
+	for i := range points {
+		tmp.ScalarMultiplication(&points[i], &scalars[i])
+		expected.Add(&expected, &tmp)
+	}
+
+	for _, nbTasks := range []int{1, 3, 256} {
+		var res PointAffine
+		if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&expected) {
+			t.Fatalf("multi-exponentiation mismatch with %d tasks", nbTasks)
+		}
+	}
+
+	// small scalars, a single window
+	for i := range scalars {
+		scalars[i].SetInt64(int64(i%3) - 1)
+	}
+	expected.setInfinity()
+	for i := range points {
+		tmp.ScalarMultiplication(&points[i], &scalars[i])
+		expected.Add(&expected, &tmp)
+	}
+	var res PointExtended
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if tmp.FromExtended(&res); !tmp.Equal(&expected) {
+		t.Fatal("multi-exponentiation mismatch with small scalars")
+	}
+
+	// empty and invalid inputs
+	if _, err := res.MultiExp(nil, nil, ecc.MultiExpConfig{}); err != nil || !res.IsZero() {
+		t.Fatal("empty multi-exponentiation should be the identity")
+	}
+	if _, err := res.MultiExp(points[1:], scalars, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("expected an error on length mismatch")
+	}
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	params := GetEdwardsCurve()
+
+	const nbSamples = 1 << 12
+	points := make([]PointAffine, nbSamples)
+	scalars := make([]big.Int, nbSamples)
+	points[0].Set(&params.Base)
+	for i := range points {
+		if i > 0 {
+			points[i].Add(&points[i-1], &params.Base)
+		}
+		s, _ := rand.Int(rand.Reader, &params.Order)
+		scalars[i].Set(s)
+	}
+
+	var res PointExtended
+	for b.Loop() {
+		res.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"crypto/rand"
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+)
+
+var errLengthMismatch = errors.New("number of public keys, signatures and messages differ")
+
+// BatchVerify verifies the signatures sigs[i] of the messages msgs[i] under the
+// public keys pubs[i].
+//
+// Instead of checking each signature separately, it draws random 128-bit
+// coefficients zᵢ and checks with a single multi-scalar multiplication that
+//
+//	cofactor*((∑ zᵢ⋅Sᵢ)*Base - ∑ zᵢ*Rᵢ - ∑ (zᵢ⋅H(Rᵢ,Aᵢ,Mᵢ))*Aᵢ) = 0
+//
+// If the batch equation does not hold, the signatures are verified one by one
+// to find the invalid ones. It returns the indices of the invalid signatures in
+// increasing order, or nil if all of them are valid. Signatures that cannot be
+// deserialized and public keys that are not on the curve are reported as invalid.
+//
+// It returns an error if hFunc is nil, if the input slices do not have the same
+// length, or if sampling the random coefficients fails.
+func BatchVerify(pubs []PublicKey, sigs [][]byte, msgs [][]byte, hFunc hash.Hash) ([]int, error) {
+
+	// hFunc cannot be nil.
+	// We need a hash function for the Fiat-Shamir.
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	if len(pubs) != len(sigs) || len(pubs) != len(msgs) {
+		return nil, errLengthMismatch
+	}
+	if len(pubs) == 0 {
+		return nil, nil
+	}
+
+	curveParams := twistededwards.GetEdwardsCurve()
+	order := &curveParams.Order
+
+	// points = [Base, R₀, A₀, R₁, A₁, ...]
+	// scalars = [∑ zᵢ⋅Sᵢ, -z₀, -z₀⋅h₀, -z₁, -z₁⋅h₁, ...]
+	points := make([]twistededwards.PointAffine, 1, 2*len(pubs)+1)
+	scalars := make([]big.Int, 1, 2*len(pubs)+1)
+	points[0].Set(&curveParams.Base)
+
+	var failed []int
+	var sig Signature
+	var z, s, tmp big.Int
+	zBytes := make([]byte, 16)
+	for i := range pubs {
+		if !pubs[i].A.IsOnCurve() {
+			failed = append(failed, i)
+			continue
+		}
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			failed = append(failed, i)
+			continue
+		}
+		hramInt, err := hashRAM(&sig.R, &pubs[i].A, msgs[i], hFunc)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := rand.Read(zBytes); err != nil {
+			return nil, err
+		}
+		z.SetBytes(zBytes)
+
+		// ∑ zᵢ⋅Sᵢ
+		s.SetBytes(sig.S[:])
+		tmp.Mul(&z, &s)
+		scalars[0].Add(&scalars[0], &tmp)
+
+		var zR, zhA big.Int
+		zR.Sub(order, &z)
+		zhA.Mul(&z, hramInt).Mod(&zhA, order).Sub(order, &zhA)
+
+		points = append(points, sig.R, pubs[i].A)
+		scalars = append(scalars, zR, zhA)
+	}
+	if len(failed) != 0 {
+		// some inputs are malformed, no need to run the batch check
+		return verifyEach(pubs, sigs, msgs, hFunc, failed)
+	}
+	scalars[0].Mod(&scalars[0], order)
+
+	var res twistededwards.PointExtended
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+
+	var bCofactor big.Int
+	curveParams.Cofactor.BigInt(&bCofactor)
+	res.ScalarMultiplication(&res, &bCofactor)
+	if res.IsZero() {
+		return nil, nil
+	}
+	return verifyEach(pubs, sigs, msgs, hFunc, nil)
+}
+
+// verifyEach verifies each signature whose index is not in skip (assumed sorted)
+// and returns the sorted indices of the invalid signatures, skipped ones included.
+func verifyEach(pubs []PublicKey, sigs [][]byte, msgs [][]byte, hFunc hash.Hash, skip []int) ([]int, error) {
+	var failed []int
+	for i := range pubs {
+		if len(skip) != 0 && skip[0] == i {
+			failed = append(failed, i)
+			skip = skip[1:]
+			continue
+		}
+		ok, err := pubs[i].Verify(sigs[i], msgs[i], hFunc)
+		if err != nil || !ok {
+			failed = append(failed, i)
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func generateBatch(tb testing.TB, n int) ([]PublicKey, [][]byte, [][]byte) {
+	r := rand.New(rand.NewSource(0)) //#nosec G404 weak rng is fine here
+	hFunc := sha256.New()
+	pubs := make([]PublicKey, n)
+	sigs := make([][]byte, n)
+	msgs := make([][]byte, n)
+	for i := range n {
+		privKey, err := GenerateKey(r)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		pubs[i] = privKey.PublicKey
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		if sigs[i], err = privKey.Sign(msgs[i], hFunc); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return pubs, sigs, msgs
+}
+
+func TestBatchVerify(t *testing.T) {
+	const n = 16
+	hFunc := sha256.New()
+
+	t.Run("valid", func(t *testing.T) {
+		pubs, sigs, msgs := generateBatch(t, n)
+		failed, err := BatchVerify(pubs, sigs, msgs, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if failed != nil {
+			t.Fatal("all signatures should be valid", failed)
+		}
+	})
+
+	t.Run("wrong_messages", func(t *testing.T) {
+		pubs, sigs, msgs := generateBatch(t, n)
+		msgs[3] = []byte("wrong message")
+		msgs[11] = []byte("wrong message")
+		failed, err := BatchVerify(pubs, sigs, msgs, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(failed, []int{3, 11}) {
+			t.Fatal("expected signatures 3 and 11 to fail", failed)
+		}
+	})
+
+	t.Run("swapped_signatures", func(t *testing.T) {
+		pubs, sigs, msgs := generateBatch(t, n)
+		sigs[0], sigs[1] = sigs[1], sigs[0]
+		failed, err := BatchVerify(pubs, sigs, msgs, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(failed, []int{0, 1}) {
+			t.Fatal("expected signatures 0 and 1 to fail", failed)
+		}
+	})
+
+	t.Run("malformed_signature", func(t *testing.T) {
+		pubs, sigs, msgs := generateBatch(t, n)
+		sigs[5] = sigs[5][:sizeSignature-1]
+		failed, err := BatchVerify(pubs, sigs, msgs, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(failed, []int{5}) {
+			t.Fatal("expected signature 5 to fail", failed)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		pubs, sigs, msgs := generateBatch(t, 2)
+		if _, err := BatchVerify(pubs, sigs, msgs[:1], hFunc); err != errLengthMismatch {
+			t.Fatal("expected length mismatch error")
+		}
+		if _, err := BatchVerify(pubs, sigs, msgs, nil); err != errHashNeeded {
+			t.Fatal("expected hash needed error")
+		}
+	})
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	const n = 256
+	pubs, sigs, msgs := generateBatch(b, n)
+	hFunc := sha256.New()
+
+	b.Run("batch", func(b *testing.B) {
+		for b.Loop() {
+			BatchVerify(pubs, sigs, msgs, hFunc)
+		}
+	})
+	b.Run("one_by_one", func(b *testing.B) {
+		for b.Loop() {
+			for i := range pubs {
+				pubs[i].Verify(sigs[i], msgs[i], hFunc)
+			}
+		}
+	})
+}
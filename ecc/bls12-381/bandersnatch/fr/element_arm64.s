@@ -0,0 +1,10 @@
+//go:build  !purego
+
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// We include the hash to force the Go compiler to recompile: 1501560133179981797
+#include "../../../../field/asm/element_4w/element_4w_arm64.s"
+
@@ -0,0 +1,1216 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+// ExpBySqrtExp is equivalent to z.Exp(x, 73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d3f41ad4a1db9f).
+// It raises x to the (p-2^s-1)/2^(s+1) power using a shorter addition chain,
+// where s the 2-adic valuation of p-1.
+//
+// uses github.com/mmcloughlin/addchain v0.4.0 to generate a shorter addition chain
+func (z *Element) ExpBySqrtExp(x Element) *Element {
+	// addition chain:
+	//
+	//	_10       = 2*1
+	//	_100      = 2*_10
+	//	_110      = _10 + _100
+	//	_1000     = _10 + _110
+	//	_1010     = _10 + _1000
+	//	_1100     = _10 + _1010
+	//	_1101     = 1 + _1100
+	//	_10000    = _100 + _1100
+	//	_11000    = _1000 + _10000
+	//	_11001    = 1 + _11000
+	//	_11101    = _100 + _11001
+	//	_101010   = _1101 + _11101
+	//	_110101   = _11000 + _11101
+	//	_111011   = _110 + _110101
+	//	_1000111  = _1100 + _111011
+	//	_1001101  = _110 + _1000111
+	//	_1011101  = _10000 + _1001101
+	//	_1100111  = _1010 + _1011101
+	//	_1101011  = _100 + _1100111
+	//	_10000011 = _11000 + _1101011
+	//	_10000111 = _100 + _10000011
+	//	_10001111 = _1000 + _10000111
+	//	_10011001 = _1010 + _10001111
+	//	_10011101 = _100 + _10011001
+	//	_10100101 = _1000 + _10011101
+	//	_11001111 = _101010 + _10100101
+	//	_11010111 = _1000 + _11001111
+	//	_11011011 = _100 + _11010111
+	//	_11100111 = _1100 + _11011011
+	//	_11101101 = _110 + _11100111
+	//	_11111101 = _10000 + _11101101
+	//	i59       = ((_11100111 << 8 + _11011011) << 9 + _10011101) << 9
+	//	i79       = ((_10011001 + i59) << 9 + _10011001) << 8 + _11010111
+	//	i106      = ((i79 << 6 + _110101) << 10 + _10000011) << 9
+	//	i125      = ((_1100111 + i106) << 8 + _111011) << 8 + 1
+	//	i168      = ((i125 << 14 + _1001101) << 10 + _111011) << 17
+	//	i187      = ((_11111101 + i168 + _10) << 8 + _10001111) << 8
+	//	i214      = ((_10000111 + i187) << 14 + _11101) << 10 + _11001
+	//	i248      = ((i214 << 12 + _1000111) << 10 + _1011101) << 10
+	//	i272      = ((_11111101 + i248) << 12 + _1101011) << 9 + _10100101
+	//	i295      = 2*((i272 << 12 + _11101101) << 8 + _11001111)
+	//	return      1 + i295
+	//
+	// Operations: 241 squares 55 multiplies
+	var t0, t1, t2, t3, t4, t5, t6, t7, t8, t9, t10, t11, t12, t13, t14, t15, t16, t17, t18, t19, t20 Element
+
+	// Step 1: t10 = x^0x2
+	t10.Square(&x)
+
+	// Step 2: t19 = x^0x4
+	t19.Square(&t10)
+
+	// Step 3: t0 = x^0x6
+	t0.Mul(&t10, &t19)
+
+	// Step 4: t16 = x^0x8
+	t16.Mul(&t10, &t0)
+
+	// Step 5: t1 = x^0xa
+	t1.Mul(&t10, &t16)
+
+	// Step 6: t20 = x^0xc
+	t20.Mul(&t10, &t1)
+
+	// Step 7: z = x^0xd
+	z.Mul(&x, &t20)
+
+	// Step 8: t3 = x^0x10
+	t3.Mul(&t19, &t20)
+
+	// Step 9: t8 = x^0x18
+	t8.Mul(&t16, &t3)
+
+	// Step 10: t6 = x^0x19
+	t6.Mul(&x, &t8)
+
+	// Step 11: t7 = x^0x1d
+	t7.Mul(&t19, &t6)
+
+	// Step 12: z = x^0x2a
+	z.Mul(z, &t7)
+
+	// Step 13: t15 = x^0x35
+	t15.Mul(&t8, &t7)
+
+	// Step 14: t11 = x^0x3b
+	t11.Mul(&t0, &t15)
+
+	// Step 15: t5 = x^0x47
+	t5.Mul(&t20, &t11)
+
+	// Step 16: t12 = x^0x4d
+	t12.Mul(&t0, &t5)
+
+	// Step 17: t4 = x^0x5d
+	t4.Mul(&t3, &t12)
+
+	// Step 18: t13 = x^0x67
+	t13.Mul(&t1, &t4)
+
+	// Step 19: t2 = x^0x6b
+	t2.Mul(&t19, &t13)
+
+	// Step 20: t14 = x^0x83
+	t14.Mul(&t8, &t2)
+
+	// Step 21: t8 = x^0x87
+	t8.Mul(&t19, &t14)
+
+	// Step 22: t9 = x^0x8f
+	t9.Mul(&t16, &t8)
+
+	// Step 23: t17 = x^0x99
+	t17.Mul(&t1, &t9)
+
+	// Step 24: t18 = x^0x9d
+	t18.Mul(&t19, &t17)
+
+	// Step 25: t1 = x^0xa5
+	t1.Mul(&t16, &t18)
+
+	// Step 26: z = x^0xcf
+	z.Mul(z, &t1)
+
+	// Step 27: t16 = x^0xd7
+	t16.Mul(&t16, z)
+
+	// Step 28: t19 = x^0xdb
+	t19.Mul(&t19, &t16)
+
+	// Step 29: t20 = x^0xe7
+	t20.Mul(&t20, &t19)
+
+	// Step 30: t0 = x^0xed
+	t0.Mul(&t0, &t20)
+
+	// Step 31: t3 = x^0xfd
+	t3.Mul(&t3, &t0)
+
+	// Step 39: t20 = x^0xe700
+	for range 8 {
+		t20.Square(&t20)
+	}
+
+	// Step 40: t19 = x^0xe7db
+	t19.Mul(&t19, &t20)
+
+	// Step 49: t19 = x^0x1cfb600
+	for range 9 {
+		t19.Square(&t19)
+	}
+
+	// Step 50: t18 = x^0x1cfb69d
+	t18.Mul(&t18, &t19)
+
+	// Step 59: t18 = x^0x39f6d3a00
+	for range 9 {
+		t18.Square(&t18)
+	}
+
+	// Step 60: t18 = x^0x39f6d3a99
+	t18.Mul(&t17, &t18)
+
+	// Step 69: t18 = x^0x73eda753200
+	for range 9 {
+		t18.Square(&t18)
+	}
+
+	// Step 70: t17 = x^0x73eda753299
+	t17.Mul(&t17, &t18)
+
+	// Step 78: t17 = x^0x73eda75329900
+	for range 8 {
+		t17.Square(&t17)
+	}
+
+	// Step 79: t16 = x^0x73eda753299d7
+	t16.Mul(&t16, &t17)
+
+	// Step 85: t16 = x^0x1cfb69d4ca675c0
+	for range 6 {
+		t16.Square(&t16)
+	}
+
+	// Step 86: t15 = x^0x1cfb69d4ca675f5
+	t15.Mul(&t15, &t16)
+
+	// Step 96: t15 = x^0x73eda753299d7d400
+	for range 10 {
+		t15.Square(&t15)
+	}
+
+	// Step 97: t14 = x^0x73eda753299d7d483
+	t14.Mul(&t14, &t15)
+
+	// Step 106: t14 = x^0xe7db4ea6533afa90600
+	for range 9 {
+		t14.Square(&t14)
+	}
+
+	// Step 107: t13 = x^0xe7db4ea6533afa90667
+	t13.Mul(&t13, &t14)
+
+	// Step 115: t13 = x^0xe7db4ea6533afa9066700
+	for range 8 {
+		t13.Square(&t13)
+	}
+
+	// Step 116: t13 = x^0xe7db4ea6533afa906673b
+	t13.Mul(&t11, &t13)
+
+	// Step 124: t13 = x^0xe7db4ea6533afa906673b00
+	for range 8 {
+		t13.Square(&t13)
+	}
+
+	// Step 125: t13 = x^0xe7db4ea6533afa906673b01
+	t13.Mul(&x, &t13)
+
+	// Step 139: t13 = x^0x39f6d3a994cebea4199cec04000
+	for range 14 {
+		t13.Square(&t13)
+	}
+
+	// Step 140: t12 = x^0x39f6d3a994cebea4199cec0404d
+	t12.Mul(&t12, &t13)
+
+	// Step 150: t12 = x^0xe7db4ea6533afa906673b01013400
+	for range 10 {
+		t12.Square(&t12)
+	}
+
+	// Step 151: t11 = x^0xe7db4ea6533afa906673b0101343b
+	t11.Mul(&t11, &t12)
+
+	// Step 168: t11 = x^0x1cfb69d4ca675f520cce76020268760000
+	for range 17 {
+		t11.Square(&t11)
+	}
+
+	// Step 169: t11 = x^0x1cfb69d4ca675f520cce760202687600fd
+	t11.Mul(&t3, &t11)
+
+	// Step 170: t10 = x^0x1cfb69d4ca675f520cce760202687600ff
+	t10.Mul(&t10, &t11)
+
+	// Step 178: t10 = x^0x1cfb69d4ca675f520cce760202687600ff00
+	for range 8 {
+		t10.Square(&t10)
+	}
+
+	// Step 179: t9 = x^0x1cfb69d4ca675f520cce760202687600ff8f
+	t9.Mul(&t9, &t10)
+
+	// Step 187: t9 = x^0x1cfb69d4ca675f520cce760202687600ff8f00
+	for range 8 {
+		t9.Square(&t9)
+	}
+
+	// Step 188: t8 = x^0x1cfb69d4ca675f520cce760202687600ff8f87
+	t8.Mul(&t8, &t9)
+
+	// Step 202: t8 = x^0x73eda753299d7d483339d80809a1d803fe3e1c000
+	for range 14 {
+		t8.Square(&t8)
+	}
+
+	// Step 203: t7 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d
+	t7.Mul(&t7, &t8)
+
+	// Step 213: t7 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007400
+	for range 10 {
+		t7.Square(&t7)
+	}
+
+	// Step 214: t6 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419
+	t6.Mul(&t6, &t7)
+
+	// Step 226: t6 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419000
+	for range 12 {
+		t6.Square(&t6)
+	}
+
+	// Step 227: t5 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047
+	t5.Mul(&t5, &t6)
+
+	// Step 237: t5 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c00
+	for range 10 {
+		t5.Square(&t5)
+	}
+
+	// Step 238: t4 = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d
+	t4.Mul(&t4, &t5)
+
+	// Step 248: t4 = x^0x1cfb69d4ca675f520cce760202687600ff8f8700741904717400
+	for range 10 {
+		t4.Square(&t4)
+	}
+
+	// Step 249: t3 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd
+	t3.Mul(&t3, &t4)
+
+	// Step 261: t3 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd000
+	for range 12 {
+		t3.Square(&t3)
+	}
+
+	// Step 262: t2 = x^0x1cfb69d4ca675f520cce760202687600ff8f87007419047174fd06b
+	t2.Mul(&t2, &t3)
+
+	// Step 271: t2 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d600
+	for range 9 {
+		t2.Square(&t2)
+	}
+
+	// Step 272: t1 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a5
+	t1.Mul(&t1, &t2)
+
+	// Step 284: t1 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a5000
+	for range 12 {
+		t1.Square(&t1)
+	}
+
+	// Step 285: t0 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50ed
+	t0.Mul(&t0, &t1)
+
+	// Step 293: t0 = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50ed00
+	for range 8 {
+		t0.Square(&t0)
+	}
+
+	// Step 294: z = x^0x39f6d3a994cebea4199cec0404d0ec01ff1f0e00e83208e2e9fa0d6a50edcf
+	z.Mul(z, &t0)
+
+	// Step 295: z = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d3f41ad4a1db9e
+	z.Square(z)
+
+	// Step 296: z = x^0x73eda753299d7d483339d80809a1d803fe3e1c01d06411c5d3f41ad4a1db9f
+	z.Mul(&x, z)
+
+	return z
+}
+
+// ExpByCbrtQPlus2Div9 is equivalent to z.Exp(x, 3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db3d628b8b).
+// It raises x to the (q+2)/9 power using a shorter addition chain.
+// This is used when q ≡ 7 (mod 9) for efficient cube root computation.
+//
+// uses github.com/mmcloughlin/addchain v0.4.0 to generate a shorter addition chain
+func (z *Element) ExpByCbrtQPlus2Div9(x Element) *Element {
+	// addition chain:
+	//
+	//	_10      = 2*1
+	//	_11      = 1 + _10
+	//	_101     = _10 + _11
+	//	_111     = _10 + _101
+	//	_1001    = _10 + _111
+	//	_1011    = _10 + _1001
+	//	_1101    = _10 + _1011
+	//	_1111    = _10 + _1101
+	//	_11000   = _1001 + _1111
+	//	_1100000 = _11000 << 2
+	//	_1100111 = _111 + _1100000
+	//	i32      = ((_1100111 << 6 + _11) << 5 + 1) << 7
+	//	i45      = ((_1011 + i32) << 4 + _1101) << 6 + _1001
+	//	i60      = (2*(i45 << 5 + _1101) + 1) << 7
+	//	i73      = ((_1001 + i60) << 2 + _11) << 8 + _1001
+	//	i90      = ((i73 << 6 + _111) << 4 + _111) << 5
+	//	i108     = ((_1111 + i90) << 9 + _1101) << 6 + _111
+	//	i123     = ((i108 << 5 + _101) << 4 + _101) << 4
+	//	i136     = ((_111 + i123) << 5 + _1111) << 5 + _101
+	//	i153     = ((i136 << 4 + _11) << 6 + _111) << 5
+	//	i165     = ((_101 + i153) << 4 + _101) << 5 + _1001
+	//	i184     = ((i165 << 7 + _1101) << 3 + _11) << 7
+	//	i198     = ((_111 + i184) << 7 + _1111) << 4 + _1101
+	//	i214     = ((i198 << 5 + _1011) << 5 + _1011) << 4
+	//	i226     = ((_1001 + i214) << 3 + 1) << 6 + _101
+	//	i238     = 2*((i226 << 5 + _1111) << 4 + _1101)
+	//	i249     = 2*((1 + i238) << 7 + _1101) + 1
+	//	i265     = ((i249 << 7 + _1111) << 4 + _1011) << 3
+	//	i279     = ((_11 + i265) << 6 + _1111) << 5 + _1011
+	//	i295     = 2*((i279 << 6 + _101) << 7 + _1011)
+	//	return     (1 + i295) << 7 + _1011
+	//
+	// Operations: 246 squares 58 multiplies
+	var t0, t1, t2, t3, t4, t5, t6 Element
+
+	// Step 1: t1 = x^0x2
+	t1.Square(&x)
+
+	// Step 2: t2 = x^0x3
+	t2.Mul(&x, &t1)
+
+	// Step 3: t0 = x^0x5
+	t0.Mul(&t1, &t2)
+
+	// Step 4: t5 = x^0x7
+	t5.Mul(&t1, &t0)
+
+	// Step 5: t4 = x^0x9
+	t4.Mul(&t1, &t5)
+
+	// Step 6: z = x^0xb
+	z.Mul(&t1, &t4)
+
+	// Step 7: t3 = x^0xd
+	t3.Mul(&t1, z)
+
+	// Step 8: t1 = x^0xf
+	t1.Mul(&t1, &t3)
+
+	// Step 9: t6 = x^0x18
+	t6.Mul(&t4, &t1)
+
+	// Step 11: t6 = x^0x60
+	for range 2 {
+		t6.Square(&t6)
+	}
+
+	// Step 12: t6 = x^0x67
+	t6.Mul(&t5, &t6)
+
+	// Step 18: t6 = x^0x19c0
+	for range 6 {
+		t6.Square(&t6)
+	}
+
+	// Step 19: t6 = x^0x19c3
+	t6.Mul(&t2, &t6)
+
+	// Step 24: t6 = x^0x33860
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 25: t6 = x^0x33861
+	t6.Mul(&x, &t6)
+
+	// Step 32: t6 = x^0x19c3080
+	for range 7 {
+		t6.Square(&t6)
+	}
+
+	// Step 33: t6 = x^0x19c308b
+	t6.Mul(z, &t6)
+
+	// Step 37: t6 = x^0x19c308b0
+	for range 4 {
+		t6.Square(&t6)
+	}
+
+	// Step 38: t6 = x^0x19c308bd
+	t6.Mul(&t3, &t6)
+
+	// Step 44: t6 = x^0x670c22f40
+	for range 6 {
+		t6.Square(&t6)
+	}
+
+	// Step 45: t6 = x^0x670c22f49
+	t6.Mul(&t4, &t6)
+
+	// Step 50: t6 = x^0xce1845e920
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 51: t6 = x^0xce1845e92d
+	t6.Mul(&t3, &t6)
+
+	// Step 52: t6 = x^0x19c308bd25a
+	t6.Square(&t6)
+
+	// Step 53: t6 = x^0x19c308bd25b
+	t6.Mul(&x, &t6)
+
+	// Step 60: t6 = x^0xce1845e92d80
+	for range 7 {
+		t6.Square(&t6)
+	}
+
+	// Step 61: t6 = x^0xce1845e92d89
+	t6.Mul(&t4, &t6)
+
+	// Step 63: t6 = x^0x3386117a4b624
+	for range 2 {
+		t6.Square(&t6)
+	}
+
+	// Step 64: t6 = x^0x3386117a4b627
+	t6.Mul(&t2, &t6)
+
+	// Step 72: t6 = x^0x3386117a4b62700
+	for range 8 {
+		t6.Square(&t6)
+	}
+
+	// Step 73: t6 = x^0x3386117a4b62709
+	t6.Mul(&t4, &t6)
+
+	// Step 79: t6 = x^0xce1845e92d89c240
+	for range 6 {
+		t6.Square(&t6)
+	}
+
+	// Step 80: t6 = x^0xce1845e92d89c247
+	t6.Mul(&t5, &t6)
+
+	// Step 84: t6 = x^0xce1845e92d89c2470
+	for range 4 {
+		t6.Square(&t6)
+	}
+
+	// Step 85: t6 = x^0xce1845e92d89c2477
+	t6.Mul(&t5, &t6)
+
+	// Step 90: t6 = x^0x19c308bd25b13848ee0
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 91: t6 = x^0x19c308bd25b13848eef
+	t6.Mul(&t1, &t6)
+
+	// Step 100: t6 = x^0x3386117a4b627091dde00
+	for range 9 {
+		t6.Square(&t6)
+	}
+
+	// Step 101: t6 = x^0x3386117a4b627091dde0d
+	t6.Mul(&t3, &t6)
+
+	// Step 107: t6 = x^0xce1845e92d89c247778340
+	for range 6 {
+		t6.Square(&t6)
+	}
+
+	// Step 108: t6 = x^0xce1845e92d89c247778347
+	t6.Mul(&t5, &t6)
+
+	// Step 113: t6 = x^0x19c308bd25b13848eef068e0
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 114: t6 = x^0x19c308bd25b13848eef068e5
+	t6.Mul(&t0, &t6)
+
+	// Step 118: t6 = x^0x19c308bd25b13848eef068e50
+	for range 4 {
+		t6.Square(&t6)
+	}
+
+	// Step 119: t6 = x^0x19c308bd25b13848eef068e55
+	t6.Mul(&t0, &t6)
+
+	// Step 123: t6 = x^0x19c308bd25b13848eef068e550
+	for range 4 {
+		t6.Square(&t6)
+	}
+
+	// Step 124: t6 = x^0x19c308bd25b13848eef068e557
+	t6.Mul(&t5, &t6)
+
+	// Step 129: t6 = x^0x3386117a4b627091dde0d1caae0
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 130: t6 = x^0x3386117a4b627091dde0d1caaef
+	t6.Mul(&t1, &t6)
+
+	// Step 135: t6 = x^0x670c22f496c4e123bbc1a3955de0
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 136: t6 = x^0x670c22f496c4e123bbc1a3955de5
+	t6.Mul(&t0, &t6)
+
+	// Step 140: t6 = x^0x670c22f496c4e123bbc1a3955de50
+	for range 4 {
+		t6.Square(&t6)
+	}
+
+	// Step 141: t6 = x^0x670c22f496c4e123bbc1a3955de53
+	t6.Mul(&t2, &t6)
+
+	// Step 147: t6 = x^0x19c308bd25b13848eef068e557794c0
+	for range 6 {
+		t6.Square(&t6)
+	}
+
+	// Step 148: t6 = x^0x19c308bd25b13848eef068e557794c7
+	t6.Mul(&t5, &t6)
+
+	// Step 153: t6 = x^0x3386117a4b627091dde0d1caaef298e0
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 154: t6 = x^0x3386117a4b627091dde0d1caaef298e5
+	t6.Mul(&t0, &t6)
+
+	// Step 158: t6 = x^0x3386117a4b627091dde0d1caaef298e50
+	for range 4 {
+		t6.Square(&t6)
+	}
+
+	// Step 159: t6 = x^0x3386117a4b627091dde0d1caaef298e55
+	t6.Mul(&t0, &t6)
+
+	// Step 164: t6 = x^0x670c22f496c4e123bbc1a3955de531caa0
+	for range 5 {
+		t6.Square(&t6)
+	}
+
+	// Step 165: t6 = x^0x670c22f496c4e123bbc1a3955de531caa9
+	t6.Mul(&t4, &t6)
+
+	// Step 172: t6 = x^0x3386117a4b627091dde0d1caaef298e55480
+	for range 7 {
+		t6.Square(&t6)
+	}
+
+	// Step 173: t6 = x^0x3386117a4b627091dde0d1caaef298e5548d
+	t6.Mul(&t3, &t6)
+
+	// Step 176: t6 = x^0x19c308bd25b13848eef068e557794c72aa468
+	for range 3 {
+		t6.Square(&t6)
+	}
+
+	// Step 177: t6 = x^0x19c308bd25b13848eef068e557794c72aa46b
+	t6.Mul(&t2, &t6)
+
+	// Step 184: t6 = x^0xce1845e92d89c2477783472abbca6395523580
+	for range 7 {
+		t6.Square(&t6)
+	}
+
+	// Step 185: t5 = x^0xce1845e92d89c2477783472abbca6395523587
+	t5.Mul(&t5, &t6)
+
+	// Step 192: t5 = x^0x670c22f496c4e123bbc1a3955de531caa91ac380
+	for range 7 {
+		t5.Square(&t5)
+	}
+
+	// Step 193: t5 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38f
+	t5.Mul(&t1, &t5)
+
+	// Step 197: t5 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38f0
+	for range 4 {
+		t5.Square(&t5)
+	}
+
+	// Step 198: t5 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd
+	t5.Mul(&t3, &t5)
+
+	// Step 203: t5 = x^0xce1845e92d89c2477783472abbca63955235871fa0
+	for range 5 {
+		t5.Square(&t5)
+	}
+
+	// Step 204: t5 = x^0xce1845e92d89c2477783472abbca63955235871fab
+	t5.Mul(z, &t5)
+
+	// Step 209: t5 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f560
+	for range 5 {
+		t5.Square(&t5)
+	}
+
+	// Step 210: t5 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b
+	t5.Mul(z, &t5)
+
+	// Step 214: t5 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b0
+	for range 4 {
+		t5.Square(&t5)
+	}
+
+	// Step 215: t4 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b9
+	t4.Mul(&t4, &t5)
+
+	// Step 218: t4 = x^0xce1845e92d89c2477783472abbca63955235871fab5c8
+	for range 3 {
+		t4.Square(&t4)
+	}
+
+	// Step 219: t4 = x^0xce1845e92d89c2477783472abbca63955235871fab5c9
+	t4.Mul(&x, &t4)
+
+	// Step 225: t4 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead7240
+	for range 6 {
+		t4.Square(&t4)
+	}
+
+	// Step 226: t4 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead7245
+	t4.Mul(&t0, &t4)
+
+	// Step 231: t4 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48a0
+	for range 5 {
+		t4.Square(&t4)
+	}
+
+	// Step 232: t4 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48af
+	t4.Mul(&t1, &t4)
+
+	// Step 236: t4 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48af0
+	for range 4 {
+		t4.Square(&t4)
+	}
+
+	// Step 237: t4 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd
+	t4.Mul(&t3, &t4)
+
+	// Step 238: t4 = x^0xce1845e92d89c2477783472abbca63955235871fab5c915fa
+	t4.Square(&t4)
+
+	// Step 239: t4 = x^0xce1845e92d89c2477783472abbca63955235871fab5c915fb
+	t4.Mul(&x, &t4)
+
+	// Step 246: t4 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd80
+	for range 7 {
+		t4.Square(&t4)
+	}
+
+	// Step 247: t3 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d
+	t3.Mul(&t3, &t4)
+
+	// Step 248: t3 = x^0xce1845e92d89c2477783472abbca63955235871fab5c915fb1a
+	t3.Square(&t3)
+
+	// Step 249: t3 = x^0xce1845e92d89c2477783472abbca63955235871fab5c915fb1b
+	t3.Mul(&x, &t3)
+
+	// Step 256: t3 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d80
+	for range 7 {
+		t3.Square(&t3)
+	}
+
+	// Step 257: t3 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8f
+	t3.Mul(&t1, &t3)
+
+	// Step 261: t3 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8f0
+	for range 4 {
+		t3.Square(&t3)
+	}
+
+	// Step 262: t3 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb
+	t3.Mul(z, &t3)
+
+	// Step 265: t3 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7d8
+	for range 3 {
+		t3.Square(&t3)
+	}
+
+	// Step 266: t2 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db
+	t2.Mul(&t2, &t3)
+
+	// Step 272: t2 = x^0xce1845e92d89c2477783472abbca63955235871fab5c915fb1b1f6c0
+	for range 6 {
+		t2.Square(&t2)
+	}
+
+	// Step 273: t1 = x^0xce1845e92d89c2477783472abbca63955235871fab5c915fb1b1f6cf
+	t1.Mul(&t1, &t2)
+
+	// Step 278: t1 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6363ed9e0
+	for range 5 {
+		t1.Square(&t1)
+	}
+
+	// Step 279: t1 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6363ed9eb
+	t1.Mul(z, &t1)
+
+	// Step 285: t1 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac0
+	for range 6 {
+		t1.Square(&t1)
+	}
+
+	// Step 286: t0 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac5
+	t0.Mul(&t0, &t1)
+
+	// Step 293: t0 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db3d6280
+	for range 7 {
+		t0.Square(&t0)
+	}
+
+	// Step 294: t0 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db3d628b
+	t0.Mul(z, &t0)
+
+	// Step 295: t0 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac516
+	t0.Square(&t0)
+
+	// Step 296: t0 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac517
+	t0.Mul(&x, &t0)
+
+	// Step 303: t0 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db3d628b80
+	for range 7 {
+		t0.Square(&t0)
+	}
+
+	// Step 304: z = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db3d628b8b
+	z.Mul(z, &t0)
+
+	return z
+}
+
+// ExpByCbrtHelperQMinus7Div9 is equivalent to z.Exp(x, 3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db3d628b8a).
+// It raises x to the (q-7)/9 power using an addition chain.
+// This helper is used by cbrtAndNormInverse to share exponentiation between
+// cube root and norm inverse computations.
+//
+// uses github.com/mmcloughlin/addchain v0.4.0 to generate a shorter addition chain
+func (z *Element) ExpByCbrtHelperQMinus7Div9(x Element) *Element {
+	// addition chain:
+	//
+	//	_10      = 2*1
+	//	_100     = 2*_10
+	//	_1000    = 2*_100
+	//	_1001    = 1 + _1000
+	//	_1011    = _10 + _1001
+	//	_1100    = 1 + _1011
+	//	_1101    = 1 + _1100
+	//	_1111    = _10 + _1101
+	//	_11011   = _1100 + _1111
+	//	_100011  = _1000 + _11011
+	//	_100111  = _100 + _100011
+	//	_101011  = _100 + _100111
+	//	_101101  = _10 + _101011
+	//	_111001  = _1100 + _101101
+	//	_111011  = _10 + _111001
+	//	_1000101 = _1100 + _111001
+	//	_1001001 = _100 + _1000101
+	//	_1010001 = _1000 + _1001001
+	//	_1010011 = _10 + _1010001
+	//	_1010101 = _10 + _1010011
+	//	_1100001 = _1100 + _1010101
+	//	_1100011 = _10 + _1100001
+	//	_1100111 = _100 + _1100011
+	//	_1101111 = _1000 + _1100111
+	//	_1110001 = _10 + _1101111
+	//	i48      = (_1100111 << 11 + _1100001) << 9 + _101101 + _10
+	//	i73      = ((i48 << 8 + _1001001) << 6 + _11011) << 9
+	//	i94      = ((_100111 + i73) << 8 + _1001) << 10 + _1101111
+	//	i111     = ((_1000 + i94) << 5 + _1111) << 9 + _1101
+	//	i137     = ((i111 << 9 + _111001) << 8 + _1010101) << 7
+	//	i158     = ((_1101111 + i137) << 9 + _1010011) << 9 + _111001
+	//	i181     = ((i158 << 8 + _1010101) << 8 + _100011) << 5
+	//	i202     = ((_1011 + i181) << 11 + _1110001) << 7 + _1110001
+	//	i219     = ((_1100 + i202) << 7 + _101101) << 7 + _111001
+	//	i238     = (i219 << 9 + _1000101) << 7 + _111011 + _100
+	//	i262     = ((i238 << 8 + _1100011) << 8 + _1100011) << 6
+	//	i279     = ((_111011 + i262) << 8 + _1100111) << 6 + _101011
+	//	i301     = ((i279 << 10 + _1010001) << 8 + _1110001) << 2
+	//	return     2*(1 + i301)
+	//
+	// Operations: 246 squares 57 multiplies
+	var t0, t1, t2, t3, t4, t5, t6, t7, t8, t9, t10, t11, t12, t13, t14, t15, t16, t17, t18, t19, t20, t21, t22, t23, t24 Element
+
+	// Step 1: t22 = x^0x2
+	t22.Square(&x)
+
+	// Step 2: t5 = x^0x4
+	t5.Square(&t22)
+
+	// Step 3: t17 = x^0x8
+	t17.Square(&t5)
+
+	// Step 4: t18 = x^0x9
+	t18.Mul(&x, &t17)
+
+	// Step 5: t10 = x^0xb
+	t10.Mul(&t22, &t18)
+
+	// Step 6: t9 = x^0xc
+	t9.Mul(&x, &t10)
+
+	// Step 7: t15 = x^0xd
+	t15.Mul(&x, &t9)
+
+	// Step 8: t16 = x^0xf
+	t16.Mul(&t22, &t15)
+
+	// Step 9: t20 = x^0x1b
+	t20.Mul(&t9, &t16)
+
+	// Step 10: t11 = x^0x23
+	t11.Mul(&t17, &t20)
+
+	// Step 11: t19 = x^0x27
+	t19.Mul(&t5, &t11)
+
+	// Step 12: t1 = x^0x2b
+	t1.Mul(&t5, &t19)
+
+	// Step 13: t8 = x^0x2d
+	t8.Mul(&t22, &t1)
+
+	// Step 14: t7 = x^0x39
+	t7.Mul(&t9, &t8)
+
+	// Step 15: t3 = x^0x3b
+	t3.Mul(&t22, &t7)
+
+	// Step 16: t6 = x^0x45
+	t6.Mul(&t9, &t7)
+
+	// Step 17: t21 = x^0x49
+	t21.Mul(&t5, &t6)
+
+	// Step 18: t0 = x^0x51
+	t0.Mul(&t17, &t21)
+
+	// Step 19: t13 = x^0x53
+	t13.Mul(&t22, &t0)
+
+	// Step 20: t12 = x^0x55
+	t12.Mul(&t22, &t13)
+
+	// Step 21: t23 = x^0x61
+	t23.Mul(&t9, &t12)
+
+	// Step 22: t4 = x^0x63
+	t4.Mul(&t22, &t23)
+
+	// Step 23: t2 = x^0x67
+	t2.Mul(&t5, &t4)
+
+	// Step 24: t14 = x^0x6f
+	t14.Mul(&t17, &t2)
+
+	// Step 25: z = x^0x71
+	z.Mul(&t22, &t14)
+
+	// Step 36: t24 = x^0x33800
+	t24.Square(&t2)
+	for s := 1; s < 11; s++ {
+		t24.Square(&t24)
+	}
+
+	// Step 37: t23 = x^0x33861
+	t23.Mul(&t23, &t24)
+
+	// Step 46: t23 = x^0x670c200
+	for range 9 {
+		t23.Square(&t23)
+	}
+
+	// Step 47: t23 = x^0x670c22d
+	t23.Mul(&t8, &t23)
+
+	// Step 48: t22 = x^0x670c22f
+	t22.Mul(&t22, &t23)
+
+	// Step 56: t22 = x^0x670c22f00
+	for range 8 {
+		t22.Square(&t22)
+	}
+
+	// Step 57: t21 = x^0x670c22f49
+	t21.Mul(&t21, &t22)
+
+	// Step 63: t21 = x^0x19c308bd240
+	for range 6 {
+		t21.Square(&t21)
+	}
+
+	// Step 64: t20 = x^0x19c308bd25b
+	t20.Mul(&t20, &t21)
+
+	// Step 73: t20 = x^0x3386117a4b600
+	for range 9 {
+		t20.Square(&t20)
+	}
+
+	// Step 74: t19 = x^0x3386117a4b627
+	t19.Mul(&t19, &t20)
+
+	// Step 82: t19 = x^0x3386117a4b62700
+	for range 8 {
+		t19.Square(&t19)
+	}
+
+	// Step 83: t18 = x^0x3386117a4b62709
+	t18.Mul(&t18, &t19)
+
+	// Step 93: t18 = x^0xce1845e92d89c2400
+	for range 10 {
+		t18.Square(&t18)
+	}
+
+	// Step 94: t18 = x^0xce1845e92d89c246f
+	t18.Mul(&t14, &t18)
+
+	// Step 95: t17 = x^0xce1845e92d89c2477
+	t17.Mul(&t17, &t18)
+
+	// Step 100: t17 = x^0x19c308bd25b13848ee0
+	for range 5 {
+		t17.Square(&t17)
+	}
+
+	// Step 101: t16 = x^0x19c308bd25b13848eef
+	t16.Mul(&t16, &t17)
+
+	// Step 110: t16 = x^0x3386117a4b627091dde00
+	for range 9 {
+		t16.Square(&t16)
+	}
+
+	// Step 111: t15 = x^0x3386117a4b627091dde0d
+	t15.Mul(&t15, &t16)
+
+	// Step 120: t15 = x^0x670c22f496c4e123bbc1a00
+	for range 9 {
+		t15.Square(&t15)
+	}
+
+	// Step 121: t15 = x^0x670c22f496c4e123bbc1a39
+	t15.Mul(&t7, &t15)
+
+	// Step 129: t15 = x^0x670c22f496c4e123bbc1a3900
+	for range 8 {
+		t15.Square(&t15)
+	}
+
+	// Step 130: t15 = x^0x670c22f496c4e123bbc1a3955
+	t15.Mul(&t12, &t15)
+
+	// Step 137: t15 = x^0x3386117a4b627091dde0d1caa80
+	for range 7 {
+		t15.Square(&t15)
+	}
+
+	// Step 138: t14 = x^0x3386117a4b627091dde0d1caaef
+	t14.Mul(&t14, &t15)
+
+	// Step 147: t14 = x^0x670c22f496c4e123bbc1a3955de00
+	for range 9 {
+		t14.Square(&t14)
+	}
+
+	// Step 148: t13 = x^0x670c22f496c4e123bbc1a3955de53
+	t13.Mul(&t13, &t14)
+
+	// Step 157: t13 = x^0xce1845e92d89c2477783472abbca600
+	for range 9 {
+		t13.Square(&t13)
+	}
+
+	// Step 158: t13 = x^0xce1845e92d89c2477783472abbca639
+	t13.Mul(&t7, &t13)
+
+	// Step 166: t13 = x^0xce1845e92d89c2477783472abbca63900
+	for range 8 {
+		t13.Square(&t13)
+	}
+
+	// Step 167: t12 = x^0xce1845e92d89c2477783472abbca63955
+	t12.Mul(&t12, &t13)
+
+	// Step 175: t12 = x^0xce1845e92d89c2477783472abbca6395500
+	for range 8 {
+		t12.Square(&t12)
+	}
+
+	// Step 176: t11 = x^0xce1845e92d89c2477783472abbca6395523
+	t11.Mul(&t11, &t12)
+
+	// Step 181: t11 = x^0x19c308bd25b13848eef068e557794c72aa460
+	for range 5 {
+		t11.Square(&t11)
+	}
+
+	// Step 182: t10 = x^0x19c308bd25b13848eef068e557794c72aa46b
+	t10.Mul(&t10, &t11)
+
+	// Step 193: t10 = x^0xce1845e92d89c2477783472abbca63955235800
+	for range 11 {
+		t10.Square(&t10)
+	}
+
+	// Step 194: t10 = x^0xce1845e92d89c2477783472abbca63955235871
+	t10.Mul(z, &t10)
+
+	// Step 201: t10 = x^0x670c22f496c4e123bbc1a3955de531caa91ac3880
+	for range 7 {
+		t10.Square(&t10)
+	}
+
+	// Step 202: t10 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38f1
+	t10.Mul(z, &t10)
+
+	// Step 203: t9 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd
+	t9.Mul(&t9, &t10)
+
+	// Step 210: t9 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7e80
+	for range 7 {
+		t9.Square(&t9)
+	}
+
+	// Step 211: t8 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead
+	t8.Mul(&t8, &t9)
+
+	// Step 218: t8 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f5680
+	for range 7 {
+		t8.Square(&t8)
+	}
+
+	// Step 219: t7 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b9
+	t7.Mul(&t7, &t8)
+
+	// Step 228: t7 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead7200
+	for range 9 {
+		t7.Square(&t7)
+	}
+
+	// Step 229: t6 = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead7245
+	t6.Mul(&t6, &t7)
+
+	// Step 236: t6 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b92280
+	for range 7 {
+		t6.Square(&t6)
+	}
+
+	// Step 237: t6 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bb
+	t6.Mul(&t3, &t6)
+
+	// Step 238: t5 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf
+	t5.Mul(&t5, &t6)
+
+	// Step 246: t5 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf00
+	for range 8 {
+		t5.Square(&t5)
+	}
+
+	// Step 247: t5 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf63
+	t5.Mul(&t4, &t5)
+
+	// Step 255: t5 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6300
+	for range 8 {
+		t5.Square(&t5)
+	}
+
+	// Step 256: t4 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6363
+	t4.Mul(&t4, &t5)
+
+	// Step 262: t4 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8c0
+	for range 6 {
+		t4.Square(&t4)
+	}
+
+	// Step 263: t3 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb
+	t3.Mul(&t3, &t4)
+
+	// Step 271: t3 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb00
+	for range 8 {
+		t3.Square(&t3)
+	}
+
+	// Step 272: t2 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67
+	t2.Mul(&t2, &t3)
+
+	// Step 278: t2 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6363ed9c0
+	for range 6 {
+		t2.Square(&t2)
+	}
+
+	// Step 279: t1 = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6363ed9eb
+	t1.Mul(&t1, &t2)
+
+	// Step 289: t1 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac00
+	for range 10 {
+		t1.Square(&t1)
+	}
+
+	// Step 290: t0 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac51
+	t0.Mul(&t0, &t1)
+
+	// Step 298: t0 = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac5100
+	for range 8 {
+		t0.Square(&t0)
+	}
+
+	// Step 299: z = x^0x670c22f496c4e123bbc1a3955de531caa91ac38fd5ae48afd8d8fb67ac5171
+	z.Mul(z, &t0)
+
+	// Step 301: z = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6363ed9eb145c4
+	for range 2 {
+		z.Square(z)
+	}
+
+	// Step 302: z = x^0x19c308bd25b13848eef068e557794c72aa46b0e3f56b922bf6363ed9eb145c5
+	z.Mul(&x, z)
+
+	// Step 303: z = x^0x3386117a4b627091dde0d1caaef298e5548d61c7ead72457ec6c7db3d628b8a
+	z.Square(z)
+
+	return z
+}
@@ -0,0 +1,574 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizeFr = fr.Bytes
+
+	sizeG1 = bls12381.SizeOfG1AffineCompressed
+	sizeG2 = bls12381.SizeOfG2AffineCompressed
+
+	// minimal-pubkey-size variant: public keys in G1, signatures in G2
+	sizePublicKey  = sizeG1
+	sizeSignature  = sizeG2
+	sizePrivateKey = sizePublicKey + sizeFr
+
+	// minimal-signature-size variant: public keys in G2, signatures in G1
+	sizePublicKeyMinSig  = sizeG2
+	sizeSignatureMinSig  = sizeG1
+	sizePrivateKeyMinSig = sizePublicKeyMinSig + sizeFr
+)
+
+// Domain separation tags of the proof-of-possession ciphersuites.
+const (
+	DSTSignature        = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+	DSTPossession       = "BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+	DSTSignatureMinSig  = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+	DSTPossessionMinSig = "BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+var (
+	errWrongSize        = errors.New("wrong size buffer")
+	errShortIKM         = errors.New("input keying material must be at least 32 bytes")
+	errInvalidPublicKey = errors.New("invalid public key")
+	errNoSignatures     = errors.New("no signatures to aggregate")
+	errNoPublicKeys     = errors.New("no public keys to aggregate")
+	errLengthMismatch   = errors.New("number of public keys and messages differ")
+)
+
+var order = fr.Modulus()
+
+// keyGen derives a secret scalar from the input keying material ikm and the
+// optional keyInfo, following the KeyGen procedure of the IETF draft:
+//
+//	salt = "BLS-SIG-KEYGEN-SALT-"
+//	while SK == 0:
+//		salt = SHA-256(salt)
+//		PRK = HKDF-Extract(salt, ikm ∥ I2OSP(0, 1))
+//		OKM = HKDF-Expand(PRK, keyInfo ∥ I2OSP(L, 2), L)
+//		SK = OS2IP(OKM) mod r
+func keyGen(ikm, keyInfo []byte) (*big.Int, error) {
+	if len(ikm) < 32 {
+		return nil, errShortIKM
+	}
+	// L = ceil((3 * ceil(log2(r))) / 16)
+	l := (3*fr.Bits + 15) / 16
+
+	ikmExt := make([]byte, len(ikm)+1)
+	copy(ikmExt, ikm)
+	info := make([]byte, len(keyInfo)+2)
+	copy(info, keyInfo)
+	info[len(keyInfo)] = byte(l >> 8)
+	info[len(keyInfo)+1] = byte(l)
+
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	okm := make([]byte, l)
+	sk := new(big.Int)
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikmExt, salt, info), okm); err != nil {
+			return nil, err
+		}
+		sk.SetBytes(okm).Mod(sk, order)
+	}
+	return sk, nil
+}
+
+// randomIKM reads 32 bytes of input keying material from rand.
+func randomIKM(rand io.Reader) ([]byte, error) {
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(rand, ikm); err != nil {
+		return nil, err
+	}
+	return ikm, nil
+}
+
+// prehash hashes message with hFunc if it is provided, and returns message
+// unchanged otherwise.
+func prehash(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// minimal-pubkey-size variant: public keys in G1, signatures in G2
+
+// PublicKey represents a BLS public key of the minimal-pubkey-size variant.
+type PublicKey struct {
+	A bls12381.G1Affine
+}
+
+// PrivateKey represents a BLS private key of the minimal-pubkey-size variant.
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// Signature represents a BLS signature of the minimal-pubkey-size variant.
+type Signature struct {
+	S bls12381.G2Affine
+}
+
+// GenerateKey generates a public and private key pair of the minimal-pubkey-size
+// variant, using 32 bytes read from rand as input keying material.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	ikm, err := randomIKM(rand)
+	if err != nil {
+		return nil, err
+	}
+	return KeyGen(ikm, nil)
+}
+
+// KeyGen deterministically derives a key pair of the minimal-pubkey-size
+// variant from the input keying material ikm (at least 32 bytes) and the
+// optional keyInfo, following the KeyGen procedure of the IETF draft.
+func KeyGen(ikm, keyInfo []byte) (*PrivateKey, error) {
+	sk, err := keyGen(ikm, keyInfo)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := new(PrivateKey)
+	sk.FillBytes(privateKey.scalar[:])
+	privateKey.PublicKey.A.ScalarMultiplicationBase(sk)
+	return privateKey, nil
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// isValid implements KeyValidate: the public key must be a non-identity
+// point of the prime order subgroup.
+func (pub *PublicKey) isValid() bool {
+	return !pub.A.IsInfinity() && pub.A.IsInSubGroup()
+}
+
+// hashToPoint maps a message to the signature group using dst as domain separation tag.
+func hashToPoint(message []byte, hFunc hash.Hash, dst string) (bls12381.G2Affine, error) {
+	msg, err := prehash(message, hFunc)
+	if err != nil {
+		return bls12381.G2Affine{}, err
+	}
+	return bls12381.HashToG2(msg, []byte(dst))
+}
+
+// sign multiplies the hash of the message by the secret scalar.
+func (privKey *PrivateKey) sign(message []byte, hFunc hash.Hash, dst string) ([]byte, error) {
+	h, err := hashToPoint(message, hFunc, dst)
+	if err != nil {
+		return nil, err
+	}
+	scalar := new(big.Int).SetBytes(privKey.scalar[:])
+	var sig Signature
+	sig.S.ScalarMultiplication(&h, scalar)
+	return sig.Bytes(), nil
+}
+
+// Sign performs the BLS signature of the message:
+//
+//	Q = hash_to_point(message)
+//	signature = sk ⋅ Q
+//
+// If hFunc is provided, the message is first hashed with hFunc before being
+// mapped to G2; otherwise the message is mapped directly.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	return privKey.sign(message, hFunc, DSTSignature)
+}
+
+// ProvePossession returns a proof of possession of the private key, that is a
+// signature of the serialized public key under the proof-of-possession domain
+// separation tag.
+func (privKey *PrivateKey) ProvePossession() ([]byte, error) {
+	return privKey.sign(privKey.PublicKey.Bytes(), nil, DSTPossession)
+}
+
+// coreVerify checks that e(pk, H(m)) = e(g, sig) (up to the order of the pairing arguments).
+func (pub *PublicKey) coreVerify(sigBin, message []byte, hFunc hash.Hash, dst string) (bool, error) {
+	if !pub.isValid() {
+		return false, nil
+	}
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	h, err := hashToPoint(message, hFunc, dst)
+	if err != nil {
+		return false, err
+	}
+	_, _, g, _ := bls12381.Generators()
+	g.Neg(&g)
+	return bls12381.PairingCheck([]bls12381.G1Affine{pub.A, g}, []bls12381.G2Affine{h, sig.S})
+}
+
+// Verify validates the BLS signature of the message under the public key.
+//
+// It returns false if the public key is the identity or not in the prime order
+// subgroup, and an error if the signature cannot be decoded or is not in the
+// prime order subgroup. The hFunc argument must match the one used in [PrivateKey.Sign].
+func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	return pub.coreVerify(sigBin, message, hFunc, DSTSignature)
+}
+
+// VerifyPossession checks a proof of possession obtained with [PrivateKey.ProvePossession].
+func (pub *PublicKey) VerifyPossession(proof []byte) (bool, error) {
+	return pub.coreVerify(proof, pub.Bytes(), nil, DSTPossession)
+}
+
+// Aggregate aggregates several serialized signatures into a single one.
+func Aggregate(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errNoSignatures
+	}
+	var acc bls12381.G2Jac
+	var sig Signature
+	for i := range sigs {
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return nil, err
+		}
+		acc.AddMixed(&sig.S)
+	}
+	sig.S.FromJacobian(&acc)
+	return sig.Bytes(), nil
+}
+
+// AggregatePublicKeys aggregates several public keys into a single
+// one. The public keys should come with a verified proof of possession to
+// avoid rogue key attacks.
+func AggregatePublicKeys(pubs []PublicKey) (PublicKey, error) {
+	var res PublicKey
+	if len(pubs) == 0 {
+		return res, errNoPublicKeys
+	}
+	var acc bls12381.G1Jac
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return res, errInvalidPublicKey
+		}
+		acc.AddMixed(&pubs[i].A)
+	}
+	res.A.FromJacobian(&acc)
+	return res, nil
+}
+
+// AggregateVerify verifies an aggregate signature of the messages
+// msgs[i] signed by pubs[i] respectively, by checking
+//
+//	∏ e(pubs[i], H(msgs[i])) = e(g, sig)
+//
+// with a single multi-pairing.
+func AggregateVerify(pubs []PublicKey, msgs [][]byte, sigBin []byte, hFunc hash.Hash) (bool, error) {
+	if len(pubs) != len(msgs) {
+		return false, errLengthMismatch
+	}
+	if len(pubs) == 0 {
+		return false, errNoPublicKeys
+	}
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	pks := make([]bls12381.G1Affine, len(pubs)+1)
+	hs := make([]bls12381.G2Affine, len(pubs)+1)
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return false, nil
+		}
+		h, err := hashToPoint(msgs[i], hFunc, DSTSignature)
+		if err != nil {
+			return false, err
+		}
+		pks[i].Set(&pubs[i].A)
+		hs[i] = h
+	}
+	_, _, g, _ := bls12381.Generators()
+	pks[len(pubs)].Neg(&g)
+	hs[len(pubs)].Set(&sig.S)
+	return bls12381.PairingCheck(pks, hs)
+}
+
+// FastAggregateVerify verifies an aggregate signature of the same
+// message by all the public keys. The public keys must come with a verified
+// proof of possession (see [PublicKey.VerifyPossession]).
+func FastAggregateVerify(pubs []PublicKey, message []byte, sigBin []byte, hFunc hash.Hash) (bool, error) {
+	if len(pubs) == 0 {
+		return false, errNoPublicKeys
+	}
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return false, nil
+		}
+	}
+	aggPub, err := AggregatePublicKeys(pubs)
+	if err != nil {
+		return false, err
+	}
+	return aggPub.Verify(sigBin, message, hFunc)
+}
+
+// ------------------------------------------------------------------------------------------------
+// minimal-signature-size variant: public keys in G2, signatures in G1
+
+// PublicKeyMinSig represents a BLS public key of the minimal-signature-size variant.
+type PublicKeyMinSig struct {
+	A bls12381.G2Affine
+}
+
+// PrivateKeyMinSig represents a BLS private key of the minimal-signature-size variant.
+type PrivateKeyMinSig struct {
+	PublicKey PublicKeyMinSig
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// SignatureMinSig represents a BLS signature of the minimal-signature-size variant.
+type SignatureMinSig struct {
+	S bls12381.G1Affine
+}
+
+// GenerateKeyMinSig generates a public and private key pair of the minimal-signature-size
+// variant, using 32 bytes read from rand as input keying material.
+func GenerateKeyMinSig(rand io.Reader) (*PrivateKeyMinSig, error) {
+	ikm, err := randomIKM(rand)
+	if err != nil {
+		return nil, err
+	}
+	return KeyGenMinSig(ikm, nil)
+}
+
+// KeyGenMinSig deterministically derives a key pair of the minimal-signature-size
+// variant from the input keying material ikm (at least 32 bytes) and the
+// optional keyInfo, following the KeyGen procedure of the IETF draft.
+func KeyGenMinSig(ikm, keyInfo []byte) (*PrivateKeyMinSig, error) {
+	sk, err := keyGen(ikm, keyInfo)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := new(PrivateKeyMinSig)
+	sk.FillBytes(privateKey.scalar[:])
+	privateKey.PublicKey.A.ScalarMultiplicationBase(sk)
+	return privateKey, nil
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKeyMinSig) Public() signature.PublicKey {
+	var pub PublicKeyMinSig
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKeyMinSig) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKeyMinSig)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// isValid implements KeyValidate: the public key must be a non-identity
+// point of the prime order subgroup.
+func (pub *PublicKeyMinSig) isValid() bool {
+	return !pub.A.IsInfinity() && pub.A.IsInSubGroup()
+}
+
+// hashToPointMinSig maps a message to the signature group using dst as domain separation tag.
+func hashToPointMinSig(message []byte, hFunc hash.Hash, dst string) (bls12381.G1Affine, error) {
+	msg, err := prehash(message, hFunc)
+	if err != nil {
+		return bls12381.G1Affine{}, err
+	}
+	return bls12381.HashToG1(msg, []byte(dst))
+}
+
+// sign multiplies the hash of the message by the secret scalar.
+func (privKey *PrivateKeyMinSig) sign(message []byte, hFunc hash.Hash, dst string) ([]byte, error) {
+	h, err := hashToPointMinSig(message, hFunc, dst)
+	if err != nil {
+		return nil, err
+	}
+	scalar := new(big.Int).SetBytes(privKey.scalar[:])
+	var sig SignatureMinSig
+	sig.S.ScalarMultiplication(&h, scalar)
+	return sig.Bytes(), nil
+}
+
+// Sign performs the BLS signature of the message:
+//
+//	Q = hash_to_point(message)
+//	signature = sk ⋅ Q
+//
+// If hFunc is provided, the message is first hashed with hFunc before being
+// mapped to G1; otherwise the message is mapped directly.
+func (privKey *PrivateKeyMinSig) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	return privKey.sign(message, hFunc, DSTSignatureMinSig)
+}
+
+// ProvePossession returns a proof of possession of the private key, that is a
+// signature of the serialized public key under the proof-of-possession domain
+// separation tag.
+func (privKey *PrivateKeyMinSig) ProvePossession() ([]byte, error) {
+	return privKey.sign(privKey.PublicKey.Bytes(), nil, DSTPossessionMinSig)
+}
+
+// coreVerify checks that e(pk, H(m)) = e(g, sig) (up to the order of the pairing arguments).
+func (pub *PublicKeyMinSig) coreVerify(sigBin, message []byte, hFunc hash.Hash, dst string) (bool, error) {
+	if !pub.isValid() {
+		return false, nil
+	}
+	var sig SignatureMinSig
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	h, err := hashToPointMinSig(message, hFunc, dst)
+	if err != nil {
+		return false, err
+	}
+	_, _, _, g := bls12381.Generators()
+	g.Neg(&g)
+	return bls12381.PairingCheck([]bls12381.G1Affine{h, sig.S}, []bls12381.G2Affine{pub.A, g})
+}
+
+// Verify validates the BLS signature of the message under the public key.
+//
+// It returns false if the public key is the identity or not in the prime order
+// subgroup, and an error if the signature cannot be decoded or is not in the
+// prime order subgroup. The hFunc argument must match the one used in [PrivateKeyMinSig.Sign].
+func (pub *PublicKeyMinSig) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	return pub.coreVerify(sigBin, message, hFunc, DSTSignatureMinSig)
+}
+
+// VerifyPossession checks a proof of possession obtained with [PrivateKeyMinSig.ProvePossession].
+func (pub *PublicKeyMinSig) VerifyPossession(proof []byte) (bool, error) {
+	return pub.coreVerify(proof, pub.Bytes(), nil, DSTPossessionMinSig)
+}
+
+// AggregateMinSig aggregates several serialized signatures into a single one.
+func AggregateMinSig(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errNoSignatures
+	}
+	var acc bls12381.G1Jac
+	var sig SignatureMinSig
+	for i := range sigs {
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return nil, err
+		}
+		acc.AddMixed(&sig.S)
+	}
+	sig.S.FromJacobian(&acc)
+	return sig.Bytes(), nil
+}
+
+// AggregatePublicKeysMinSig aggregates several public keys into a single
+// one. The public keys should come with a verified proof of possession to
+// avoid rogue key attacks.
+func AggregatePublicKeysMinSig(pubs []PublicKeyMinSig) (PublicKeyMinSig, error) {
+	var res PublicKeyMinSig
+	if len(pubs) == 0 {
+		return res, errNoPublicKeys
+	}
+	var acc bls12381.G2Jac
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return res, errInvalidPublicKey
+		}
+		acc.AddMixed(&pubs[i].A)
+	}
+	res.A.FromJacobian(&acc)
+	return res, nil
+}
+
+// AggregateVerifyMinSig verifies an aggregate signature of the messages
+// msgs[i] signed by pubs[i] respectively, by checking
+//
+//	∏ e(pubs[i], H(msgs[i])) = e(g, sig)
+//
+// with a single multi-pairing.
+func AggregateVerifyMinSig(pubs []PublicKeyMinSig, msgs [][]byte, sigBin []byte, hFunc hash.Hash) (bool, error) {
+	if len(pubs) != len(msgs) {
+		return false, errLengthMismatch
+	}
+	if len(pubs) == 0 {
+		return false, errNoPublicKeys
+	}
+	var sig SignatureMinSig
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	pks := make([]bls12381.G2Affine, len(pubs)+1)
+	hs := make([]bls12381.G1Affine, len(pubs)+1)
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return false, nil
+		}
+		h, err := hashToPointMinSig(msgs[i], hFunc, DSTSignatureMinSig)
+		if err != nil {
+			return false, err
+		}
+		pks[i].Set(&pubs[i].A)
+		hs[i] = h
+	}
+	_, _, _, g := bls12381.Generators()
+	pks[len(pubs)].Neg(&g)
+	hs[len(pubs)].Set(&sig.S)
+	return bls12381.PairingCheck(hs, pks)
+}
+
+// FastAggregateVerifyMinSig verifies an aggregate signature of the same
+// message by all the public keys. The public keys must come with a verified
+// proof of possession (see [PublicKeyMinSig.VerifyPossession]).
+func FastAggregateVerifyMinSig(pubs []PublicKeyMinSig, message []byte, sigBin []byte, hFunc hash.Hash) (bool, error) {
+	if len(pubs) == 0 {
+		return false, errNoPublicKeys
+	}
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return false, nil
+		}
+	}
+	aggPub, err := AggregatePublicKeysMinSig(pubs)
+	if err != nil {
+		return false, err
+	}
+	return aggPub.Verify(sigBin, message, hFunc)
+}
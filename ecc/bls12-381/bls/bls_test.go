@@ -0,0 +1,394 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestBLS(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = 10
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] test the signing and verification", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+			wrong, _ := publicKey.Verify(sig, []byte("wrong message"), hFunc)
+
+			return flag && !wrong
+		},
+	))
+
+	properties.Property("[BLS12-381] test the signing and verification (no pre-hash)", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			sig, _ := privKey.Sign(msg, nil)
+			flag, _ := publicKey.Verify(sig, msg, nil)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-381] test the proof of possession", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			other, _ := GenerateKey(rand.Reader)
+
+			proof, _ := privKey.ProvePossession()
+			flag, _ := privKey.PublicKey.VerifyPossession(proof)
+			wrong, _ := other.PublicKey.VerifyPossession(proof)
+
+			// a signature on the public key bytes is not a valid proof of possession
+			sig, _ := privKey.Sign(privKey.PublicKey.Bytes(), nil)
+			wrongDST, _ := privKey.PublicKey.VerifyPossession(sig)
+
+			return flag && !wrong && !wrongDST
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+	const n = 4
+	privKeys := make([]*PrivateKey, n)
+	pubKeys := make([]PublicKey, n)
+	msgs := make([][]byte, n)
+	sameMsgSigs := make([][]byte, n)
+	distinctMsgSigs := make([][]byte, n)
+	msg := []byte("common message")
+	for i := range privKeys {
+		var err error
+		privKeys[i], err = GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKeys[i].PublicKey
+		msgs[i] = []byte{byte(i), 'm', 's', 'g'}
+		if sameMsgSigs[i], err = privKeys[i].Sign(msg, nil); err != nil {
+			t.Fatal(err)
+		}
+		if distinctMsgSigs[i], err = privKeys[i].Sign(msgs[i], nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("fast_aggregate_verify", func(t *testing.T) {
+		aggSig, err := Aggregate(sameMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := FastAggregateVerify(pubKeys, msg, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		ok, _ = FastAggregateVerify(pubKeys[1:], msg, aggSig, nil)
+		if ok {
+			t.Fatal("aggregate signature should not verify with a missing key")
+		}
+	})
+
+	t.Run("aggregate_verify", func(t *testing.T) {
+		aggSig, err := Aggregate(distinctMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := AggregateVerify(pubKeys, msgs, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		ok, _ = AggregateVerify(pubKeys, msgs, aggSig, nil)
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		if ok {
+			t.Fatal("aggregate signature should not verify with swapped messages")
+		}
+		if _, err = AggregateVerify(pubKeys, msgs[1:], aggSig, nil); err != errLengthMismatch {
+			t.Fatal("expected length mismatch error")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if _, err := Aggregate(nil); err != errNoSignatures {
+			t.Fatal("expected error on empty signature list")
+		}
+		if _, err := AggregatePublicKeys(nil); err != errNoPublicKeys {
+			t.Fatal("expected error on empty public key list")
+		}
+	})
+
+	t.Run("identity_public_key", func(t *testing.T) {
+		var pk PublicKey
+		ok, _ := pk.Verify(sameMsgSigs[0], msg, nil)
+		if ok {
+			t.Fatal("identity public key should be rejected")
+		}
+	})
+}
+
+func BenchmarkSign(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking BLS sign()")
+	b.ResetTimer()
+	for range b.N {
+		privKey.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	msg := []byte("benchmarking BLS verify()")
+	sig, _ := privKey.Sign(msg, nil)
+	b.ResetTimer()
+	for range b.N {
+		privKey.PublicKey.Verify(sig, msg, nil)
+	}
+}
+
+func TestBLSMinSig(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 2
+	} else {
+		parameters.MinSuccessfulTests = 10
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] test the signing and verification", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKeyMinSig(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+			wrong, _ := publicKey.Verify(sig, []byte("wrong message"), hFunc)
+
+			return flag && !wrong
+		},
+	))
+
+	properties.Property("[BLS12-381] test the signing and verification (no pre-hash)", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKeyMinSig(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			sig, _ := privKey.Sign(msg, nil)
+			flag, _ := publicKey.Verify(sig, msg, nil)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-381] test the proof of possession", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKeyMinSig(rand.Reader)
+			other, _ := GenerateKeyMinSig(rand.Reader)
+
+			proof, _ := privKey.ProvePossession()
+			flag, _ := privKey.PublicKey.VerifyPossession(proof)
+			wrong, _ := other.PublicKey.VerifyPossession(proof)
+
+			// a signature on the public key bytes is not a valid proof of possession
+			sig, _ := privKey.Sign(privKey.PublicKey.Bytes(), nil)
+			wrongDST, _ := privKey.PublicKey.VerifyPossession(sig)
+
+			return flag && !wrong && !wrongDST
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestAggregateMinSig(t *testing.T) {
+	t.Parallel()
+	const n = 4
+	privKeys := make([]*PrivateKeyMinSig, n)
+	pubKeys := make([]PublicKeyMinSig, n)
+	msgs := make([][]byte, n)
+	sameMsgSigs := make([][]byte, n)
+	distinctMsgSigs := make([][]byte, n)
+	msg := []byte("common message")
+	for i := range privKeys {
+		var err error
+		privKeys[i], err = GenerateKeyMinSig(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKeys[i].PublicKey
+		msgs[i] = []byte{byte(i), 'm', 's', 'g'}
+		if sameMsgSigs[i], err = privKeys[i].Sign(msg, nil); err != nil {
+			t.Fatal(err)
+		}
+		if distinctMsgSigs[i], err = privKeys[i].Sign(msgs[i], nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("fast_aggregate_verify", func(t *testing.T) {
+		aggSig, err := AggregateMinSig(sameMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := FastAggregateVerifyMinSig(pubKeys, msg, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		ok, _ = FastAggregateVerifyMinSig(pubKeys[1:], msg, aggSig, nil)
+		if ok {
+			t.Fatal("aggregate signature should not verify with a missing key")
+		}
+	})
+
+	t.Run("aggregate_verify", func(t *testing.T) {
+		aggSig, err := AggregateMinSig(distinctMsgSigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := AggregateVerifyMinSig(pubKeys, msgs, aggSig, nil)
+		if err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		ok, _ = AggregateVerifyMinSig(pubKeys, msgs, aggSig, nil)
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		if ok {
+			t.Fatal("aggregate signature should not verify with swapped messages")
+		}
+		if _, err = AggregateVerifyMinSig(pubKeys, msgs[1:], aggSig, nil); err != errLengthMismatch {
+			t.Fatal("expected length mismatch error")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if _, err := AggregateMinSig(nil); err != errNoSignatures {
+			t.Fatal("expected error on empty signature list")
+		}
+		if _, err := AggregatePublicKeysMinSig(nil); err != errNoPublicKeys {
+			t.Fatal("expected error on empty public key list")
+		}
+	})
+
+	t.Run("identity_public_key", func(t *testing.T) {
+		var pk PublicKeyMinSig
+		ok, _ := pk.Verify(sameMsgSigs[0], msg, nil)
+		if ok {
+			t.Fatal("identity public key should be rejected")
+		}
+	})
+}
+
+func BenchmarkSignMinSig(b *testing.B) {
+	privKey, _ := GenerateKeyMinSig(rand.Reader)
+	msg := []byte("benchmarking BLS sign()")
+	b.ResetTimer()
+	for range b.N {
+		privKey.Sign(msg, nil)
+	}
+}
+
+func BenchmarkVerifyMinSig(b *testing.B) {
+	privKey, _ := GenerateKeyMinSig(rand.Reader)
+	msg := []byte("benchmarking BLS verify()")
+	sig, _ := privKey.Sign(msg, nil)
+	b.ResetTimer()
+	for range b.N {
+		privKey.PublicKey.Verify(sig, msg, nil)
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+	t.Parallel()
+	ikm := make([]byte, 32)
+	if _, err := KeyGen(ikm[:31], nil); err != errShortIKM {
+		t.Fatal("expected error for short input keying material")
+	}
+	sk1, err := KeyGen(ikm, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk2, err := KeyGenMinSig(ikm, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.scalar != sk2.scalar {
+		t.Fatal("both variants should derive the same secret scalar")
+	}
+	sk3, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.scalar == sk3.scalar {
+		t.Fatal("key info should change the secret scalar")
+	}
+}
+
+// test vectors from https://github.com/ethereum/bls12-381-tests
+func TestSignVectors(t *testing.T) {
+	t.Parallel()
+	vectors := []struct {
+		privKey, pubKey, message, signature string
+	}{
+		{
+			privKey:   "263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3",
+			pubKey:    "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a",
+			message:   "5656565656565656565656565656565656565656565656565656565656565656",
+			signature: "882730e5d03f6b42c3abc26d3372625034e1d871b65a8a6b900a56dae22da98abbe1b68f85e49fe7652a55ec3d0591c20767677e33e5cbb1207315c41a9ac03be39c2e7668edc043d6cb1d9fd93033caa8a1c5b0e84bedaeb6c64972503a43eb",
+		},
+		{
+			privKey:   "328388aff0d4a5b7dc9205abd374e7e98f3cd9f3418edb4eafda5fb16473d216",
+			message:   "abababababababababababababababababababababababababababababababab",
+			signature: "ae82747ddeefe4fd64cf9cedb9b04ae3e8a43420cd255e3c7cd06a8d88b7c7f8638543719981c5d16fa3527c468c25f0026704a6951bde891360c7e8d12ddee0559004ccdbe6046b55bae1b257ee97f7cdb955773d7cf29adf3ccbb9975e4eb9",
+		},
+	}
+	for _, v := range vectors {
+		var sk PrivateKey
+		scalar, _ := hex.DecodeString(v.privKey)
+		copy(sk.scalar[:], scalar)
+		sk.PublicKey.A.ScalarMultiplicationBase(new(big.Int).SetBytes(scalar))
+		if v.pubKey != "" && hex.EncodeToString(sk.PublicKey.Bytes()) != v.pubKey {
+			t.Fatal("wrong public key")
+		}
+		msg, _ := hex.DecodeString(v.message)
+		sig, err := sk.Sign(msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sig) != v.signature {
+			t.Fatal("wrong signature")
+		}
+		ok, err := sk.PublicKey.Verify(sig, msg, nil)
+		if err != nil || !ok {
+			t.Fatal("signature should verify")
+		}
+	}
+}
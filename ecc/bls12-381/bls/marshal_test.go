@@ -0,0 +1,98 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+func TestSerialization(t *testing.T) {
+	t.Parallel()
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pk PublicKey
+	n, err := pk.SetBytes(privKey.PublicKey.Bytes())
+	if err != nil || n != sizePublicKey {
+		t.Fatal("public key deserialization failed")
+	}
+	if !pk.Equal(privKey.Public()) {
+		t.Fatal("public key round trip failed")
+	}
+	if _, err = pk.SetBytes(privKey.PublicKey.Bytes()[:sizePublicKey-1]); err != io.ErrShortBuffer {
+		t.Fatal("expected short buffer error")
+	}
+
+	var sk PrivateKey
+	n, err = sk.SetBytes(privKey.Bytes())
+	if err != nil || n != sizePrivateKey {
+		t.Fatal("private key deserialization failed")
+	}
+	if sk.scalar != privKey.scalar || !sk.PublicKey.Equal(&privKey.PublicKey) {
+		t.Fatal("private key round trip failed")
+	}
+
+	msg := []byte("serialization")
+	sigBin, err := privKey.Sign(msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sig Signature
+	n, err = sig.SetBytes(sigBin)
+	if err != nil || n != sizeSignature {
+		t.Fatal("signature deserialization failed")
+	}
+	if _, err = sig.SetBytes(append(sigBin, 0)); err != errWrongSize {
+		t.Fatal("expected wrong size error")
+	}
+}
+
+func TestSerializationMinSig(t *testing.T) {
+	t.Parallel()
+	privKey, err := GenerateKeyMinSig(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pk PublicKeyMinSig
+	n, err := pk.SetBytes(privKey.PublicKey.Bytes())
+	if err != nil || n != sizePublicKeyMinSig {
+		t.Fatal("public key deserialization failed")
+	}
+	if !pk.Equal(privKey.Public()) {
+		t.Fatal("public key round trip failed")
+	}
+	if _, err = pk.SetBytes(privKey.PublicKey.Bytes()[:sizePublicKeyMinSig-1]); err != io.ErrShortBuffer {
+		t.Fatal("expected short buffer error")
+	}
+
+	var sk PrivateKeyMinSig
+	n, err = sk.SetBytes(privKey.Bytes())
+	if err != nil || n != sizePrivateKeyMinSig {
+		t.Fatal("private key deserialization failed")
+	}
+	if sk.scalar != privKey.scalar || !sk.PublicKey.Equal(&privKey.PublicKey) {
+		t.Fatal("private key round trip failed")
+	}
+
+	msg := []byte("serialization")
+	sigBin, err := privKey.Sign(msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sig SignatureMinSig
+	n, err = sig.SetBytes(sigBin)
+	if err != nil || n != sizeSignatureMinSig {
+		t.Fatal("signature deserialization failed")
+	}
+	if _, err = sig.SetBytes(append(sigBin, 0)); err != errWrongSize {
+		t.Fatal("expected wrong size error")
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/poseidon2"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
 	"github.com/consensys/gnark-crypto/utils/testutils"
 	"github.com/stretchr/testify/require"
@@ -120,6 +121,39 @@ func TestFflonk(t *testing.T) {
 
 }
 
+func TestFflonkWithTranscript(t *testing.T) {
+
+	assert := require.New(t)
+
+	nbSets := 3
+	p := make([][][]fr.Element, nbSets)
+	x := make([][]fr.Element, nbSets)
+	digests := make([]kzg.Digest, nbSets)
+	var err error
+	for i := range nbSets {
+		p[i] = make([][]fr.Element, i+2)
+		for j := range p[i] {
+			p[i][j] = make([]fr.Element, j+10)
+			fr.Vector(p[i][j]).MustSetRandom()
+		}
+		x[i] = make([]fr.Element, i+1)
+		fr.Vector(x[i]).MustSetRandom()
+		digests[i], err = FoldAndCommit(p[i], testSrs.Pk)
+		assert.NoError(err)
+	}
+
+	proof, err := BatchOpenWithTranscript(p, digests, x, poseidon2.NewTranscript("test"), testSrs.Pk)
+	assert.NoError(err)
+	err = BatchVerifyWithTranscript(proof, digests, x, poseidon2.NewTranscript("test"), testSrs.Vk)
+	assert.NoError(err)
+
+	// tamper the proof
+	proof.ClaimedValues[0][0][0].MustSetRandom()
+	err = BatchVerifyWithTranscript(proof, digests, x, poseidon2.NewTranscript("test"), testSrs.Vk)
+	assert.Error(err)
+
+}
+
 func TestCommit(t *testing.T) {
 
 	assert := require.New(t)
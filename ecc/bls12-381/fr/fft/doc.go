@@ -4,5 +4,6 @@
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
 // Package fft provides in-place discrete Fourier transform on powers-of-two subgroups
-// of 𝔽ᵣˣ (the multiplicative group (ℤ/rℤ, x) ).
+// of 𝔽ᵣˣ (the multiplicative group (ℤ/rℤ, x) ), and on mixed-radix subgroups whose order is
+// a product of small primes dividing r-1, such as 2ᵏ⋅3ʲ.
 package fft
@@ -0,0 +1,417 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/parallel"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// ErrMixedRadixSize is returned when no mixed-radix domain of the requested size exists.
+var ErrMixedRadixSize = errors.New("the cardinality of a mixed-radix domain must divide r-1 and have no prime factor larger than 7")
+
+// maxRadix is the largest prime factor of the cardinality of a mixed-radix domain
+const maxRadix = 7
+
+// the FFT stages on a mixed-radix domain are run block by block while they only
+// combine elements of blocks of at most this size
+const mixedRadixBlockSize = 1 << 12
+
+// MixedRadixDomain with a cardinality which is a product of small primes dividing
+// r-1, such as 2ᵏ⋅3ʲ, instead of a power of 2.
+// The FFT on it is a mixed-radix Cooley-Tukey FFT, with a stage per prime factor
+// of the cardinality, using radix-2, radix-3 or generic butterflies.
+// Unlike on Domain, the inputs and outputs of the FFTs are in natural order.
+type MixedRadixDomain struct {
+	Cardinality            uint64
+	CardinalityInv         fr.Element
+	Generator              fr.Element
+	GeneratorInv           fr.Element
+	FrMultiplicativeGen    fr.Element // generator of Fr*
+	FrMultiplicativeGenInv fr.Element
+
+	// prime factors of the cardinality, in the order of the FFT stages
+	radices []uint64
+
+	// twiddles[s][j-1][k] = ωₘₚʲᵏ for the stage s, of radix p, which combines
+	// transforms of size m
+	twiddles [][][]fr.Element
+
+	// roots[s][t] = ωₚᵗ for the stage s of radix p
+	roots [][]fr.Element
+
+	// (ω₃-ω₃²)/2 where ω₃ is the cube root of unity Generator^(Cardinality/3)
+	radix3 fr.Element
+
+	// cosetTable <1, u, u², ..., uⁿ⁻¹> where u is the shifting element
+	cosetTable []fr.Element
+
+	// cosetTableInv same as cosetTable but with u⁻¹
+	cosetTableInv []fr.Element
+}
+
+// NewMixedRadixDomain returns the subgroup of 𝔽ᵣˣ of cardinality m, which must
+// divide r-1 and have no prime factor larger than 7.
+// NextMixedRadixSize rounds a size up to a valid cardinality.
+//
+// Only the WithShift option is taken into account; the twiddles and the coset
+// tables are always precomputed.
+func NewMixedRadixDomain(m uint64, opts ...DomainOption) (*MixedRadixDomain, error) {
+	opt := domainOptions(opts...)
+
+	radices, err := mixedRadices(m)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := &MixedRadixDomain{
+		Cardinality: m,
+		radices:     radices,
+	}
+	domain.FrMultiplicativeGen = GeneratorFullMultiplicativeGroup()
+	if opt.shift != nil {
+		domain.FrMultiplicativeGen.Set(opt.shift)
+	}
+	domain.FrMultiplicativeGenInv.Inverse(&domain.FrMultiplicativeGen)
+
+	// Generator = g^((r-1)/m) has order m since g generates 𝔽ᵣˣ
+	var e big.Int
+	e.Sub(fr.Modulus(), big.NewInt(1))
+	e.Quo(&e, new(big.Int).SetUint64(m))
+	g := GeneratorFullMultiplicativeGroup()
+	domain.Generator.Exp(g, &e)
+	domain.GeneratorInv.Inverse(&domain.Generator)
+	domain.CardinalityInv.SetUint64(m).Inverse(&domain.CardinalityInv)
+
+	// twiddle factors and coset tables
+	var wg sync.WaitGroup
+	domain.cosetTable = make([]fr.Element, m)
+	domain.cosetTableInv = make([]fr.Element, m)
+	wg.Go(func() { BuildExpTable(domain.FrMultiplicativeGen, domain.cosetTable) })
+	wg.Go(func() { BuildExpTable(domain.FrMultiplicativeGenInv, domain.cosetTableInv) })
+	wg.Go(domain.preComputeTwiddles)
+	wg.Wait()
+
+	if m%3 == 0 {
+		var w, w2 fr.Element
+		w.Exp(domain.Generator, new(big.Int).SetUint64(m/3))
+		w2.Square(&w)
+		domain.radix3.Sub(&w, &w2).Halve()
+	}
+
+	return domain, nil
+}
+
+func (domain *MixedRadixDomain) preComputeTwiddles() {
+	n := domain.Cardinality
+	domain.twiddles = make([][][]fr.Element, len(domain.radices))
+	domain.roots = make([][]fr.Element, len(domain.radices))
+
+	m := uint64(1)
+	for s, p := range domain.radices {
+		var w, wj fr.Element // ωₘₚ and ωₘₚʲ
+		w.Exp(domain.Generator, new(big.Int).SetUint64(n/(m*p)))
+
+		domain.roots[s] = make([]fr.Element, p)
+		wj.Exp(w, new(big.Int).SetUint64(m))
+		BuildExpTable(wj, domain.roots[s])
+
+		domain.twiddles[s] = make([][]fr.Element, p-1)
+		wj.Set(&w)
+		for j := range p - 1 {
+			domain.twiddles[s][j] = make([]fr.Element, m)
+			BuildExpTable(wj, domain.twiddles[s][j])
+			wj.Mul(&wj, &w)
+		}
+		m *= p
+	}
+}
+
+// NextMixedRadixSize returns the smallest cardinality of a mixed-radix domain
+// larger than or equal to m, or an error if there is none.
+func NextMixedRadixSize(m uint64) (uint64, error) {
+	factors := smallFactors()
+	best := uint64(0)
+
+	// enumerate the products of the small factors of r-1, by increasing prime
+	var search func(p, n uint64)
+	search = func(p, n uint64) {
+		if n >= m {
+			if best == 0 || n < best {
+				best = n
+			}
+			return
+		}
+		if p > maxRadix {
+			return
+		}
+		for e := 0; e <= factors[p]; e++ {
+			search(p+1, n)
+			if n >= m || n > math.MaxUint64/p {
+				return
+			}
+			n *= p
+		}
+	}
+	search(2, 1)
+
+	if best == 0 {
+		return 0, ErrMixedRadixSize
+	}
+	return best, nil
+}
+
+// FFT computes the discrete Fourier transform of a and stores the result in a.
+// The size of a must be the cardinality of the domain; the input and the output
+// are in natural order.
+func (domain *MixedRadixDomain) FFT(a []fr.Element, opts ...Option) {
+	opt := fftOptions(opts)
+	domain.checkSize(a)
+
+	if opt.coset {
+		scaleByTable(a, domain.cosetTable, opt.nbTasks)
+	}
+	domain.transform(a, opt.nbTasks)
+}
+
+// FFTInverse computes the inverse discrete Fourier transform of a and stores the
+// result in a. The size of a must be the cardinality of the domain; the input and
+// the output are in natural order.
+func (domain *MixedRadixDomain) FFTInverse(a []fr.Element, opts ...Option) {
+	opt := fftOptions(opts)
+	domain.checkSize(a)
+
+	// the inverse DFT of a at i is the DFT of a at -i, divided by n
+	domain.transform(a, opt.nbTasks)
+	slices.Reverse(a[1:])
+	parallel.Execute(len(a), func(start, end int) {
+		for i := start; i < end; i++ {
+			a[i].Mul(&a[i], &domain.CardinalityInv)
+		}
+	}, opt.nbTasks)
+	if opt.coset {
+		scaleByTable(a, domain.cosetTableInv, opt.nbTasks)
+	}
+}
+
+func (domain *MixedRadixDomain) checkSize(a []fr.Element) {
+	if uint64(len(a)) != domain.Cardinality {
+		panic("fft: the size of the input must be the cardinality of the domain")
+	}
+}
+
+// transform computes in place the FFT of a, without the coset shift.
+//
+// The input is first permuted in digit-reversed order, then each stage of radix
+// p combines p consecutive transforms of size m into a transform of size m⋅p:
+// with Yⱼ the j-th transform, the output is X[k+t⋅m] = ∑ⱼ ωₘₚʲᵏ⋅ωₚʲᵗ⋅Yⱼ[k].
+func (domain *MixedRadixDomain) transform(a []fr.Element, nbTasks int) {
+	n := domain.Cardinality
+	radices := domain.radices
+	domain.digitReverse(a, nbTasks)
+
+	// the first stages only combine elements of blocks fitting in cache, they
+	// are run block by block
+	split, blockSize := 0, uint64(1)
+	for split < len(radices) && blockSize*radices[split] <= mixedRadixBlockSize {
+		blockSize *= radices[split]
+		split++
+	}
+	parallel.Execute(int(n/blockSize), func(start, end int) {
+		scratch := make([]fr.Element, maxRadix)
+		for i := uint64(start); i < uint64(end); i++ {
+			block := a[i*blockSize : (i+1)*blockSize]
+			m := uint64(1)
+			for s := range split {
+				domain.butterflies(block, s, m, 0, blockSize/radices[s], scratch)
+				m *= radices[s]
+			}
+		}
+	}, nbTasks)
+
+	// the next stages are run one after the other
+	m := blockSize
+	for s := split; s < len(radices); s++ {
+		parallel.Execute(int(n/radices[s]), func(start, end int) {
+			scratch := make([]fr.Element, maxRadix)
+			domain.butterflies(a, s, m, uint64(start), uint64(end), scratch)
+		}, nbTasks)
+		m *= radices[s]
+	}
+}
+
+// butterflies runs the butterflies start to end-1 of the stage s, which combines
+// transforms of size m of a. scratch is a buffer of size at least maxRadix.
+func (domain *MixedRadixDomain) butterflies(a []fr.Element, s int, m, start, end uint64, scratch []fr.Element) {
+	p := domain.radices[s]
+	twiddles, roots := domain.twiddles[s], domain.roots[s]
+
+	for b := start; b < end; {
+		// the butterflies b to b+l-1 are in the same transform of size m⋅p,
+		// their inputs are contiguous
+		k := b % m
+		l := min(m-k, end-b)
+		offset := (b-k)*p + k
+
+		// multiply by the twiddles ωₘₚʲᵏ
+		for j := uint64(1); j < p && m > 1; j++ {
+			v := fr.Vector(a[offset+j*m : offset+j*m+l])
+			v.Mul(v, twiddles[j-1][k:k+l])
+		}
+
+		switch p {
+		case 2:
+			for i := offset; i < offset+l; i++ {
+				fr.Butterfly(&a[i], &a[i+m])
+			}
+		case 3:
+			for i := offset; i < offset+l; i++ {
+				butterfly3(&a[i], &a[i+m], &a[i+2*m], &domain.radix3)
+			}
+		default:
+			for i := offset; i < offset+l; i++ {
+				butterflyGeneric(a[i:], m, roots, scratch[:p])
+			}
+		}
+		b += l
+	}
+}
+
+// digitReverse permutes a such that a[i] moves to the position whose digits in
+// the mixed radix basis of the domain are those of i, in reverse order.
+func (domain *MixedRadixDomain) digitReverse(a []fr.Element, nbTasks int) {
+	if len(domain.radices) <= 1 {
+		return
+	}
+	n := domain.Cardinality
+	radices := domain.radices
+	k := len(radices)
+
+	// weights[s] is the place value in the reversed index of the digit of radix
+	// radices[s], which is the (k-1-s)-th digit of the index
+	weights := make([]uint64, k)
+	weights[0] = 1
+	for s := 1; s < k; s++ {
+		weights[s] = weights[s-1] * radices[s-1]
+	}
+
+	tmp := make([]fr.Element, n)
+	parallel.Execute(int(n), func(start, end int) {
+		// digits of start, and the corresponding reversed index
+		digits := make([]uint64, k)
+		idx, pos := uint64(start), uint64(0)
+		for s := k - 1; s >= 0; s-- {
+			digits[s] = idx % radices[s]
+			pos += digits[s] * weights[s]
+			idx /= radices[s]
+		}
+		for i := start; i < end; i++ {
+			tmp[pos] = a[i]
+
+			// increment the digits, least significant first
+			for s := k - 1; s >= 0; s-- {
+				digits[s]++
+				pos += weights[s]
+				if digits[s] < radices[s] {
+					break
+				}
+				digits[s] = 0
+				pos -= radices[s] * weights[s]
+			}
+		}
+	}, nbTasks)
+	copy(a, tmp)
+}
+
+// butterfly3 computes the DFT of size 3 of (x₀, x₁, x₂) in place, with
+// c = (ω₃-ω₃²)/2: since ω₃+ω₃² = -1, with s = x₁+x₂ and d = x₁-x₂,
+// X₁ = x₀-s/2+c⋅d and X₂ = x₀-s/2-c⋅d.
+func butterfly3(x0, x1, x2, c *fr.Element) {
+	var s, d fr.Element
+	s.Add(x1, x2)
+	d.Sub(x1, x2).Mul(&d, c)
+	x1.Set(x0)
+	x0.Add(x0, &s)
+	s.Halve()
+	x1.Sub(x1, &s)
+	x2.Sub(x1, &d)
+	x1.Add(x1, &d)
+}
+
+// butterflyGeneric computes in place the DFT of size p = len(roots) of the
+// elements of x at the indices 0, m, …, (p-1)⋅m, where roots are the p-th roots of
+// unity. res is a scratch buffer of size p.
+func butterflyGeneric(x []fr.Element, m uint64, roots, res []fr.Element) {
+	p := uint64(len(roots))
+	var t fr.Element
+	for i := range p {
+		res[i] = x[0]
+		for j := uint64(1); j < p; j++ {
+			t.Mul(&x[j*m], &roots[i*j%p])
+			res[i].Add(&res[i], &t)
+		}
+	}
+	for i := range p {
+		x[i*m] = res[i]
+	}
+}
+
+// scaleByTable multiplies a by table, element-wise.
+func scaleByTable(a, table []fr.Element, nbTasks int) {
+	parallel.Execute(len(a), func(start, end int) {
+		v := fr.Vector(a[start:end])
+		v.Mul(v, table[start:end])
+	}, nbTasks)
+}
+
+// mixedRadices returns the prime factors of m, or an error if m is not the
+// cardinality of a mixed-radix domain.
+func mixedRadices(m uint64) ([]uint64, error) {
+	if m == 0 {
+		return nil, ErrMixedRadixSize
+	}
+	factors := smallFactors()
+	var radices []uint64
+	for p := uint64(2); p <= maxRadix; p++ {
+		for e := 0; m%p == 0; e++ {
+			if e == factors[p] {
+				return nil, ErrMixedRadixSize
+			}
+			radices = append(radices, p)
+			m /= p
+		}
+	}
+	if m != 1 {
+		return nil, ErrMixedRadixSize
+	}
+	return radices, nil
+}
+
+// smallFactors returns the valuations in r-1 of the primes up to maxRadix.
+var smallFactors = sync.OnceValue(func() map[uint64]int {
+	res := make(map[uint64]int)
+	var n, q, r, bp big.Int
+	n.Sub(fr.Modulus(), big.NewInt(1))
+	for p := uint64(2); p <= maxRadix; p++ {
+		bp.SetUint64(p)
+		for {
+			q.QuoRem(&n, &bp, &r)
+			if r.Sign() != 0 {
+				break
+			}
+			n.Set(&q)
+			res[p]++
+		}
+	}
+	return res
+})
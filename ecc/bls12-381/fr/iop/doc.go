@@ -4,5 +4,5 @@
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
 // Package iop provides an API to computations common
-// to iop backends (permutation, quotient).
+// to iop backends (permutation, lookup, quotient).
 package iop
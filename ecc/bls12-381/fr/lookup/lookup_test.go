@@ -0,0 +1,96 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package lookup
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+func TestLookupProof(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	assert.NoError(t, err)
+
+	// t2 contains a repeated entry, t1 only entries of t2
+	t1 := make([]fr.Element, 16)
+	t2 := make([]fr.Element, 16)
+	for i := range t2 {
+		t2[i].SetUint64(uint64(4*i + 1))
+	}
+	t2[15].Set(&t2[3])
+	for i := range t1 {
+		t1[i].Set(&t2[(i*i)%7])
+	}
+
+	// correct proof
+	proof, err := ProveLookup(kzgSrs.Pk, t1, t2)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyLookup(kzgSrs.Vk, proof))
+
+	// serialization
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	assert.NoError(t, err)
+	var read LookupProof
+	n, err := read.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, written, n)
+	assert.Equal(t, proof, read)
+	assert.NoError(t, VerifyLookup(kzgSrs.Vk, read))
+
+	// wrong proofs
+	{
+		wrong := proof
+		wrong.BatchedProof.ClaimedValues = append([]fr.Element{}, proof.BatchedProof.ClaimedValues...)
+		wrong.BatchedProof.ClaimedValues[2].SetOne()
+		assert.Error(t, VerifyLookup(kzgSrs.Vk, wrong))
+
+		wrong = proof
+		wrong.M.Set(&proof.T1)
+		assert.Error(t, VerifyLookup(kzgSrs.Vk, wrong))
+
+		wrong = proof
+		wrong.BatchedProof.ClaimedValues = proof.BatchedProof.ClaimedValues[1:]
+		assert.ErrorIs(t, VerifyLookup(kzgSrs.Vk, wrong), ErrProofShape)
+	}
+
+	// entries not in the table
+	t1[5].SetUint64(2)
+	_, err = ProveLookup(kzgSrs.Pk, t1, t2)
+	assert.ErrorIs(t, err, ErrNotInTable)
+	_, err = ProveLookup(kzgSrs.Pk, t1[:5], t2[:5])
+	assert.ErrorIs(t, err, ErrSize)
+	_, err = ProveLookup(kzgSrs.Pk, t1, t2[:8])
+	assert.ErrorIs(t, err, ErrIncompatibleSize)
+}
+
+func BenchmarkProveLookup(b *testing.B) {
+
+	srsSize := 1 << 15
+	polySize := 1 << 14
+
+	kzgSrs, _ := kzg.NewSRS(uint64(srsSize), big.NewInt(13))
+	t1 := make([]fr.Element, polySize)
+	t2 := make([]fr.Element, polySize)
+
+	for i := range polySize {
+		t2[i].SetUint64(uint64(i))
+	}
+	for i := range polySize {
+		t1[i].Set(&t2[(5*i)%(polySize/4)])
+	}
+
+	for b.Loop() {
+		ProveLookup(kzgSrs.Pk, t1, t2)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package lookup
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+func TestMultisetProof(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	assert.NoError(t, err)
+
+	// the rows of t2 are the rows of t1, permuted
+	const nbColumns, size = 3, 8
+	t1 := make([][]fr.Element, nbColumns)
+	t2 := make([][]fr.Element, nbColumns)
+	for j := range nbColumns {
+		t1[j] = make([]fr.Element, size)
+		t2[j] = make([]fr.Element, size)
+		for i := range size {
+			t1[j][i].SetUint64(uint64(4*i + j))
+		}
+		for i := range size {
+			t2[j][i].Set(&t1[j][(5*i)%size])
+		}
+	}
+
+	// correct proof
+	proof, err := ProveMultisetEquality(kzgSrs.Pk, t1, t2)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyMultisetEquality(kzgSrs.Vk, proof))
+
+	// serialization
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	assert.NoError(t, err)
+	var read MultisetProof
+	n, err := read.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, written, n)
+	assert.Equal(t, proof, read)
+	assert.NoError(t, VerifyMultisetEquality(kzgSrs.Vk, read))
+
+	// the columns are permuted separately: each column is a permutation of the
+	// same column of t1, but the rows are not
+	{
+		t2[1][0], t2[1][1] = t2[1][1], t2[1][0]
+		proof, err := ProveMultisetEquality(kzgSrs.Pk, t1, t2)
+		assert.NoError(t, err)
+		assert.Error(t, VerifyMultisetEquality(kzgSrs.Vk, proof))
+		t2[1][0], t2[1][1] = t2[1][1], t2[1][0]
+	}
+
+	// wrong proof
+	{
+		t1[0][0].MustSetRandom()
+		proof, err := ProveMultisetEquality(kzgSrs.Pk, t1, t2)
+		assert.NoError(t, err)
+		assert.Error(t, VerifyMultisetEquality(kzgSrs.Vk, proof))
+	}
+
+	_, err = ProveMultisetEquality(kzgSrs.Pk, t1, t2[1:])
+	assert.ErrorIs(t, err, ErrNumberColumns)
+}
+
+func BenchmarkProveMultisetEquality(b *testing.B) {
+
+	srsSize := 1 << 15
+	polySize := 1 << 14
+
+	kzgSrs, _ := kzg.NewSRS(uint64(srsSize), big.NewInt(13))
+	t1 := make([][]fr.Element, 2)
+	t2 := make([][]fr.Element, 2)
+	for j := range t1 {
+		t1[j] = make([]fr.Element, polySize)
+		t2[j] = make([]fr.Element, polySize)
+		for i := range polySize {
+			t1[j][i].SetUint64(uint64(i + j))
+		}
+		for i := range polySize {
+			t2[j][i].Set(&t1[j][(5*i)%(polySize)])
+		}
+	}
+
+	for b.Loop() {
+		ProveMultisetEquality(kzgSrs.Pk, t1, t2)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomPolynomial(n int) Polynomial {
+	p := make(Polynomial, n)
+	for i := range p {
+		p[i].MustSetRandom()
+	}
+	return p
+}
+
+func randomPoints(n int) []fr.Element {
+	points := make([]fr.Element, n)
+	for i := range points {
+		points[i].MustSetRandom()
+	}
+	return points
+}
+
+func TestMul(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, sizes := range [][2]int{{1, 1}, {3, 70}, {65, 65}, {100, 300}, {257, 1000}} {
+		p1 := randomPolynomial(sizes[0])
+		p2 := randomPolynomial(sizes[1])
+		expected := mulNaive(p1, p2)
+
+		var p Polynomial
+		p.Mul(p1, p2)
+		assert.True(p.Equal(expected), "multiplication of sizes %v", sizes)
+
+		// aliasing
+		p1.Mul(p1, p2)
+		assert.True(p1.Equal(expected), "multiplication of sizes %v in place", sizes)
+	}
+}
+
+func TestDivRem(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, sizes := range [][2]int{{1, 1}, {10, 20}, {20, 10}, {100, 30}, {300, 100}, {1000, 120}} {
+		a := randomPolynomial(sizes[0])
+		b := randomPolynomial(sizes[1])
+		q, r := DivRem(a, b)
+		assert.Equal(max(0, len(a)-len(b)+1), len(q))
+		assert.Equal(len(b)-1, len(r))
+
+		// a = q⋅b + r
+		var qb Polynomial
+		qb.Mul(q, b)
+		qb = trimTo(qb, len(a))
+		qb.Add(qb, trimTo(r, len(a)))
+		assert.True(qb.Equal(a), "division of sizes %v", sizes)
+
+		// same result as the long division
+		if len(a) >= len(b) {
+			qNaive, rNaive := divRemNaive(a, b)
+			assert.True(q.Equal(qNaive), "quotient of sizes %v", sizes)
+			assert.True(r.Equal(rNaive), "remainder of sizes %v", sizes)
+		}
+	}
+
+	// leading zeros are ignored
+	a := randomPolynomial(200)
+	b := append(randomPolynomial(100), make(Polynomial, 5)...)
+	q, r := DivRem(a, b)
+	assert.Equal(101, len(q))
+	assert.Equal(99, len(r))
+
+	assert.Panics(func() { DivRem(a, make(Polynomial, 3)) })
+}
+
+func TestDivideByVanishing(t *testing.T) {
+	assert := assert.New(t)
+
+	points := randomPoints(100)
+	z := BuildVanishing(points)
+	assert.Equal(101, len(z))
+	assert.True(z[100].IsOne())
+	for _, y := range EvaluateMultiPoint(z, points) {
+		assert.True(y.IsZero())
+	}
+
+	// p = z⋅h is divisible by z
+	h := randomPolynomial(150)
+	var p Polynomial
+	p.Mul(z, h)
+	q, r := DivideByVanishing(p, points)
+	assert.True(q.Equal(h))
+	for i := range r {
+		assert.True(r[i].IsZero())
+	}
+}
+
+func TestEvaluateMultiPoint(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, sizes := range [][2]int{{1, 10}, {10, 0}, {10, 10}, {200, 100}, {100, 333}, {513, 1000}} {
+		p := randomPolynomial(sizes[0])
+		points := randomPoints(sizes[1])
+		res := EvaluateMultiPoint(p, points)
+		assert.Equal(len(points), len(res))
+		for i := range points {
+			expected := p.Eval(&points[i])
+			assert.True(res[i].Equal(&expected), "evaluation %d of sizes %v", i, sizes)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, n := range []int{1, 2, 33, 100, 257} {
+		p := randomPolynomial(n)
+		points := randomPoints(n)
+		values := EvaluateMultiPoint(p, points)
+		f, err := Interpolate(points, values)
+		assert.NoError(err)
+		assert.True(f.Equal(p), "interpolation of size %d", n)
+	}
+
+	// errors
+	points := randomPoints(40)
+	_, err := Interpolate(points, randomPoints(39))
+	assert.ErrorIs(err, ErrInterpolationSize)
+	points[30] = points[7]
+	_, err = Interpolate(points, randomPoints(40))
+	assert.ErrorIs(err, ErrInterpolationPoints)
+}
+
+func BenchmarkMul(b *testing.B) {
+	const n = 1 << 14
+	p1 := randomPolynomial(n)
+	p2 := randomPolynomial(n)
+	var p Polynomial
+	b.ResetTimer()
+	for range b.N {
+		p.Mul(p1, p2)
+	}
+}
+
+func BenchmarkEvaluateMultiPoint(b *testing.B) {
+	const n = 1 << 12
+	p := randomPolynomial(n)
+	points := randomPoints(n)
+	b.ResetTimer()
+	for range b.N {
+		EvaluateMultiPoint(p, points)
+	}
+}
+
+func BenchmarkInterpolate(b *testing.B) {
+	const n = 1 << 12
+	points := randomPoints(n)
+	values := make([]fr.Element, n)
+	for i := range values {
+		values[i].MustSetRandom()
+	}
+	b.ResetTimer()
+	for range b.N {
+		_, _ = Interpolate(points, values)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon2
+
+import (
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// bytesPerElement is the number of bytes packed into a field element when absorbing bytes
+const bytesPerElement = (fr.Bits - 1) / 8
+
+// operations, absorbed with their label for domain separation
+const (
+	opDomain uint64 = iota + 1
+	opAbsorbElements
+	opAbsorbBytes
+	opAbsorbG1
+	opSqueezeElements
+	opSqueezeIndices
+)
+
+// Transcript is a Fiat-Shamir transcript based on a duplex sponge over the
+// Poseidon2 permutation, in overwrite mode. Values are absorbed as field
+// elements, and challenges are squeezed as field elements, which are uniformly
+// distributed without reducing a digest.
+//
+// Every operation first absorbs its type, its label and the number of values,
+// so that the sequence of operations is encoded injectively. Labels separate
+// the challenges of a protocol, and the domain separator given at creation
+// separates protocols.
+//
+// A Transcript is not safe for concurrent use.
+type Transcript struct {
+	perm   *Permutation
+	state  []fr.Element
+	rate   int
+	input  []fr.Element // absorbed elements, not yet in the state
+	output []fr.Element // squeezed elements, not yet returned, the next one being last
+}
+
+// NewTranscript returns a transcript over the Poseidon2 permutation of width 3,
+// with 6 full rounds and 50 partial rounds, and a capacity of 1.
+func NewTranscript(domainSeparator string) *Transcript {
+	return NewTranscriptWithPermutation(NewPermutation(3, 6, 50), 1, domainSeparator)
+}
+
+// NewTranscriptWithPermutation returns a transcript over the given permutation,
+// where capacity elements of the state are never absorbed into nor squeezed.
+// The capacity must be positive and smaller than the width of the permutation.
+func NewTranscriptWithPermutation(perm *Permutation, capacity int, domainSeparator string) *Transcript {
+	width := perm.params.Width
+	if capacity <= 0 || capacity >= width {
+		panic("poseidon2: the capacity must be between 1 and the width of the permutation minus 1")
+	}
+	t := &Transcript{
+		perm:  perm,
+		state: make([]fr.Element, width),
+		rate:  width - capacity,
+	}
+	t.header(opDomain, domainSeparator, 0)
+	return t
+}
+
+// Clone returns an independent copy of the transcript.
+func (t *Transcript) Clone() *Transcript {
+	return &Transcript{
+		perm:   t.perm,
+		state:  append([]fr.Element(nil), t.state...),
+		rate:   t.rate,
+		input:  append([]fr.Element(nil), t.input...),
+		output: append([]fr.Element(nil), t.output...),
+	}
+}
+
+// AbsorbElements absorbs the field elements x.
+func (t *Transcript) AbsorbElements(label string, x ...fr.Element) {
+	t.header(opAbsorbElements, label, len(x))
+	t.absorb(x...)
+}
+
+// AbsorbBytes absorbs b, packed into field elements of bytesPerElement bytes each.
+func (t *Transcript) AbsorbBytes(label string, b []byte) {
+	t.header(opAbsorbBytes, label, len(b))
+	t.absorbBytes(b)
+}
+
+// AbsorbG1 absorbs the points, in their compressed encoding.
+func (t *Transcript) AbsorbG1(label string, points ...curve.G1Affine) {
+	t.header(opAbsorbG1, label, len(points))
+	for i := range points {
+		b := points[i].Bytes()
+		t.absorbBytes(b[:])
+	}
+}
+
+// SqueezeElement returns a challenge in the field.
+func (t *Transcript) SqueezeElement(label string) fr.Element {
+	return t.SqueezeElements(label, 1)[0]
+}
+
+// SqueezeElements returns n challenges in the field.
+func (t *Transcript) SqueezeElements(label string, n int) []fr.Element {
+	t.header(opSqueezeElements, label, n)
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i] = t.squeeze()
+	}
+	return res
+}
+
+// SqueezeIndices returns n integers uniformly distributed in [0, 2ⁿᵇᴮⁱᵗˢ).
+// Squeezed elements which do not fall in a whole number of intervals of size
+// 2ⁿᵇᴮⁱᵗˢ are rejected, so that there is no bias.
+//
+// It panics if nbBits is not in [0, min(64, fr.Bits-1)].
+func (t *Transcript) SqueezeIndices(label string, nbBits, n int) []uint64 {
+	if nbBits < 0 || nbBits > 64 || nbBits >= fr.Bits {
+		panic("poseidon2: invalid number of bits")
+	}
+	t.header(opSqueezeIndices, label, n)
+	var e fr.Element
+	e.SetUint64(uint64(nbBits))
+	t.absorb(e)
+
+	// the largest multiple of 2ⁿᵇᴮⁱᵗˢ in [0, q]
+	bound := new(big.Int).Rsh(fr.Modulus(), uint(nbBits))
+	bound.Lsh(bound, uint(nbBits))
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(nbBits))
+	mask.Sub(mask, big.NewInt(1))
+
+	res := make([]uint64, n)
+	var v big.Int
+	for i := range res {
+		for {
+			e = t.squeeze()
+			e.BigInt(&v)
+			if v.Cmp(bound) < 0 {
+				break
+			}
+		}
+		res[i] = v.And(&v, mask).Uint64()
+	}
+	return res
+}
+
+// header absorbs the type of the operation, its label and its number of values
+func (t *Transcript) header(op uint64, label string, n int) {
+	var e [2]fr.Element
+	e[0].SetUint64(op)
+	e[1].SetUint64(uint64(len(label)))
+	t.absorb(e[:]...)
+	t.absorbBytes([]byte(label))
+	e[0].SetUint64(uint64(n))
+	t.absorb(e[0])
+}
+
+// absorbBytes absorbs b, packed into big-endian chunks of bytesPerElement bytes,
+// the last one possibly shorter. The length of b is absorbed by the caller.
+func (t *Transcript) absorbBytes(b []byte) {
+	var e fr.Element
+	for len(b) > 0 {
+		n := min(len(b), bytesPerElement)
+		e.SetBytes(b[:n])
+		t.absorb(e)
+		b = b[n:]
+	}
+}
+
+// absorb adds the elements to the input, and applies the permutation on each full block
+func (t *Transcript) absorb(x ...fr.Element) {
+	t.output = t.output[:0]
+	for i := range x {
+		t.input = append(t.input, x[i])
+		if len(t.input) == t.rate {
+			t.duplex()
+		}
+	}
+}
+
+// squeeze returns the next element of the output, applying the permutation
+// first if some elements were absorbed since the last one.
+func (t *Transcript) squeeze() fr.Element {
+	if len(t.input) != 0 || len(t.output) == 0 {
+		t.duplex()
+	}
+	res := t.output[len(t.output)-1]
+	t.output = t.output[:len(t.output)-1]
+	return res
+}
+
+// duplex overwrites the rate part of the state with the input, applies the
+// permutation and makes the rate part of the state the output.
+func (t *Transcript) duplex() {
+	copy(t.state, t.input)
+	t.input = t.input[:0]
+	if err := t.perm.Permutation(t.state); err != nil {
+		panic(err) // the state has the width of the permutation
+	}
+	t.output = append(t.output[:0], t.state[:t.rate]...)
+}
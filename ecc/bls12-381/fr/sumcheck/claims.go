@@ -0,0 +1,277 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/polynomial"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+var (
+	errNoClaims           = errors.New("sumcheck: no claims")
+	errNoInputs           = errors.New("sumcheck: claims must have at least one input")
+	errInconsistentInputs = errors.New("sumcheck: all claims must have the same number of inputs of the same size")
+	errNotPowerOfTwo      = errors.New("sumcheck: input size must be a power of two, at least 2")
+	errWrongFinalEval     = errors.New("sumcheck: wrong number of final evaluations")
+	errFinalEvalMismatch  = errors.New("sumcheck: final evaluations do not match the last round")
+)
+
+// Gate is a low degree multivariate polynomial g, combining the values of
+// multilinear polynomials at a point.
+type Gate interface {
+	// Evaluate returns g(x₁, …, xₖ). It must not modify its inputs.
+	Evaluate(x ...fr.Element) fr.Element
+	// Degree returns the total degree of g.
+	Degree() int
+}
+
+// ProductGate is the gate (x₁, …, xₖ) ↦ x₁⋅…⋅xₖ, where k = NbInputs.
+type ProductGate struct {
+	NbInputs int
+}
+
+// Evaluate returns the product of the inputs.
+func (g ProductGate) Evaluate(x ...fr.Element) fr.Element {
+	var res fr.Element
+	res.SetOne()
+	for i := range x {
+		res.Mul(&res, &x[i])
+	}
+	return res
+}
+
+// Degree returns the number of inputs.
+func (g ProductGate) Degree() int {
+	return g.NbInputs
+}
+
+// gateClaims is the prover side of the statements
+//
+//	∑_{x∈{0,1}ⁿ} g(Pⱼ,₁(x), …, Pⱼ,ₖ(x)) = cⱼ for 1 ≤ j ≤ m
+type gateClaims struct {
+	gate             Gate
+	tables           [][]fr.Vector // tables[j][i] holds the evaluations of Pⱼ,ᵢ, folded as the challenges come
+	combinationCoeff fr.Element
+	nbVars           int
+}
+
+// NewClaims returns the prover side of the statements
+//
+//	∑_{x∈{0,1}ⁿ} g(Pⱼ,₁(x), …, Pⱼ,ₖ(x)) = cⱼ for 1 ≤ j ≤ m
+//
+// where g is the gate and Pⱼ,ᵢ is the multilinear polynomial whose evaluations
+// on the hypercube are inputs[j][i]. The inputs are copied and left untouched.
+func NewClaims(gate Gate, inputs [][]polynomial.MultiLin) (Claims, error) {
+	if len(inputs) == 0 {
+		return nil, errNoClaims
+	}
+	nbInputs := len(inputs[0])
+	if nbInputs == 0 {
+		return nil, errNoInputs
+	}
+	size := len(inputs[0][0])
+	if size < 2 || size&(size-1) != 0 {
+		return nil, errNotPowerOfTwo
+	}
+
+	c := &gateClaims{
+		gate:   gate,
+		tables: make([][]fr.Vector, len(inputs)),
+		nbVars: bits.TrailingZeros(uint(size)),
+	}
+	for j := range inputs {
+		if len(inputs[j]) != nbInputs {
+			return nil, errInconsistentInputs
+		}
+		c.tables[j] = make([]fr.Vector, nbInputs)
+		for i := range inputs[j] {
+			if len(inputs[j][i]) != size {
+				return nil, errInconsistentInputs
+			}
+			c.tables[j][i] = lift(inputs[j][i])
+		}
+	}
+	return c, nil
+}
+
+func (c *gateClaims) NbVars() int {
+	return c.nbVars
+}
+
+func (c *gateClaims) NbClaims() int {
+	return len(c.tables)
+}
+
+func (c *gateClaims) Combine(a fr.Element) fr.Vector {
+	c.combinationCoeff = a
+	return c.computeGJ()
+}
+
+func (c *gateClaims) Next(r fr.Element) fr.Vector {
+	c.fold(&r)
+	return c.computeGJ()
+}
+
+// ProverFinalEval returns the evaluations Pⱼ,ᵢ(r₁, …, rₙ), flattened as
+// evaluations[j⋅k+i].
+func (c *gateClaims) ProverFinalEval(r []fr.Element) fr.Vector {
+	c.fold(&r[len(r)-1])
+	nbInputs := len(c.tables[0])
+	evaluations := make(fr.Vector, len(c.tables)*nbInputs)
+	for j := range c.tables {
+		for i := range c.tables[j] {
+			evaluations[j*nbInputs+i] = c.tables[j][i][0]
+		}
+	}
+	return evaluations
+}
+
+// fold sets the current variable to r in all the tables
+func (c *gateClaims) fold(r *fr.Element) {
+	for j := range c.tables {
+		for i := range c.tables[j] {
+			c.tables[j][i] = fold(c.tables[j][i], r)
+		}
+	}
+}
+
+// computeGJ returns the evaluations at 1, …, deg(g) of the partial sum polynomial
+//
+//	gⱼ(X) = ∑_{1≤l≤m} aˡ⁻¹ ∑_{x∈{0,1}ⁿ⁻ʲ} g(Pₗ,₁(X, x), …, Pₗ,ₖ(X, x))
+//
+// where the Pₗ,ᵢ have already been folded on the first j-1 variables.
+func (c *gateClaims) computeGJ() fr.Vector {
+	degree := c.gate.Degree()
+	nbInputs := len(c.tables[0])
+	mid := len(c.tables[0][0]) / 2
+
+	res := make(fr.Vector, degree)
+	var lock sync.Mutex
+
+	parallel.Execute(mid, func(start, end int) {
+		partial := make(fr.Vector, degree)
+		claimPartial := make(fr.Vector, degree)
+		values := make([]fr.Element, nbInputs)
+		steps := make([]fr.Element, nbInputs)
+		var coeff, v fr.Element
+		coeff.SetOne()
+
+		for j := range c.tables {
+			for e := range claimPartial {
+				claimPartial[e].SetZero()
+			}
+			for x := start; x < end; x++ {
+				// Pₗ,ᵢ(X, x) = Pₗ,ᵢ(0, x) + X⋅(Pₗ,ᵢ(1, x) - Pₗ,ᵢ(0, x))
+				for i, t := range c.tables[j] {
+					values[i] = t[mid+x]
+					steps[i].Sub(&t[mid+x], &t[x])
+				}
+				for e := range degree {
+					if e != 0 {
+						for i := range values {
+							values[i].Add(&values[i], &steps[i])
+						}
+					}
+					v = c.gate.Evaluate(values...)
+					claimPartial[e].Add(&claimPartial[e], &v)
+				}
+			}
+			for e := range partial {
+				v.Mul(&claimPartial[e], &coeff)
+				partial[e].Add(&partial[e], &v)
+			}
+			coeff.Mul(&coeff, &c.combinationCoeff)
+		}
+
+		lock.Lock()
+		for e := range res {
+			res[e].Add(&res[e], &partial[e])
+		}
+		lock.Unlock()
+	})
+
+	return res
+}
+
+// lazyGateClaims is the verifier side of the statements
+//
+//	∑_{x∈{0,1}ⁿ} g(Pⱼ,₁(x), …, Pⱼ,ₖ(x)) = cⱼ for 1 ≤ j ≤ m
+type lazyGateClaims struct {
+	gate             Gate
+	sums             []fr.Element
+	nbVars           int
+	checkEvaluations func(r, evaluations []fr.Element) error
+}
+
+// NewLazyClaims returns the verifier side of the statements
+//
+//	∑_{x∈{0,1}ⁿ} g(Pⱼ,₁(x), …, Pⱼ,ₖ(x)) = sums[j] for 1 ≤ j ≤ m
+//
+// At the end of the protocol, the prover provides the evaluations Pⱼ,ᵢ(r) at
+// the random point r = (r₁, …, rₙ), flattened as evaluations[j⋅k+i]. The
+// verifier checks that they are consistent with the last round, then calls
+// checkEvaluations(r, evaluations), which must ensure that they are correct,
+// e.g. by opening commitments to the Pⱼ,ᵢ. If checkEvaluations is nil, the
+// evaluations must be checked by other means.
+func NewLazyClaims(gate Gate, sums []fr.Element, nbVars int, checkEvaluations func(r, evaluations []fr.Element) error) LazyClaims {
+	return &lazyGateClaims{
+		gate:             gate,
+		sums:             sums,
+		nbVars:           nbVars,
+		checkEvaluations: checkEvaluations,
+	}
+}
+
+func (c *lazyGateClaims) NbClaims() int {
+	return len(c.sums)
+}
+
+func (c *lazyGateClaims) NbVars() int {
+	return c.nbVars
+}
+
+func (c *lazyGateClaims) CombinedSum(a fr.Element) fr.Element {
+	// Horner: c₁ + a(c₂ + a(…))
+	var res fr.Element
+	for j := len(c.sums) - 1; j >= 0; j-- {
+		res.Mul(&res, &a)
+		res.Add(&res, &c.sums[j])
+	}
+	return res
+}
+
+func (c *lazyGateClaims) Degree(int) int {
+	return c.gate.Degree()
+}
+
+func (c *lazyGateClaims) VerifyFinalEval(r []fr.Element, combinationCoeff, purportedValue fr.Element, proof fr.Vector) error {
+	m := len(c.sums)
+	if m == 0 || len(proof) == 0 || len(proof)%m != 0 {
+		return errWrongFinalEval
+	}
+	nbInputs := len(proof) / m
+
+	// ∑_{1≤j≤m} aʲ⁻¹ g(Pⱼ,₁(r), …, Pⱼ,ₖ(r))
+	var res, v fr.Element
+	for j := m - 1; j >= 0; j-- {
+		v = c.gate.Evaluate(proof[j*nbInputs : (j+1)*nbInputs]...)
+		res.Mul(&res, &combinationCoeff)
+		res.Add(&res, &v)
+	}
+	if !res.Equal(&purportedValue) {
+		return errFinalEvalMismatch
+	}
+
+	if c.checkEvaluations != nil {
+		return c.checkEvaluations(r, proof)
+	}
+	return nil
+}
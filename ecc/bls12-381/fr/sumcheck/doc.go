@@ -0,0 +1,21 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package sumcheck implements the sumcheck protocol for multilinear polynomials.
+//
+// The prover convinces the verifier that
+//
+//	∑_{x∈{0,1}ⁿ} g(P₁(x), …, Pₖ(x)) = c
+//
+// where the Pᵢ are multilinear polynomials given by their evaluations on the
+// hypercube and g is a low degree gate (e.g. a product). Several such claims
+// can be proven at once, they are combined with a random coefficient.
+//
+// The protocol is made non-interactive with a Fiat-Shamir transcript.
+//
+// At the end of the protocol, the verifier is left with claimed evaluations of
+// the Pᵢ at a random point; checking them (e.g. with a polynomial commitment
+// opening) is the responsibility of the caller.
+package sumcheck
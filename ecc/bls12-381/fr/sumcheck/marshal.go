@@ -0,0 +1,67 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// WriteTo writes the binary encoding of the proof to w: the number of rounds
+// as a big-endian uint32, followed by the round polynomials and the final
+// evaluation proof, each encoded as a vector.
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(proof.PartialSumPolys))); err != nil {
+		return 0, err
+	}
+	n := int64(4)
+	for i := range proof.PartialSumPolys {
+		m, err := proof.PartialSumPolys[i].WriteTo(w)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err := proof.FinalEvalProof.WriteTo(w)
+	return n + m, err
+}
+
+// ReadFrom reads the binary encoding of a proof from r, as written by WriteTo.
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var nbRounds uint32
+	if err := binary.Read(r, binary.BigEndian, &nbRounds); err != nil {
+		return 0, err
+	}
+	n := int64(4)
+	proof.PartialSumPolys = make([]fr.Vector, nbRounds)
+	for i := range proof.PartialSumPolys {
+		m, err := proof.PartialSumPolys[i].ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err := proof.FinalEvalProof.ReadFrom(r)
+	return n + m, err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (proof *Proof) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (proof *Proof) UnmarshalBinary(data []byte) error {
+	_, err := proof.ReadFrom(bytes.NewReader(data))
+	return err
+}
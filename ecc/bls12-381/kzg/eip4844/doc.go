@@ -0,0 +1,16 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package eip4844 implements the KZG commitments to blobs of EIP-4844
+// (Deneb), following the polynomial commitments specification of the
+// Ethereum consensus layer and the API of c-kzg-4844.
+//
+// A blob is a polynomial of degree less than FieldElementsPerBlob, given by
+// its evaluations on the roots of unity in bit-reversed order, each encoded
+// on 32 bytes in big-endian. Commitments and proofs are compressed G₁ points.
+//
+// The trusted setup of Ethereum is loaded with ReadTrustedSetup, from the JSON
+// file of the consensus specifications.
+//
+// See https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/polynomial-commitments.md
+package eip4844
@@ -0,0 +1,345 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSetup   *TrustedSetup
+	testContext *Context
+	setupOnce   sync.Once
+)
+
+func getContext(t testing.TB) (*TrustedSetup, *Context) {
+	setupOnce.Do(func() {
+		var err error
+		testSetup, err = NewTrustedSetup(big.NewInt(42))
+		require.NoError(t, err)
+		testContext, err = NewContext(testSetup)
+		require.NoError(t, err)
+	})
+	return testSetup, testContext
+}
+
+// randomBlob returns a random blob and its evaluations
+func randomBlob() (*Blob, []fr.Element) {
+	var blob Blob
+	p := make([]fr.Element, FieldElementsPerBlob)
+	for i := range p {
+		p[i].MustSetRandom()
+		b := p[i].Bytes()
+		copy(blob[i*BytesPerFieldElement:], b[:])
+	}
+	return &blob, p
+}
+
+// toCanonical returns the coefficients of p in evaluation form
+func toCanonical(p []fr.Element) []fr.Element {
+	coeffs := make([]fr.Element, len(p))
+	copy(coeffs, p)
+	fft.BitReverse(coeffs)
+	domain := fft.NewDomain(FieldElementsPerBlob)
+	domain.FFTInverse(coeffs, fft.DIF)
+	fft.BitReverse(coeffs)
+	return coeffs
+}
+
+func TestBlobToKZGCommitment(t *testing.T) {
+	assert := require.New(t)
+	ts, ctx := getContext(t)
+
+	blob, p := randomBlob()
+	commitment, err := ctx.BlobToKZGCommitment(blob)
+	assert.NoError(err)
+
+	// the commitment is the KZG commitment to the polynomial in canonical form
+	expected, err := kzg.Commit(toCanonical(p), kzg.ProvingKey{G1: ts.G1Monomial})
+	assert.NoError(err)
+	assert.Equal(KZGCommitment(expected.Bytes()), commitment)
+
+	// the empty blob commits to the point at infinity
+	commitment, err = ctx.BlobToKZGCommitment(&Blob{})
+	assert.NoError(err)
+	assert.Equal(byte(0xc0), commitment[0])
+
+	// non canonical field elements are rejected
+	for i := range BytesPerFieldElement {
+		blob[i] = 0xff
+	}
+	_, err = ctx.BlobToKZGCommitment(blob)
+	assert.ErrorIs(err, ErrInvalidFieldElement)
+}
+
+func TestComputeKZGProof(t *testing.T) {
+	assert := require.New(t)
+	_, ctx := getContext(t)
+
+	blob, p := randomBlob()
+	commitment, err := ctx.BlobToKZGCommitment(blob)
+	assert.NoError(err)
+	coeffs := toCanonical(p)
+
+	var z fr.Element
+	z.MustSetRandom()
+	roots := RootsOfUnity()
+	for _, point := range []fr.Element{z, roots[0], roots[17]} {
+		proof, yBytes, err := ctx.ComputeKZGProof(blob, point.Bytes())
+		assert.NoError(err)
+
+		var expected fr.Element
+		for i := len(coeffs) - 1; i >= 0; i-- {
+			expected.Mul(&expected, &point).Add(&expected, &coeffs[i])
+		}
+		assert.Equal(expected.Bytes(), [32]byte(yBytes))
+
+		ok, err := ctx.VerifyKZGProof(Bytes48(commitment), point.Bytes(), yBytes, Bytes48(proof))
+		assert.NoError(err)
+		assert.True(ok)
+
+		expected.Add(&expected, &expected)
+		ok, err = ctx.VerifyKZGProof(Bytes48(commitment), point.Bytes(), expected.Bytes(), Bytes48(proof))
+		assert.NoError(err)
+		assert.False(ok)
+	}
+
+	_, _, err = ctx.ComputeKZGProof(blob, Bytes32{0xff})
+	assert.ErrorIs(err, ErrInvalidFieldElement)
+	_, err = ctx.VerifyKZGProof(Bytes48{}, Bytes32{}, Bytes32{}, Bytes48{})
+	assert.ErrorIs(err, ErrInvalidPoint)
+}
+
+func TestBlobKZGProof(t *testing.T) {
+	assert := require.New(t)
+	_, ctx := getContext(t)
+
+	const n = 4
+	blobs := make([]Blob, n)
+	commitments := make([]Bytes48, n)
+	proofs := make([]Bytes48, n)
+	for i := range blobs {
+		blob, _ := randomBlob()
+		blobs[i] = *blob
+		c, err := ctx.BlobToKZGCommitment(blob)
+		assert.NoError(err)
+		commitments[i] = Bytes48(c)
+		proof, err := ctx.ComputeBlobKZGProof(blob, commitments[i])
+		assert.NoError(err)
+		proofs[i] = Bytes48(proof)
+
+		ok, err := ctx.VerifyBlobKZGProof(blob, commitments[i], proofs[i])
+		assert.NoError(err)
+		assert.True(ok)
+	}
+
+	ok, err := ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	assert.NoError(err)
+	assert.True(ok)
+
+	ok, err = ctx.VerifyBlobKZGProofBatch(nil, nil, nil)
+	assert.NoError(err)
+	assert.True(ok)
+
+	// swapped proofs
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	ok, err = ctx.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[0])
+	assert.NoError(err)
+	assert.False(ok)
+	ok, err = ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	assert.NoError(err)
+	assert.False(ok)
+
+	_, err = ctx.VerifyBlobKZGProofBatch(blobs, commitments[1:], proofs)
+	assert.ErrorIs(err, ErrInvalidBatchLength)
+}
+
+// invalidPoints are encodings of G₁ points rejected by the specifications
+var invalidPoints = map[string]string{
+	"not_in_G1":                  "8123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	"not_on_curve":               "8123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcde0",
+	"x_equal_to_modulus":         "9a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+	"x_greater_than_modulus":     "9a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaac",
+	"wrong_c_flag":               "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	"b_flag_and_x_nonzero":       "c123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	"b_flag_and_a_flag_true":     "e00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	"infinity_with_false_b_flag": "800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	"infinity_with_nonzero_x":    "c01000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+}
+
+// invalidFieldElements are encodings of field elements rejected by the
+// specifications
+var invalidFieldElements = map[string]string{
+	"modulus":          "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+	"modulus_plus_one": "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000002",
+	"all_ones":         "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+}
+
+func TestInvalidInputs(t *testing.T) {
+	_, ctx := getContext(t)
+
+	blob, _ := randomBlob()
+	commitment, err := ctx.BlobToKZGCommitment(blob)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, Bytes48(commitment))
+	require.NoError(t, err)
+	var z Bytes32
+
+	for name, encoded := range invalidPoints {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			var p Bytes48
+			assert.NoError(decodeHex(p[:], encoded))
+
+			_, err := ctx.ComputeBlobKZGProof(blob, p)
+			assert.ErrorIs(err, ErrInvalidPoint)
+			_, err = ctx.VerifyKZGProof(p, z, z, Bytes48(proof))
+			assert.ErrorIs(err, ErrInvalidPoint)
+			_, err = ctx.VerifyKZGProof(Bytes48(commitment), z, z, p)
+			assert.ErrorIs(err, ErrInvalidPoint)
+			_, err = ctx.VerifyBlobKZGProof(blob, p, Bytes48(proof))
+			assert.ErrorIs(err, ErrInvalidPoint)
+			_, err = ctx.VerifyBlobKZGProof(blob, Bytes48(commitment), p)
+			assert.ErrorIs(err, ErrInvalidPoint)
+			_, err = ctx.VerifyBlobKZGProofBatch([]Blob{*blob}, []Bytes48{p}, []Bytes48{Bytes48(proof)})
+			assert.ErrorIs(err, ErrInvalidPoint)
+			_, err = ctx.VerifyBlobKZGProofBatch([]Blob{*blob}, []Bytes48{Bytes48(commitment)}, []Bytes48{p})
+			assert.ErrorIs(err, ErrInvalidPoint)
+		})
+	}
+
+	for name, encoded := range invalidFieldElements {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			var e Bytes32
+			assert.NoError(decodeHex(e[:], encoded))
+
+			// the last element of the blob is not canonical
+			invalid := *blob
+			copy(invalid[BytesPerBlob-BytesPerFieldElement:], e[:])
+			_, err := ctx.BlobToKZGCommitment(&invalid)
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+			_, _, err = ctx.ComputeKZGProof(&invalid, z)
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+			_, err = ctx.ComputeBlobKZGProof(&invalid, Bytes48(commitment))
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+			_, err = ctx.VerifyBlobKZGProof(&invalid, Bytes48(commitment), Bytes48(proof))
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+			_, err = ctx.VerifyBlobKZGProofBatch([]Blob{invalid}, []Bytes48{Bytes48(commitment)}, []Bytes48{Bytes48(proof)})
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+
+			_, _, err = ctx.ComputeKZGProof(blob, e)
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+			_, err = ctx.VerifyKZGProof(Bytes48(commitment), e, z, Bytes48(proof))
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+			_, err = ctx.VerifyKZGProof(Bytes48(commitment), z, e, Bytes48(proof))
+			assert.ErrorIs(err, ErrInvalidFieldElement)
+		})
+	}
+}
+
+func TestInfinity(t *testing.T) {
+	assert := require.New(t)
+	_, ctx := getContext(t)
+
+	// the zero blob commits to the point at infinity, and so do its proofs
+	var infinity Bytes48
+	infinity[0] = 0xc0
+	commitment, err := ctx.BlobToKZGCommitment(&Blob{})
+	assert.NoError(err)
+	assert.Equal(infinity, Bytes48(commitment))
+	proof, err := ctx.ComputeBlobKZGProof(&Blob{}, infinity)
+	assert.NoError(err)
+	assert.Equal(infinity, Bytes48(proof))
+
+	var z, y Bytes32
+	z[31] = 5
+	ok, err := ctx.VerifyKZGProof(infinity, z, y, infinity)
+	assert.NoError(err)
+	assert.True(ok)
+	y[31] = 1
+	ok, err = ctx.VerifyKZGProof(infinity, z, y, infinity)
+	assert.NoError(err)
+	assert.False(ok)
+
+	ok, err = ctx.VerifyBlobKZGProof(&Blob{}, infinity, infinity)
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = ctx.VerifyBlobKZGProofBatch([]Blob{{}, {}}, []Bytes48{infinity, infinity}, []Bytes48{infinity, infinity})
+	assert.NoError(err)
+	assert.True(ok)
+
+	// a constant blob has an infinity proof, but not an infinity commitment
+	var constant Blob
+	for i := range FieldElementsPerBlob {
+		constant[(i+1)*BytesPerFieldElement-1] = 7
+	}
+	commitment, err = ctx.BlobToKZGCommitment(&constant)
+	assert.NoError(err)
+	proof, err = ctx.ComputeBlobKZGProof(&constant, Bytes48(commitment))
+	assert.NoError(err)
+	assert.Equal(infinity, Bytes48(proof))
+	ok, err = ctx.VerifyBlobKZGProof(&constant, Bytes48(commitment), infinity)
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = ctx.VerifyBlobKZGProof(&constant, infinity, infinity)
+	assert.NoError(err)
+	assert.False(ok)
+}
+
+func TestTrustedSetupJSON(t *testing.T) {
+	assert := require.New(t)
+	ts, _ := getContext(t)
+
+	var buf bytes.Buffer
+	_, err := ts.WriteTo(&buf)
+	assert.NoError(err)
+	read, err := ReadTrustedSetup(bytes.NewReader(buf.Bytes()))
+	assert.NoError(err)
+	assert.Equal(ts, read)
+
+	// the Lagrange basis is computed if absent
+	buf.Reset()
+	_, err = (&TrustedSetup{G1Monomial: ts.G1Monomial, G2Monomial: ts.G2Monomial}).WriteTo(&buf)
+	assert.NoError(err)
+	read, err = ReadTrustedSetup(&buf)
+	assert.NoError(err)
+	assert.Equal(ts.G1Lagrange, read.G1Lagrange)
+
+	_, err = ReadTrustedSetup(bytes.NewReader([]byte(`{"g1_lagrange": ["0x00"]}`)))
+	assert.ErrorIs(err, ErrInvalidTrustedSetup)
+}
+
+func BenchmarkBlobKZGProof(b *testing.B) {
+	_, ctx := getContext(b)
+	blob, _ := randomBlob()
+	commitment, err := ctx.BlobToKZGCommitment(blob)
+	require.NoError(b, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, Bytes48(commitment))
+	require.NoError(b, err)
+
+	b.Run("BlobToKZGCommitment", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = ctx.BlobToKZGCommitment(blob)
+		}
+	})
+	b.Run("ComputeBlobKZGProof", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = ctx.ComputeBlobKZGProof(blob, Bytes48(commitment))
+		}
+	})
+	b.Run("VerifyBlobKZGProof", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = ctx.VerifyBlobKZGProof(blob, Bytes48(commitment), Bytes48(proof))
+		}
+	})
+}
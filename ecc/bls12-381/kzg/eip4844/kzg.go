@@ -0,0 +1,397 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+const (
+	// FieldElementsPerBlob is the number of field elements of a blob.
+	FieldElementsPerBlob = 4096
+	// BytesPerFieldElement is the size in bytes of an encoded field element.
+	BytesPerFieldElement = 32
+	// BytesPerBlob is the size in bytes of a blob.
+	BytesPerBlob = FieldElementsPerBlob * BytesPerFieldElement
+	// BytesPerCommitment is the size in bytes of a commitment.
+	BytesPerCommitment = bls12381.SizeOfG1AffineCompressed
+	// BytesPerProof is the size in bytes of a proof.
+	BytesPerProof = bls12381.SizeOfG1AffineCompressed
+)
+
+// Domain separators of the Fiat-Shamir challenges.
+const (
+	FiatShamirProtocolDomain      = "FSBLOBVERIFY_V1_"
+	RandomChallengeKZGBatchDomain = "RCKZGBATCH___V1_"
+)
+
+var (
+	ErrInvalidFieldElement = errors.New("field element is not canonical")
+	ErrInvalidPoint        = errors.New("invalid encoding of a G1 point")
+	ErrInvalidBatchLength  = errors.New("the numbers of blobs, commitments and proofs differ")
+)
+
+// Bytes32 is a big-endian encoded field element.
+type Bytes32 [32]byte
+
+// Bytes48 is a compressed G₁ point.
+type Bytes48 [48]byte
+
+// Blob is a polynomial in evaluation form, on the roots of unity in
+// bit-reversed order.
+type Blob [BytesPerBlob]byte
+
+// KZGCommitment is a commitment to a blob.
+type KZGCommitment Bytes48
+
+// KZGProof is an opening proof of a blob.
+type KZGProof Bytes48
+
+// Context holds the trusted setup in the form used by the prover and the
+// verifier. A Context is safe for concurrent use.
+type Context struct {
+	vk kzg.VerifyingKey
+	// lagrange is the Lagrange basis of the setup, in bit-reversed order
+	lagrange []bls12381.G1Affine
+	// roots are the roots of unity, in bit-reversed order
+	roots []fr.Element
+	// invWidth is 1/FieldElementsPerBlob
+	invWidth fr.Element
+}
+
+// NewContext returns the context of the trusted setup ts.
+func NewContext(ts *TrustedSetup) (*Context, error) {
+	if len(ts.G1Lagrange) != FieldElementsPerBlob || len(ts.G2Monomial) < 2 {
+		return nil, ErrInvalidTrustedSetup
+	}
+	ctx := &Context{
+		vk:       ts.VerifyingKey(),
+		lagrange: make([]bls12381.G1Affine, FieldElementsPerBlob),
+		roots:    RootsOfUnity(),
+	}
+	copy(ctx.lagrange, ts.G1Lagrange)
+	fft.BitReverse(ctx.lagrange)
+	ctx.invWidth.SetUint64(FieldElementsPerBlob).Inverse(&ctx.invWidth)
+	return ctx, nil
+}
+
+// RootsOfUnity returns the roots of unity of order FieldElementsPerBlob, in
+// bit-reversed order, on which blobs are evaluated.
+func RootsOfUnity() []fr.Element {
+	domain := fft.NewDomain(FieldElementsPerBlob)
+	roots := make([]fr.Element, FieldElementsPerBlob)
+	roots[0].SetOne()
+	for i := 1; i < len(roots); i++ {
+		roots[i].Mul(&roots[i-1], &domain.Generator)
+	}
+	fft.BitReverse(roots)
+	return roots
+}
+
+// BlobToPolynomial decodes the evaluations of a blob.
+func BlobToPolynomial(blob *Blob) ([]fr.Element, error) {
+	p := make([]fr.Element, FieldElementsPerBlob)
+	for i := range p {
+		if err := p[i].SetBytesCanonical(blob[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement]); err != nil {
+			return nil, ErrInvalidFieldElement
+		}
+	}
+	return p, nil
+}
+
+// BlobToKZGCommitment returns the commitment to blob.
+func (ctx *Context) BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+	p, err := BlobToPolynomial(blob)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	c, err := multiExp(ctx.lagrange, p)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return KZGCommitment(c.Bytes()), nil
+}
+
+// ComputeKZGProof returns the proof of the evaluation of blob at z, and the
+// evaluation.
+func (ctx *Context) ComputeKZGProof(blob *Blob, zBytes Bytes32) (KZGProof, Bytes32, error) {
+	p, err := BlobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	var z fr.Element
+	if err := z.SetBytesCanonical(zBytes[:]); err != nil {
+		return KZGProof{}, Bytes32{}, ErrInvalidFieldElement
+	}
+	proof, y, err := ctx.computeKZGProof(p, &z)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	return proof, y.Bytes(), nil
+}
+
+// ComputeBlobKZGProof returns the proof of the evaluation of blob at the
+// challenge derived from blob and its commitment, for VerifyBlobKZGProof.
+func (ctx *Context) ComputeBlobKZGProof(blob *Blob, commitmentBytes Bytes48) (KZGProof, error) {
+	if _, err := decodePoint(commitmentBytes); err != nil {
+		return KZGProof{}, err
+	}
+	p, err := BlobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	z := computeChallenge(blob, commitmentBytes)
+	proof, _, err := ctx.computeKZGProof(p, &z)
+	return proof, err
+}
+
+// VerifyKZGProof returns true if proof proves that the blob committed to by
+// commitment evaluates to y at z.
+func (ctx *Context) VerifyKZGProof(commitmentBytes Bytes48, zBytes, yBytes Bytes32, proofBytes Bytes48) (bool, error) {
+	commitment, err := decodePoint(commitmentBytes)
+	if err != nil {
+		return false, err
+	}
+	proof, err := decodePoint(proofBytes)
+	if err != nil {
+		return false, err
+	}
+	var z, y fr.Element
+	if z.SetBytesCanonical(zBytes[:]) != nil || y.SetBytesCanonical(yBytes[:]) != nil {
+		return false, ErrInvalidFieldElement
+	}
+	return ctx.verifyKZGProof(&commitment, &z, &y, &proof)
+}
+
+// VerifyBlobKZGProof returns true if proof proves that commitment is the
+// commitment to blob.
+func (ctx *Context) VerifyBlobKZGProof(blob *Blob, commitmentBytes, proofBytes Bytes48) (bool, error) {
+	commitment, err := decodePoint(commitmentBytes)
+	if err != nil {
+		return false, err
+	}
+	proof, err := decodePoint(proofBytes)
+	if err != nil {
+		return false, err
+	}
+	p, err := BlobToPolynomial(blob)
+	if err != nil {
+		return false, err
+	}
+	z := computeChallenge(blob, commitmentBytes)
+	y := ctx.evaluate(p, &z)
+	return ctx.verifyKZGProof(&commitment, &z, &y, &proof)
+}
+
+// VerifyBlobKZGProofBatch returns true if proofs[i] proves that
+// commitments[i] is the commitment to blobs[i], for all i. The proofs are
+// checked at once with a random linear combination.
+func (ctx *Context) VerifyBlobKZGProofBatch(blobs []Blob, commitmentsBytes, proofsBytes []Bytes48) (bool, error) {
+	n := len(blobs)
+	if len(commitmentsBytes) != n || len(proofsBytes) != n {
+		return false, ErrInvalidBatchLength
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	commitments := make([]bls12381.G1Affine, n)
+	proofs := make([]bls12381.G1Affine, n)
+	zs := make([]fr.Element, n)
+	ys := make([]fr.Element, n)
+	for i := range blobs {
+		var err error
+		if commitments[i], err = decodePoint(commitmentsBytes[i]); err != nil {
+			return false, err
+		}
+		if proofs[i], err = decodePoint(proofsBytes[i]); err != nil {
+			return false, err
+		}
+		p, err := BlobToPolynomial(&blobs[i])
+		if err != nil {
+			return false, err
+		}
+		zs[i] = computeChallenge(&blobs[i], commitmentsBytes[i])
+		ys[i] = ctx.evaluate(p, &zs[i])
+	}
+	return ctx.verifyKZGProofBatch(commitments, zs, ys, proofs)
+}
+
+// computeKZGProof returns the proof of the evaluation of p at z, and the
+// evaluation
+func (ctx *Context) computeKZGProof(p []fr.Element, z *fr.Element) (KZGProof, fr.Element, error) {
+	y := ctx.evaluate(p, z)
+
+	// q = (p - y) / (X - z) in evaluation form. At a root ωₘ = z, the
+	// quotient is ∑_{i≠m} (pᵢ - y)·ωᵢ / (z·(z - ωᵢ)).
+	q := make([]fr.Element, FieldElementsPerBlob)
+	m := -1
+	for i := range q {
+		q[i].Sub(&ctx.roots[i], z)
+		if q[i].IsZero() {
+			m = i
+		}
+	}
+	q = fr.BatchInvert(q)
+	var t fr.Element
+	for i := range q {
+		t.Sub(&p[i], &y)
+		q[i].Mul(&q[i], &t)
+	}
+	if m >= 0 {
+		// (pᵢ - y)/(ωᵢ - z) · ωᵢ / z = -(pᵢ - y)·ωᵢ / (z·(z - ωᵢ))
+		var invZ fr.Element
+		invZ.Inverse(z)
+		q[m].SetZero()
+		for i := range q {
+			if i == m {
+				continue
+			}
+			t.Mul(&q[i], &ctx.roots[i]).Mul(&t, &invZ)
+			q[m].Sub(&q[m], &t)
+		}
+	}
+
+	proof, err := multiExp(ctx.lagrange, q)
+	if err != nil {
+		return KZGProof{}, fr.Element{}, err
+	}
+	return KZGProof(proof.Bytes()), y, nil
+}
+
+// evaluate returns p(z) for p in evaluation form, with the barycentric formula
+//
+//	p(z) = (zⁿ - 1)/n · ∑ᵢ pᵢ·ωᵢ/(z - ωᵢ)
+func (ctx *Context) evaluate(p []fr.Element, z *fr.Element) fr.Element {
+	den := make([]fr.Element, FieldElementsPerBlob)
+	for i := range den {
+		den[i].Sub(z, &ctx.roots[i])
+		if den[i].IsZero() {
+			return p[i]
+		}
+	}
+	den = fr.BatchInvert(den)
+	var res, t fr.Element
+	for i := range den {
+		t.Mul(&p[i], &ctx.roots[i]).Mul(&t, &den[i])
+		res.Add(&res, &t)
+	}
+	zn := *z
+	for range bits.TrailingZeros(FieldElementsPerBlob) {
+		zn.Square(&zn)
+	}
+	var one fr.Element
+	one.SetOne()
+	zn.Sub(&zn, &one).Mul(&zn, &ctx.invWidth)
+	return *res.Mul(&res, &zn)
+}
+
+// verifyKZGProof checks the opening proof of commitment at z to y
+func (ctx *Context) verifyKZGProof(commitment *bls12381.G1Affine, z, y *fr.Element, proof *bls12381.G1Affine) (bool, error) {
+	err := kzg.Verify(commitment, &kzg.OpeningProof{H: *proof, ClaimedValue: *y}, *z, ctx.vk)
+	if errors.Is(err, kzg.ErrVerifyOpeningProof) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// verifyKZGProofBatch checks the opening proofs of commitments[i] at zs[i] to
+// ys[i], with the check
+//
+//	e(∑ rⁱπᵢ, -[τ]G₂)·e(∑ rⁱ(Cᵢ - [yᵢ]G₁ + zᵢπᵢ), G₂) = 1
+func (ctx *Context) verifyKZGProofBatch(commitments []bls12381.G1Affine, zs, ys []fr.Element, proofs []bls12381.G1Affine) (bool, error) {
+	n := len(commitments)
+	h := sha256.New()
+	h.Write([]byte(RandomChallengeKZGBatchDomain))
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], FieldElementsPerBlob)
+	h.Write(buf[:])
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	h.Write(buf[:])
+	for i := range commitments {
+		c := commitments[i].Bytes()
+		z := zs[i].Bytes()
+		y := ys[i].Bytes()
+		pi := proofs[i].Bytes()
+		h.Write(c[:])
+		h.Write(z[:])
+		h.Write(y[:])
+		h.Write(pi[:])
+	}
+	var r fr.Element
+	r.SetBytes(h.Sum(nil))
+
+	// ∑ rⁱπᵢ and ∑ rⁱ(Cᵢ + zᵢπᵢ) - (∑ rⁱyᵢ)G₁
+	powers := make([]fr.Element, n)
+	powers[0].SetOne()
+	for i := 1; i < n; i++ {
+		powers[i].Mul(&powers[i-1], &r)
+	}
+	proofLincomb, err := multiExp(proofs, powers)
+	if err != nil {
+		return false, err
+	}
+	points := make([]bls12381.G1Affine, 0, 2*n+1)
+	scalars := make([]fr.Element, 0, 2*n+1)
+	var sumY, t fr.Element
+	for i := range commitments {
+		points = append(points, commitments[i], proofs[i])
+		scalars = append(scalars, powers[i], *t.Mul(&powers[i], &zs[i]))
+		t.Mul(&powers[i], &ys[i])
+		sumY.Add(&sumY, &t)
+	}
+	points = append(points, ctx.vk.G1)
+	scalars = append(scalars, *sumY.Neg(&sumY))
+	rhs, err := multiExp(points, scalars)
+	if err != nil {
+		return false, err
+	}
+
+	var negTau bls12381.G2Affine
+	negTau.Neg(&ctx.vk.G2[1])
+	return bls12381.PairingCheck(
+		[]bls12381.G1Affine{proofLincomb, rhs},
+		[]bls12381.G2Affine{negTau, ctx.vk.G2[0]},
+	)
+}
+
+// computeChallenge returns the evaluation point of a blob, hashed with its
+// commitment
+func computeChallenge(blob *Blob, commitment Bytes48) fr.Element {
+	h := sha256.New()
+	h.Write([]byte(FiatShamirProtocolDomain))
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], FieldElementsPerBlob)
+	h.Write(degree[:])
+	h.Write(blob[:])
+	h.Write(commitment[:])
+	var z fr.Element
+	z.SetBytes(h.Sum(nil))
+	return z
+}
+
+// decodePoint decodes a compressed G₁ point, checked to be in the subgroup
+func decodePoint(b Bytes48) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+	if _, err := p.SetBytes(b[:]); err != nil {
+		return p, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// multiExp returns ∑ scalars[i]·points[i]
+func multiExp(points []bls12381.G1Affine, scalars []fr.Element) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	_, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	return res, err
+}
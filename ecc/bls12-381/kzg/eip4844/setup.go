@@ -0,0 +1,179 @@
+// Copyright 2020-2026 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/consensys/gnark-crypto/parallel"
+)
+
+// NbG2Points is the number of G₂ points of the trusted setup of Ethereum.
+const NbG2Points = 65
+
+var ErrInvalidTrustedSetup = errors.New("invalid trusted setup")
+
+// TrustedSetup is a KZG setup for blobs, [τⁱ]G₁ and [τⁱ]G₂ for a secret τ.
+type TrustedSetup struct {
+	// G1Monomial are the [τⁱ]G₁ for i < FieldElementsPerBlob.
+	G1Monomial []bls12381.G1Affine
+	// G1Lagrange are the [Lᵢ(τ)]G₁, where Lᵢ is the i-th Lagrange polynomial
+	// of the roots of unity in natural order.
+	G1Lagrange []bls12381.G1Affine
+	// G2Monomial are the [τⁱ]G₂ for i < NbG2Points.
+	G2Monomial []bls12381.G2Affine
+}
+
+// trustedSetupJSON is the format of trusted_setup_4096.json: hexadecimal
+// encodings of the compressed points, prefixed by 0x.
+type trustedSetupJSON struct {
+	G1Monomial []string `json:"g1_monomial"`
+	G1Lagrange []string `json:"g1_lagrange"`
+	G2Monomial []string `json:"g2_monomial"`
+}
+
+// ReadTrustedSetup reads a trusted setup in the JSON format of the consensus
+// specifications. The points are checked to be in the subgroups. The Lagrange
+// basis is computed from the monomial one if absent.
+func ReadTrustedSetup(r io.Reader) (*TrustedSetup, error) {
+	var encoded trustedSetupJSON
+	if err := json.NewDecoder(r).Decode(&encoded); err != nil {
+		return nil, err
+	}
+	if len(encoded.G2Monomial) != NbG2Points ||
+		(len(encoded.G1Monomial) != FieldElementsPerBlob && len(encoded.G1Monomial) != 0) ||
+		(len(encoded.G1Lagrange) != FieldElementsPerBlob && len(encoded.G1Lagrange) != 0) ||
+		len(encoded.G1Monomial)+len(encoded.G1Lagrange) == 0 {
+		return nil, ErrInvalidTrustedSetup
+	}
+
+	var ts TrustedSetup
+	var err error
+	if ts.G1Monomial, err = decodePoints[bls12381.G1Affine](encoded.G1Monomial); err != nil {
+		return nil, err
+	}
+	if ts.G1Lagrange, err = decodePoints[bls12381.G1Affine](encoded.G1Lagrange); err != nil {
+		return nil, err
+	}
+	if ts.G2Monomial, err = decodePoints[bls12381.G2Affine](encoded.G2Monomial); err != nil {
+		return nil, err
+	}
+	if len(ts.G1Lagrange) == 0 {
+		if ts.G1Lagrange, err = kzg.ToLagrangeG1(ts.G1Monomial); err != nil {
+			return nil, err
+		}
+	}
+	return &ts, nil
+}
+
+// WriteTo writes the trusted setup in the JSON format of the consensus
+// specifications.
+func (ts *TrustedSetup) WriteTo(w io.Writer) (int64, error) {
+	encoded := trustedSetupJSON{
+		G1Monomial: encodePoints(ts.G1Monomial),
+		G1Lagrange: encodePoints(ts.G1Lagrange),
+		G2Monomial: encodePoints(ts.G2Monomial),
+	}
+	b, err := json.Marshal(&encoded)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// NewTrustedSetup returns the trusted setup for the secret tau.
+//
+// In production, the trusted setup of Ethereum, generated through MPC, must
+// be used.
+func NewTrustedSetup(tau *big.Int) (*TrustedSetup, error) {
+	srs, err := kzg.NewSRS(FieldElementsPerBlob, tau)
+	if err != nil {
+		return nil, err
+	}
+	ts := TrustedSetup{G1Monomial: srs.Pk.G1}
+	if ts.G1Lagrange, err = kzg.ToLagrangeG1(srs.Pk.G1); err != nil {
+		return nil, err
+	}
+
+	var t fr.Element
+	t.SetBigInt(tau)
+	powers := make([]fr.Element, NbG2Points-1)
+	powers[0] = t
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &t)
+	}
+	_, _, _, g2 := bls12381.Generators()
+	ts.G2Monomial = append([]bls12381.G2Affine{g2}, bls12381.BatchScalarMultiplicationG2(&g2, powers)...)
+	return &ts, nil
+}
+
+// VerifyingKey returns the KZG verifying key of the trusted setup.
+func (ts *TrustedSetup) VerifyingKey() kzg.VerifyingKey {
+	var vk kzg.VerifyingKey
+	_, _, vk.G1, _ = bls12381.Generators()
+	vk.G2[0] = ts.G2Monomial[0]
+	vk.G2[1] = ts.G2Monomial[1]
+	vk.Lines[0] = bls12381.PrecomputeLines(vk.G2[0])
+	vk.Lines[1] = bls12381.PrecomputeLines(vk.G2[1])
+	return vk
+}
+
+type point interface {
+	bls12381.G1Affine | bls12381.G2Affine
+}
+
+// decodePoints decodes the hexadecimal encodings of compressed points
+func decodePoints[T point](encoded []string) ([]T, error) {
+	res := make([]T, len(encoded))
+	errs := make([]error, len(encoded))
+	parallel.Execute(len(encoded), func(start, end int) {
+		for i := start; i < end; i++ {
+			b, err := hex.DecodeString(strings.TrimPrefix(encoded[i], "0x"))
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			var n int
+			switch p := any(&res[i]).(type) {
+			case *bls12381.G1Affine:
+				n, err = p.SetBytes(b)
+			case *bls12381.G2Affine:
+				n, err = p.SetBytes(b)
+			}
+			if err == nil && n != len(b) {
+				err = ErrInvalidTrustedSetup
+			}
+			errs[i] = err
+		}
+	})
+	return res, errors.Join(errs...)
+}
+
+// encodePoints returns the hexadecimal encodings of the compressed points
+func encodePoints[T point](points []T) []string {
+	if len(points) == 0 {
+		return nil
+	}
+	res := make([]string, len(points))
+	for i := range points {
+		switch p := any(&points[i]).(type) {
+		case *bls12381.G1Affine:
+			b := p.Bytes()
+			res[i] = "0x" + hex.EncodeToString(b[:])
+		case *bls12381.G2Affine:
+			b := p.Bytes()
+			res[i] = "0x" + hex.EncodeToString(b[:])
+		}
+	}
+	return res
+}